@@ -24,9 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/auth/login": {
+        "/api/v1/admin/foods": {
             "post": {
-                "description": "Authenticate a user and return access and refresh tokens",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a food to the catalog. The ID is generated when omitted, the barcode is normalized to GTIN-13 and the Nutri-Score, NOVA group and ingredient tree are computed. Records a create revision.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,23 +39,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Login user",
+                "summary": "Create a food",
                 "parameters": [
                     {
-                        "description": "User credentials",
-                        "name": "credentials",
+                        "description": "Food",
+                        "name": "food",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.LoginRequest"
+                            "$ref": "#/definitions/docs.AdminFoodRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -60,7 +65,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.TokenResponse"
+                                            "$ref": "#/definitions/docs.FoodDetailResponse"
                                         }
                                     }
                                 }
@@ -79,6 +84,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -88,28 +105,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/logout": {
-            "post": {
-                "description": "Revoke a refresh token to log out a user",
-                "consumes": [
-                    "application/json"
+        "/api/v1/admin/foods/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List pairs of foods that are likely the same food, most likely first. Candidates share a barcode or have similar names; each pair is scored from 0 to 1 by barcode, name similarity and the distance between their nutrients per 100g. A food_type or food_id is required.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Logout user",
+                "summary": "List likely duplicate foods",
                 "parameters": [
                     {
-                        "description": "Refresh token to revoke",
-                        "name": "refresh_token",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.RefreshTokenRequest"
-                        }
+                        "type": "string",
+                        "description": "Only pairs of foods of this type (food_type or food_id is required)",
+                        "name": "food_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only pairs including this food (food_type or food_id is required)",
+                        "name": "food_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.6,
+                        "description": "Minimum score, 0 to 1",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.5,
+                        "description": "Minimum name similarity of candidates without a shared barcode, 0 to 1",
+                        "name": "min_name_similarity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of pairs, at most 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -124,7 +167,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.MessageResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.DuplicatePairResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -143,6 +189,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -152,9 +204,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/refresh": {
-            "post": {
-                "description": "Refresh an expired access token using a valid refresh token",
+        "/api/v1/admin/foods/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retire a food, removing it from search and listings. Its ratings, saved foods and intake logs are kept, and a delete revision holding its last state is recorded, which can be reverted.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete a food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch (RFC 7386) to a food. Nested objects such as nutrition_100g are merged key by key and null removes a value, so {\"nutrition_100g\": {\"protein\": 12.5}} corrects one nutrient. Derived fields are recomputed. Records an update revision unless nothing changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -162,17 +269,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Refresh token",
+                "summary": "Update a food",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "refresh_token",
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch of editable food fields",
+                        "name": "patch",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.RefreshTokenRequest"
+                            "type": "object"
                         }
                     }
                 ],
@@ -188,7 +302,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.TokenResponse"
+                                            "$ref": "#/definitions/docs.FoodDetailResponse"
                                         }
                                     }
                                 }
@@ -207,6 +321,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -216,9 +342,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/register": {
+        "/api/v1/admin/foods/{id}/merge": {
             "post": {
-                "description": "Register a new user in the system",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Merge a food into the food that survives: its ratings, saved foods and intake logs move to the target, along with translations into locales the target lacks. The merged food is deleted, and its ID redirects to the target from then on. Recorded as a merge revision of the merged food, which cannot be reverted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -226,23 +357,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register new user",
+                "summary": "Merge a duplicate food",
                 "parameters": [
                     {
-                        "description": "User registration information",
-                        "name": "user",
+                        "type": "string",
+                        "description": "ID of the food to merge away",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Food to merge into",
+                        "name": "merge",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.RegisterRequest"
+                            "$ref": "#/definitions/docs.MergeFoodsRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -252,7 +390,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.MessageResponse"
+                                            "$ref": "#/definitions/docs.MergeResultResponse"
                                         }
                                     }
                                 }
@@ -265,8 +403,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -280,25 +430,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/foods": {
+        "/api/v1/admin/foods/{id}/revisions": {
             "get": {
-                "description": "Search for foods by name or other criteria",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List the revisions of a food, newest first, with author, changed fields and timestamp. Deleted foods keep their history.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "admin"
                 ],
-                "summary": "Search foods",
+                "summary": "List food revisions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query"
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -309,7 +462,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 10,
+                        "default": 20,
                         "description": "Number of items per page",
                         "name": "limit",
                         "in": "query"
@@ -329,7 +482,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/docs.FoodResponse"
+                                                "$ref": "#/definitions/docs.FoodRevisionResponse"
                                             }
                                         },
                                         "meta": {
@@ -340,8 +493,14 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -355,40 +514,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/foods/category/{category}": {
+        "/api/v1/admin/foods/{id}/revisions/{revisionId}": {
             "get": {
-                "description": "Get foods filtered by category",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get one revision of a food with its full before and after states",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "admin"
                 ],
-                "summary": "Get foods by category",
+                "summary": "Get a food revision",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Food category",
-                        "name": "category",
+                        "description": "Food ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "revisionId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -403,11 +557,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        },
-                                        "meta": {
-                                            "$ref": "#/definitions/docs.PaginationMeta"
+                                            "$ref": "#/definitions/docs.FoodRevisionResponse"
                                         }
                                     }
                                 }
@@ -420,6 +570,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -429,40 +597,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/foods/{foodId}/rate": {
+        "/api/v1/admin/foods/{id}/revisions/{revisionId}/revert": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add or update a user's rating for a food",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Restore a food to its state before a revision: reverting a create retires the food, reverting a delete restores it and reverting an update restores the previous values of the fields it changed, keeping later edits to other fields. The revert is recorded as a new revision.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "admin"
                 ],
-                "summary": "Rate a food",
+                "summary": "Revert a food revision",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Food ID",
-                        "name": "foodId",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Rating information",
-                        "name": "rating",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.RatingRequest"
-                        }
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "revisionId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -477,7 +640,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.RatingResponse"
+                                            "$ref": "#/definitions/docs.FoodRevisionResponse"
                                         }
                                     }
                                 }
@@ -496,6 +659,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -511,19 +680,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/foods/{id}": {
+        "/api/v1/admin/foods/{id}/translations": {
             "get": {
-                "description": "Get detailed information about a specific food",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List a food's names, alternate names and descriptions in locales other than English",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "admin"
                 ],
-                "summary": "Get food by ID",
+                "summary": "List food translations",
                 "parameters": [
                     {
                         "type": "string",
@@ -545,13 +716,28 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.FoodDetailResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.FoodTranslationResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -567,14 +753,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/profiles/user/me": {
-            "get": {
+        "/api/v1/admin/foods/{id}/translations/{locale}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all profiles for the authenticated user",
+                "description": "Create or replace a food's name, alternate names and description in a locale. English is edited on the food itself.",
                 "consumes": [
                     "application/json"
                 ],
@@ -582,9 +768,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "admin"
+                ],
+                "summary": "Set a food translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "fr",
+                            "de",
+                            "ak",
+                            "ee",
+                            "gaa"
+                        ],
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translation",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.FoodTranslationRequest"
+                        }
+                    }
                 ],
-                "summary": "List user profiles",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -597,63 +815,29 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/docs.ProfileResponse"
-                                            }
+                                            "$ref": "#/definitions/docs.FoodTranslationResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/profiles/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a profile by its ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "profiles"
-                ],
-                "summary": "Get profile by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Profile ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/docs.ProfileResponse"
+                            "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
                     "404": {
@@ -670,47 +854,36 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing profile",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Remove a food's translation into a locale, so that it is shown in English there",
                 "tags": [
-                    "profiles"
+                    "admin"
                 ],
-                "summary": "Update profile",
+                "summary": "Delete a food translation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Profile ID",
+                        "description": "Food ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated profile information",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.ProfileRequest"
-                        }
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/docs.ProfileResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -718,8 +891,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -731,14 +910,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a profile by its ID",
+            }
+        },
+        "/api/v1/auth/login": {
+            "post": {
+                "description": "Authenticate a user and return access and refresh tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -746,36 +922,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "auth"
                 ],
-                "summary": "Delete profile",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Profile ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/docs.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -789,9 +976,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/profiles/{userId}": {
+        "/api/v1/auth/logout": {
             "post": {
-                "description": "Create a new profile for a user",
+                "description": "Revoke a refresh token to log out a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -799,32 +986,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profiles"
+                    "auth"
                 ],
-                "summary": "Create user profile",
+                "summary": "Logout user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Profile information",
-                        "name": "profile",
+                        "description": "Refresh token to revoke",
+                        "name": "refresh_token",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.ProfileRequest"
+                            "$ref": "#/definitions/docs.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/docs.ProfileResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.MessageResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -833,8 +1025,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
@@ -848,14 +1040,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/recommendations": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get personalized food recommendations for the authenticated user",
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "Refresh an expired access token using a valid refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -863,29 +1050,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recommendations"
+                    "auth"
                 ],
-                "summary": "Get food recommendations",
+                "summary": "Refresh token",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of recommendations to return",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Profile ID to use for recommendations",
-                        "name": "profileId",
-                        "in": "query"
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -900,13 +1076,19 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.RecommendationResponse"
+                                            "$ref": "#/definitions/docs.TokenResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -922,14 +1104,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/recommendations/alternatives/{foodId}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get alternative food suggestions for a specific food item",
+        "/api/v1/auth/register": {
+            "post": {
+                "description": "Register a new user in the system",
                 "consumes": [
                     "application/json"
                 ],
@@ -937,28 +1114,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recommendations"
+                    "auth"
                 ],
-                "summary": "Get food alternatives",
+                "summary": "Register new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Food ID",
-                        "name": "foodId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 5,
-                        "description": "Number of alternatives to return",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "User registration information",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -968,7 +1140,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.AlternativesResponse"
+                                            "$ref": "#/definitions/docs.MessageResponse"
                                         }
                                     }
                                 }
@@ -981,6 +1153,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/docs.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -990,35 +1168,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/recommendations/filter": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Filter food recommendations based on specific criteria",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/categories": {
+            "get": {
+                "description": "List the distinct food types in the catalog with counts, and the category taxonomy with the number of foods under each category including its subcategories",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recommendations"
-                ],
-                "summary": "Filter food recommendations",
-                "parameters": [
-                    {
-                        "description": "Filter criteria",
-                        "name": "filter",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.RecommendationRequest"
-                        }
-                    }
+                    "foods"
                 ],
+                "summary": "List food categories",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1031,13 +1190,77 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/docs.RecommendationResponse"
+                                            "$ref": "#/definitions/docs.CategoryListingResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports/foods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream all foods in the catalog, or those of a food type or updated since a time, as NDJSON (one food per line) or as CSV with a column per nutrient named after its key and unit, e.g. protein_g",
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Export the food catalog",
+                "parameters": [
+                    {
+                        "enum": [
+                            "ndjson",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods of this type",
+                        "name": "food_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods updated at or after this time, RFC 3339 or YYYY-MM-DD",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also export retired foods, with their retired_at",
+                        "name": "include_retired",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported foods",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -1059,19 +1282,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/reference/allergens": {
+        "/api/v1/food-submissions": {
             "get": {
-                "description": "Get a list of all allergens",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List the current user's food submissions and their review status, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reference"
+                    "submissions"
+                ],
+                "summary": "List my food submissions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Review status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get allergens",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1086,14 +1338,29 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/docs.ReferenceItem"
+                                                "$ref": "#/definitions/docs.FoodSubmissionResponse"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/docs.PaginationMeta"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1101,11 +1368,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/reference/dietary-patterns": {
-            "get": {
-                "description": "Get a list of all dietary patterns",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit a food missing from the catalog. It stays pending, and out of search, until a moderator approves it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1113,10 +1383,91 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reference"
+                    "submissions"
                 ],
-                "summary": "Get dietary patterns",
-                "responses": {
+                "summary": "Submit a food",
+                "parameters": [
+                    {
+                        "description": "Food",
+                        "name": "submission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.FoodSubmissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodSubmissionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/food-submissions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the current user's food submissions, including the rejection reason when rejected",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submissions"
+                ],
+                "summary": "Get my food submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
@@ -1128,16 +1479,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/docs.ReferenceItem"
-                                            }
+                                            "$ref": "#/definitions/docs.FoodSubmissionResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1147,9 +1513,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/reference/health-conditions": {
+        "/api/v1/foods": {
             "get": {
-                "description": "Get a list of all health conditions",
+                "description": "Full-text search over food names, alternate names, descriptions and ingredients, ranked by relevance. Supports quoted phrases, OR and -exclusions, and falls back to typo-tolerant name matching when nothing matches. Without q, lists all foods.\nStructured filters narrow the results. With facets=true, meta.facets holds counts per food type and label plus nutrient histograms for the matching foods.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1157,9 +1523,96 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reference"
+                    "foods"
+                ],
+                "summary": "Search foods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Food type",
+                        "name": "food_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated labels",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Match any or all labels",
+                        "name": "labels_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only foods with (true) or without (false) an EAN-13 barcode",
+                        "name": "has_barcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated Nutri-Score grades, e.g. A,B",
+                        "name": "nutri_score",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "nutri_score"
+                        ],
+                        "type": "string",
+                        "default": "relevance",
+                        "description": "Result order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also return facet counts for the matching foods",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum per 100g; any nutrient key accepts _min and _max, e.g. sodium_max=300",
+                        "name": "protein_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "meta.next_cursor of the previous page; continues after it instead of using page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get health conditions",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1174,14 +1627,82 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/docs.ReferenceItem"
+                                                "$ref": "#/definitions/docs.FoodResponse"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/docs.SearchMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/foods/barcode/{code}": {
+            "get": {
+                "description": "Look up a food by EAN-13, EAN-8, UPC-A or UPC-E barcode. The check digit is validated and the code normalized to GTIN-13 before lookup.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get food by barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodDetailResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1190,159 +1711,4404 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "docs.AlternativesResponse": {
+        },
+        "/api/v1/foods/category/{category}": {
+            "get": {
+                "description": "Get foods in a taxonomy category, including its subcategories, or with a given food_type when category is not a category slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get foods by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category slug or food type",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "meta.next_cursor of the previous page; continues after it instead of using page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/docs.PaginationMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/foods/compare": {
+            "post": {
+                "description": "Compare 2 to 10 foods side by side: an aligned per-100g nutrient table with percent differences relative to the first food and the best food per nutrient, plus label and allergen differences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Compare foods",
+                "parameters": [
+                    {
+                        "description": "Foods to compare",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CompareFoodsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodComparisonResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/foods/suggest": {
+            "get": {
+                "description": "Suggest food names and alternate names with a word starting with q, with the matched prefix highlighted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Autocomplete food names",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name prefix",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of suggestions",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.SuggestionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/foods/{foodId}/rate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add or update a user's rating for a food",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Rate a food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "foodId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating information",
+                        "name": "rating",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RatingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.RatingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/foods/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific food",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get food by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "301": {
+                        "description": "The food was merged into another food, named by the Location header"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the households the authenticated user owns or has a profile in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "List households",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.HouseholdResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a household owned by the authenticated user, optionally adding some of their profiles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Create household",
+                "parameters": [
+                    {
+                        "description": "Household",
+                        "name": "household",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.HouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.HouseholdResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List pending household invitations sent to the authenticated user's email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "List household invitations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.HouseholdInvitationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/invitations/{invitationId}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept an invitation by adding one of the authenticated user's profiles to the household",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Accept household invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile to add",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.HouseholdMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.HouseholdResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/invitations/{invitationId}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Decline an invitation sent to the authenticated user",
+                "tags": [
+                    "households"
+                ],
+                "summary": "Decline household invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a household and its member profiles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Get household",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.HouseholdResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a household. Only the owner can delete it; member profiles are not affected.",
+                "tags": [
+                    "households"
+                ],
+                "summary": "Delete household",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/{id}/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite another user, by email, to add one of their profiles to the household. Owner only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Invite to household",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitee",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.HouseholdInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.HouseholdInvitationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/{id}/meal-plan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Build one meal plan that excludes every member's allergens and dietary restrictions, with per-member portions scaled to each calorie target",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Get household meal plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Number of days, at most 30",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.HouseholdMealPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/{id}/members": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add one of the authenticated user's profiles to a household they belong to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Add household member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile to add",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.HouseholdMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.HouseholdResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/households/{id}/members/{profileId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a profile from a household. The owner can remove any profile; other members only their own.",
+                "tags": [
+                    "households"
+                ],
+                "summary": "Remove household member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "profileId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/moderation/submissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List food submissions with a status, oldest first. Defaults to the pending queue.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "List food submissions for moderation",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Review status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.FoodSubmissionResponse"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/docs.PaginationMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/moderation/submissions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get any food submission",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Get a food submission for moderation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodSubmissionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a JSON merge patch (RFC 7386) to the food of a pending submission before approving it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Edit a food submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch of editable food fields",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodSubmissionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/moderation/submissions/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Promote a pending submission into the food catalog. The created food's ID is returned in food_id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Approve a food submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodSubmissionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/moderation/submissions/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending submission with a reason shown to the submitter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "Reject a food submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RejectSubmissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.FoodSubmissionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/user/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all profiles for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "List user profiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.ProfileResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a profile by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Get profile by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProfileResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Update profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated profile information",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a profile by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Delete profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/{id}/calorie-adjustments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List automatic calorie target changes made for a profile from its weight trend",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "measurements"
+                ],
+                "summary": "List calorie target adjustments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.CalorieAdjustmentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/{id}/gaps": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare a profile's average daily intake against targets derived from age/sex reference intakes, the calorie target and health conditions, and suggest foods that close each deficit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Get nutrient gap report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "intake",
+                        "description": "Intake source: intake (logged food) or meal_plan",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD) for source=intake",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD) for source=intake",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Number of meal plan days for source=meal_plan, at most 30",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of suggested foods per gap",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.GapReportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/{id}/intake": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List a profile's logged intake between two dates (inclusive)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "intake"
+                ],
+                "summary": "List food intake",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), defaults to 6 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.IntakeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record an amount of a food eaten by a profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "intake"
+                ],
+                "summary": "Log food intake",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Intake entry",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.IntakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.IntakeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/{id}/intake/{entryId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a logged intake entry",
+                "tags": [
+                    "intake"
+                ],
+                "summary": "Delete intake entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Intake entry ID",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/profiles/{userId}": {
+            "post": {
+                "description": "Create a new profile for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Create user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile information",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get personalized food recommendations for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Get food recommendations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of recommendations to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination.next_cursor of the previous page; continues after it instead of using offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile ID to use for recommendations",
+                        "name": "profileId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.RecommendationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations/alternatives/{foodId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get alternative food suggestions for a specific food item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Get food alternatives",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "foodId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of alternatives to return",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.AlternativesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations/check": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Look up a food by ID or EAN-13 barcode and evaluate it against the user's default or specified profile. The verdict is avoid when an allergen or dietary restriction is violated, caution for any other violated rule, and safe otherwise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Check food compatibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID (food_id or ean is required)",
+                        "name": "food_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "EAN-13, EAN-8, UPC-A or UPC-E barcode",
+                        "name": "ean",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile ID to check against, defaults to the default profile",
+                        "name": "profileId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.CheckResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Filter food recommendations based on specific criteria",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Filter food recommendations",
+                "parameters": [
+                    {
+                        "description": "Filter criteria",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RecommendationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.RecommendationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reference/allergens": {
+            "get": {
+                "description": "Get a list of all allergens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reference"
+                ],
+                "summary": "Get allergens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.ReferenceItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reference/dietary-patterns": {
+            "get": {
+                "description": "Get a list of all dietary patterns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reference"
+                ],
+                "summary": "Get dietary patterns",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.ReferenceItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reference/food-categories": {
+            "get": {
+                "description": "Get the hierarchical food category taxonomy with the food types mapped to each category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reference"
+                ],
+                "summary": "Get food category taxonomy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.FoodCategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reference/health-conditions": {
+            "get": {
+                "description": "Get a list of all health conditions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reference"
+                ],
+                "summary": "Get health conditions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/docs.ReferenceItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/me/locale": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the language food names and descriptions are shown in when signed in. It takes precedence over the Accept-Language header; foods without a translation are shown in English.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Set the preferred language",
+                "parameters": [
+                    {
+                        "description": "Preferred language",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UserLocaleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/docs.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/me/measurements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List measurements of one type with their exponential moving average trend and weekly rate of change",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "measurements"
+                ],
+                "summary": "List body measurements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "weight",
+                        "description": "Measurement type (weight, body_fat, waist, hip, chest, neck)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), defaults to 90 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.MeasurementSeriesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a weight or body measurement. Measurement times in the future are rejected. Weight readings update the smoothed trend and may adjust the default profile's calorie target when the trend drifts from its goal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "measurements"
+                ],
+                "summary": "Record body measurement",
+                "parameters": [
+                    {
+                        "description": "Measurement",
+                        "name": "measurement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.MeasurementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/docs.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/docs.MeasurementRecordResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/me/measurements/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a recorded measurement",
+                "tags": [
+                    "measurements"
+                ],
+                "summary": "Delete body measurement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Measurement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "docs.AdminFoodRequest": {
+            "type": "object",
+            "properties": {
+                "alternate_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "ean_13": {
+                    "type": "string",
+                    "example": "4006381333931"
+                },
+                "food_type": {
+                    "type": "string",
+                    "example": "yogurt"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "ingredient_analysis": {
+                    "$ref": "#/definitions/docs.IngredientAnalysisResponse"
+                },
+                "ingredients": {
+                    "type": "string",
+                    "example": "Milk, live cultures"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Greek yogurt, plain"
+                },
+                "nutrition_100g": {
+                    "$ref": "#/definitions/docs.NutrientsResponse"
+                },
+                "package_size": {
+                    "$ref": "#/definitions/docs.ServingResponse"
+                },
+                "serving": {
+                    "$ref": "#/definitions/docs.ServingResponse"
+                }
+            }
+        },
+        "docs.AlternativesResponse": {
+            "type": "object",
+            "properties": {
+                "alternatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FoodResponse"
+                    }
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.CalorieAdjustmentResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "goal_rate_kg_week": {
+                    "type": "number",
+                    "example": -0.5
+                },
+                "id": {
+                    "type": "string"
+                },
+                "new_target": {
+                    "type": "integer",
+                    "example": 2050
+                },
+                "observed_rate_kg_week": {
+                    "type": "number",
+                    "example": -0.1
+                },
+                "previous_target": {
+                    "type": "integer",
+                    "example": 2200
+                },
+                "profile_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CategoryListingResponse": {
+            "type": "object",
+            "properties": {
+                "food_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FoodTypeCount"
+                    }
+                },
+                "taxonomy": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FoodCategoryResponse"
+                    }
+                }
+            }
+        },
+        "docs.CheckResponse": {
+            "type": "object",
+            "properties": {
+                "food": {
+                    "$ref": "#/definitions/docs.FoodResponse"
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "verdict": {
+                    "type": "string",
+                    "example": "avoid"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ViolationResponse"
+                    }
+                }
+            }
+        },
+        "docs.CompareFoodsRequest": {
+            "type": "object",
+            "properties": {
+                "food_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "FOOD123",
+                        "FOOD456"
+                    ]
+                }
+            }
+        },
+        "docs.ComparedFoodResponse": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "food_type": {
+                    "type": "string",
+                    "example": "yogurt"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Greek yogurt, plain"
+                }
+            }
+        },
+        "docs.DuplicateFoodResponse": {
+            "type": "object",
+            "properties": {
+                "ean_13": {
+                    "type": "string",
+                    "example": "5901234123457"
+                },
+                "food_type": {
+                    "type": "string",
+                    "example": "everyday"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Oat Milk, Barista"
+                }
+            }
+        },
+        "docs.DuplicatePairResponse": {
+            "type": "object",
+            "properties": {
+                "foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.DuplicateFoodResponse"
+                    }
+                },
+                "name_similarity": {
+                    "type": "number",
+                    "example": 0.857
+                },
+                "nutrient_distance": {
+                    "type": "number",
+                    "example": 0.034
+                },
+                "same_barcode": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.912
+                }
+            }
+        },
+        "docs.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "docs.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1240
+                },
+                "value": {
+                    "type": "string",
+                    "example": "vegan"
+                }
+            }
+        },
+        "docs.Facets": {
+            "type": "object",
+            "properties": {
+                "food_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FacetCount"
+                    }
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FacetCount"
+                    }
+                },
+                "nutrients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.NutrientHistogram"
+                    }
+                }
+            }
+        },
+        "docs.FieldChangeResponse": {
+            "type": "object",
+            "properties": {
+                "new": {},
+                "old": {}
+            }
+        },
+        "docs.FoodAmountItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.FoodCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FoodCategoryResponse"
+                    }
+                },
+                "food_count": {
+                    "type": "integer",
+                    "example": 830
+                },
+                "food_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Cheese"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "cheese"
+                }
+            }
+        },
+        "docs.FoodComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "$ref": "#/definitions/docs.SetDifferenceResponse"
+                },
+                "foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ComparedFoodResponse"
+                    }
+                },
+                "labels": {
+                    "$ref": "#/definitions/docs.SetDifferenceResponse"
+                },
+                "nutrients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.NutrientComparisonResponse"
+                    }
+                }
+            }
+        },
+        "docs.FoodDetailResponse": {
+            "type": "object",
+            "properties": {
+                "allergen_info": {
+                    "type": "string"
+                },
+                "calories": {
+                    "type": "number"
+                },
+                "carbohydrates": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "fat": {
+                    "type": "number"
+                },
+                "fiber": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "ingredient_tree": {
+                    "$ref": "#/definitions/docs.IngredientListResponse"
+                },
+                "ingredients": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "fr"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nova_group": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "nova_markers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.NovaMarkerResponse"
+                    }
+                },
+                "nutri_score": {
+                    "$ref": "#/definitions/docs.NutriScoreResponse"
+                },
+                "nutri_score_grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "nutrition_100g": {
+                    "$ref": "#/definitions/docs.NutrientsResponse"
+                },
+                "nutrition_per_serving": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "package_size": {
+                    "$ref": "#/definitions/docs.ServingResponse"
+                },
+                "protein": {
+                    "type": "number"
+                },
+                "retired_at": {
+                    "description": "Set when a newer dataset release dropped the food or an admin deleted it",
+                    "type": "string"
+                },
+                "serving": {
+                    "$ref": "#/definitions/docs.ServingResponse"
+                },
+                "serving_size": {
+                    "type": "string"
+                },
+                "serving_size_unit": {
+                    "type": "string"
+                },
+                "sodium": {
+                    "type": "number"
+                },
+                "sugar": {
+                    "type": "number"
+                }
+            }
+        },
+        "docs.FoodResponse": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number"
+                },
+                "carbohydrates": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "fat": {
+                    "type": "number"
+                },
+                "fiber": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "fr"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nova_group": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "nutri_score_grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "protein": {
+                    "type": "number"
+                },
+                "sodium": {
+                    "type": "number"
+                },
+                "sugar": {
+                    "type": "number"
+                }
+            }
+        },
+        "docs.FoodRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete",
+                        "revert",
+                        "merge"
+                    ],
+                    "example": "update"
+                },
+                "after": {
+                    "$ref": "#/definitions/docs.FoodDetailResponse"
+                },
+                "author_id": {
+                    "type": "string",
+                    "example": "6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+                },
+                "before": {
+                    "$ref": "#/definitions/docs.FoodDetailResponse"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/docs.FieldChangeResponse"
+                    }
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4b7e2a1c-3f5d-4e8a-9c6b-1d2e3f4a5b6c"
+                },
+                "reverted_revision_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.FoodSubmissionRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "ean_13": {
+                    "type": "string",
+                    "example": "7394376616037"
+                },
+                "food_type": {
+                    "type": "string",
+                    "example": "plant_milk"
+                },
+                "ingredients": {
+                    "type": "string",
+                    "example": "Water, oats (10%), rapeseed oil, salt"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Oat drink, barista"
+                },
+                "nutrition_100g": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "serving": {
+                    "$ref": "#/definitions/docs.ServingResponse"
+                }
+            }
+        },
+        "docs.FoodSubmissionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "food": {
+                    "$ref": "#/definitions/docs.FoodDetailResponse"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8d2f1c3e-5b4a-4c7d-9e8f-0a1b2c3d4e5f"
+                },
+                "rejection_reason": {
+                    "type": "string",
+                    "example": "Nutrition values do not match the label"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "pending"
+                },
+                "submitter_id": {
+                    "type": "string",
+                    "example": "6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.FoodTranslationRequest": {
+            "type": "object",
+            "properties": {
+                "alternate_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Boisson à l'avoine, barista"
+                }
+            }
+        },
+        "docs.FoodTranslationResponse": {
+            "type": "object",
+            "properties": {
+                "alternate_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "fr"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Boisson à l'avoine, barista"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.FoodTypeCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 512
+                },
+                "food_type": {
+                    "type": "string",
+                    "example": "cheese"
+                }
+            }
+        },
+        "docs.GapReportResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "from": {
+                    "type": "string"
+                },
+                "gaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.NutrientGap"
+                    }
+                },
+                "met_targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "intake"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "unassessed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "docs.HistogramBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 310
+                },
+                "max": {
+                    "type": "number",
+                    "example": 20
+                },
+                "min": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "docs.HouseholdDailyPlan": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "Day 1"
+                },
+                "meals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.HouseholdMeal"
+                    }
+                }
+            }
+        },
+        "docs.HouseholdInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "partner@example.com"
+                }
+            }
+        },
+        "docs.HouseholdInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "household_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "invitee_email": {
+                    "type": "string",
+                    "example": "partner@example.com"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "docs.HouseholdMeal": {
+            "type": "object",
+            "properties": {
+                "foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FoodResponse"
+                    }
+                },
+                "portions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.MemberPortion"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "dinner"
+                }
+            }
+        },
+        "docs.HouseholdMealPlanResponse": {
+            "type": "object",
+            "properties": {
+                "applied_rules": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.HouseholdDailyPlan"
+                    }
+                },
+                "household_id": {
+                    "type": "string"
+                },
+                "total_days": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "docs.HouseholdMember": {
+            "type": "object",
+            "properties": {
+                "calorie_target": {
+                    "type": "integer",
+                    "example": 1600
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "profile_name": {
+                    "type": "string",
+                    "example": "Kids"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.HouseholdMemberRequest": {
+            "type": "object",
+            "properties": {
+                "profile_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.HouseholdRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "The Smiths"
+                },
+                "profile_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "docs.HouseholdResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.HouseholdMember"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "The Smiths"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.IngredientAnalysisResponse": {
+            "type": "object",
+            "properties": {
+                "additives": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "en:e330"
+                    ]
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "en:sugar"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "en:palm-oil-free"
+                    ]
+                }
+            }
+        },
+        "docs.IngredientListResponse": {
+            "type": "object",
+            "properties": {
+                "contains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.IngredientResponse"
+                    }
+                },
+                "may_contain": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "docs.IngredientResponse": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.IngredientResponse"
+                    }
+                },
+                "e_number": {
+                    "type": "string",
+                    "example": "E322"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "milk chocolate"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 12.5
+                }
+            }
+        },
+        "docs.IntakeRequest": {
+            "type": "object",
+            "properties": {
+                "amount_g": {
+                    "type": "number",
+                    "example": 150
+                },
+                "consumed_at": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "meal_type": {
+                    "type": "string",
+                    "example": "lunch"
+                }
+            }
+        },
+        "docs.IntakeResponse": {
+            "type": "object",
+            "properties": {
+                "amount_g": {
+                    "type": "number"
+                },
+                "consumed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meal_type": {
+                    "type": "string"
+                },
+                "profile_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "docs.MeasurementRecordResponse": {
+            "type": "object",
+            "properties": {
+                "calorie_adjustment": {
+                    "$ref": "#/definitions/docs.CalorieAdjustmentResponse"
+                },
+                "measurement": {
+                    "$ref": "#/definitions/docs.MeasurementResponse"
+                },
+                "trend": {
+                    "$ref": "#/definitions/docs.MeasurementSeriesResponse"
+                }
+            }
+        },
+        "docs.MeasurementRequest": {
+            "type": "object",
+            "properties": {
+                "measured_at": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "weight"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "docs.MeasurementResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "measured_at": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "weight"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "kg"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "docs.MeasurementSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "latest_trend": {
+                    "type": "number",
+                    "example": 82.9
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.TrendPoint"
+                    }
+                },
+                "rate_window_days": {
+                    "type": "integer",
+                    "example": 28
+                },
+                "sample_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "type": {
+                    "type": "string",
+                    "example": "weight"
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "kg"
+                },
+                "weekly_rate": {
+                    "type": "number",
+                    "example": -0.35
+                }
+            }
+        },
+        "docs.MemberPortion": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "example": 133.3
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "grams": {
+                    "type": "number",
+                    "example": 85.5
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "profile_name": {
+                    "type": "string",
+                    "example": "Kids"
+                }
+            }
+        },
+        "docs.MergeFoodsRequest": {
+            "type": "object",
+            "properties": {
+                "into": {
+                    "type": "string",
+                    "example": "FOOD456"
+                }
+            }
+        },
+        "docs.MergeResultResponse": {
+            "type": "object",
+            "properties": {
+                "intake_logs": {
+                    "type": "integer",
+                    "example": 37
+                },
+                "ratings": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "revision": {
+                    "$ref": "#/definitions/docs.FoodRevisionResponse"
+                },
+                "saved_foods": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "source_id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "target_id": {
+                    "type": "string",
+                    "example": "FOOD456"
+                },
+                "translations": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "docs.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.NovaMarkerResponse": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "marker": {
+                    "type": "string",
+                    "example": "E471"
+                }
+            }
+        },
+        "docs.NutriScorePointsResponse": {
+            "type": "object",
+            "properties": {
+                "energy": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "fibre": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "fruit_veg": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "negative": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "positive": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "protein": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "saturated_fat": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sodium": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sugars": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "docs.NutriScoreResponse": {
+            "type": "object",
+            "properties": {
+                "grade": {
+                    "type": "string",
+                    "example": "B"
+                },
+                "points": {
+                    "$ref": "#/definitions/docs.NutriScorePointsResponse"
+                },
+                "protein_counted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "docs.NutrientComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "best_food_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "better": {
+                    "type": "string",
+                    "example": "higher"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Protein"
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "protein"
+                },
+                "percent_diff": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "docs.NutrientGap": {
+            "type": "object",
+            "properties": {
+                "daily_average": {
+                    "type": "number",
+                    "example": 14.2
+                },
+                "gap": {
+                    "type": "number",
+                    "example": 11.8
+                },
+                "nutrient": {
+                    "type": "string",
+                    "example": "dietary_fiber"
+                },
+                "percent_of_target": {
+                    "type": "number",
+                    "example": 54.6
+                },
+                "status": {
+                    "type": "string",
+                    "example": "deficit"
+                },
+                "suggested_foods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FoodResponse"
+                    }
+                },
+                "target": {
+                    "$ref": "#/definitions/docs.NutrientTarget"
+                },
+                "top_contributors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.FoodAmountItem"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "docs.NutrientHistogram": {
             "type": "object",
             "properties": {
-                "alternatives": {
+                "buckets": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docs.FoodResponse"
+                        "$ref": "#/definitions/docs.HistogramBucket"
                     }
                 },
-                "food_id": {
-                    "type": "string"
+                "nutrient": {
+                    "type": "string",
+                    "example": "protein"
                 },
-                "limit": {
-                    "type": "integer"
+                "unit": {
+                    "type": "string",
+                    "example": "g"
                 }
             }
         },
-        "docs.ErrorResponse": {
+        "docs.NutrientTarget": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
+                "max": {
+                    "type": "number",
+                    "example": 2300
                 },
-                "details": {
-                    "type": "string"
+                "min": {
+                    "type": "number"
                 },
-                "error": {
-                    "type": "string"
+                "nutrient": {
+                    "type": "string",
+                    "example": "sodium"
                 },
-                "success": {
-                    "type": "boolean"
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "mg"
                 }
             }
         },
-        "docs.FoodDetailResponse": {
+        "docs.NutrientsResponse": {
             "type": "object",
             "properties": {
-                "allergen_info": {
-                    "type": "string"
+                "added_sugars": {
+                    "type": "number"
+                },
+                "calcium": {
+                    "type": "number",
+                    "example": 113
                 },
                 "calories": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 61
                 },
                 "carbohydrates": {
-                    "type": "number"
-                },
-                "category": {
-                    "type": "string"
+                    "type": "number",
+                    "example": 4.8
                 },
-                "fat": {
-                    "type": "number"
+                "cholesterol": {
+                    "type": "number",
+                    "example": 10
                 },
-                "fiber": {
+                "dietary_fiber": {
                     "type": "number"
                 },
-                "id": {
-                    "type": "string"
+                "folate": {
+                    "type": "number",
+                    "example": 5
                 },
-                "image_url": {
-                    "type": "string"
+                "fruits_vegetables_nuts": {
+                    "type": "number"
                 },
-                "ingredients": {
-                    "type": "string"
+                "iron": {
+                    "type": "number"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": true
+                "magnesium": {
+                    "type": "number",
+                    "example": 10
                 },
-                "name": {
-                    "type": "string"
+                "omega_3": {
+                    "type": "number"
                 },
-                "nutrition_per_serving": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "number"
-                    }
+                "potassium": {
+                    "type": "number",
+                    "example": 132
                 },
                 "protein": {
-                    "type": "number"
-                },
-                "serving_size": {
-                    "type": "string"
+                    "type": "number",
+                    "example": 3.2
                 },
-                "serving_size_unit": {
-                    "type": "string"
+                "saturated_fats": {
+                    "type": "number",
+                    "example": 1.9
                 },
                 "sodium": {
-                    "type": "number"
-                },
-                "sugar": {
-                    "type": "number"
-                }
-            }
-        },
-        "docs.FoodResponse": {
-            "type": "object",
-            "properties": {
-                "calories": {
-                    "type": "number"
-                },
-                "carbohydrates": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 43
                 },
-                "category": {
-                    "type": "string"
+                "total_fat": {
+                    "type": "number",
+                    "example": 3.3
                 },
-                "fat": {
-                    "type": "number"
+                "total_sugars": {
+                    "type": "number",
+                    "example": 5.1
                 },
-                "fiber": {
+                "trans_fats": {
                     "type": "number"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "image_url": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "vitamin_a": {
+                    "type": "number",
+                    "example": 46
                 },
-                "protein": {
-                    "type": "number"
+                "vitamin_b12": {
+                    "type": "number",
+                    "example": 0.45
                 },
-                "sodium": {
+                "vitamin_c": {
                     "type": "number"
                 },
-                "sugar": {
-                    "type": "number"
-                }
-            }
-        },
-        "docs.LoginRequest": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "vitamin_d": {
+                    "type": "number",
+                    "example": 1.3
                 },
-                "password": {
-                    "type": "string",
-                    "example": "password123"
-                }
-            }
-        },
-        "docs.MessageResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
+                "zinc": {
+                    "type": "number",
+                    "example": 0.4
                 }
             }
         },
@@ -1352,6 +6118,9 @@ const docTemplate = `{
                 "current_page": {
                     "type": "integer"
                 },
+                "next_cursor": {
+                    "type": "string"
+                },
                 "page_size": {
                     "type": "integer"
                 },
@@ -1519,6 +6288,9 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "cursor": {
+                    "type": "string"
+                },
                 "limit": {
                     "type": "integer"
                 },
@@ -1554,6 +6326,9 @@ const docTemplate = `{
                         "limit": {
                             "type": "integer"
                         },
+                        "next_cursor": {
+                            "type": "string"
+                        },
                         "offset": {
                             "type": "integer"
                         }
@@ -1613,6 +6388,15 @@ const docTemplate = `{
                 }
             }
         },
+        "docs.RejectSubmissionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Duplicate of an existing food"
+                }
+            }
+        },
         "docs.Response": {
             "type": "object",
             "properties": {
@@ -1623,6 +6407,123 @@ const docTemplate = `{
                 }
             }
         },
+        "docs.RuleResponse": {
+            "type": "object",
+            "properties": {
+                "operation": {
+                    "type": "string",
+                    "example": "exclude"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "target": {
+                    "type": "string",
+                    "example": "peanuts"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "allergen"
+                },
+                "value": {}
+            }
+        },
+        "docs.SearchMeta": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "description": "Only with facets=true",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/docs.Facets"
+                        }
+                    ]
+                },
+                "fuzzy": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJvIjoibmFtZSIsIm4iOiJBcHBsZSIsImkiOiJmZF8xIn0"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "docs.ServingResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1
+                },
+                "grams": {
+                    "type": "number",
+                    "example": 240
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "cup"
+                }
+            }
+        },
+        "docs.SetDifferenceResponse": {
+            "type": "object",
+            "properties": {
+                "common": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "only": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "docs.SuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "alternate": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "food_id": {
+                    "type": "string",
+                    "example": "FOOD123"
+                },
+                "highlighted": {
+                    "type": "string",
+                    "example": "\u003cem\u003eBroc\u003c/em\u003ecoli, raw"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Broccoli, raw"
+                }
+            }
+        },
         "docs.TokenResponse": {
             "type": "object",
             "properties": {
@@ -1639,6 +6540,63 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "docs.TrendPoint": {
+            "type": "object",
+            "properties": {
+                "measured_at": {
+                    "type": "string"
+                },
+                "trend": {
+                    "type": "number",
+                    "example": 82.9
+                },
+                "value": {
+                    "type": "number",
+                    "example": 82.4
+                }
+            }
+        },
+        "docs.UserLocaleRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "fr",
+                        "de",
+                        "ak",
+                        "ee",
+                        "gaa"
+                    ],
+                    "example": "fr"
+                }
+            }
+        },
+        "docs.ViolationResponse": {
+            "type": "object",
+            "properties": {
+                "matched": {
+                    "type": "string",
+                    "example": "roasted peanuts"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "contains allergen \"peanuts\""
+                },
+                "rule": {
+                    "$ref": "#/definitions/docs.RuleResponse"
+                },
+                "severity": {
+                    "type": "string",
+                    "example": "high"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 812.5
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1655,7 +6613,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
-	BasePath:         "/api/v1",
+	BasePath:         "/",
 	Schemes:          []string{"http", "https"},
 	Title:            "NutriMatch API",
 	Description:      "A personalized nutrition recommendation system built on the OpenNutrition dataset",
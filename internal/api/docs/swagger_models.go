@@ -83,6 +83,7 @@ type RecommendationRequest struct {
 	MaxFat      float64  `json:"max_fat,omitempty"`
 	Limit       int      `json:"limit,omitempty"`
 	Offset      int      `json:"offset,omitempty"`
+	Cursor      string   `json:"cursor,omitempty"`
 }
 
 // RecommendationResponse represents the response for food recommendations
@@ -91,8 +92,9 @@ type RecommendationResponse struct {
 	TotalCount   int            `json:"total_count"`
 	AppliedRules []string       `json:"applied_rules"`
 	Pagination   struct {
-		Limit  int `json:"limit"`
-		Offset int `json:"offset"`
+		Limit      int    `json:"limit"`
+		Offset     int    `json:"offset"`
+		NextCursor string `json:"next_cursor"`
 	} `json:"pagination"`
 }
 
@@ -115,38 +117,137 @@ type ReferenceItem struct {
 
 // FoodResponse represents a food item in the API response
 type FoodResponse struct {
-	ID            string  `json:"id"`
-	Name          string  `json:"name"`
-	Category      string  `json:"category"`
-	Calories      float64 `json:"calories"`
-	Protein       float64 `json:"protein"`
-	Carbohydrates float64 `json:"carbohydrates"`
-	Fat           float64 `json:"fat"`
-	Fiber         float64 `json:"fiber"`
-	Sugar         float64 `json:"sugar"`
-	Sodium        float64 `json:"sodium"`
-	ImageURL      string  `json:"image_url,omitempty"`
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	Locale          string  `json:"locale,omitempty" example:"fr"`
+	Category        string  `json:"category"`
+	Calories        float64 `json:"calories"`
+	Protein         float64 `json:"protein"`
+	Carbohydrates   float64 `json:"carbohydrates"`
+	Fat             float64 `json:"fat"`
+	Fiber           float64 `json:"fiber"`
+	Sugar           float64 `json:"sugar"`
+	Sodium          float64 `json:"sodium"`
+	NutriScoreGrade string  `json:"nutri_score_grade,omitempty" example:"B"`
+	NovaGroup       int     `json:"nova_group,omitempty" example:"3"`
+	ImageURL        string  `json:"image_url,omitempty"`
 }
 
 // FoodDetailResponse represents detailed information about a food item
 type FoodDetailResponse struct {
-	ID                  string                 `json:"id"`
-	Name                string                 `json:"name"`
-	Category            string                 `json:"category"`
-	Calories            float64                `json:"calories"`
-	Protein             float64                `json:"protein"`
-	Carbohydrates       float64                `json:"carbohydrates"`
-	Fat                 float64                `json:"fat"`
-	Fiber               float64                `json:"fiber"`
-	Sugar               float64                `json:"sugar"`
-	Sodium              float64                `json:"sodium"`
-	Ingredients         string                 `json:"ingredients,omitempty"`
-	AllergenInfo        string                 `json:"allergen_info,omitempty"`
-	ServingSize         string                 `json:"serving_size"`
-	ServingSizeUnit     string                 `json:"serving_size_unit"`
-	NutritionPerServing map[string]float64     `json:"nutrition_per_serving"`
-	ImageURL            string                 `json:"image_url,omitempty"`
-	Metadata            map[string]interface{} `json:"metadata,omitempty"`
+	ID                  string                  `json:"id"`
+	Name                string                  `json:"name"`
+	Locale              string                  `json:"locale,omitempty" example:"fr"`
+	Category            string                  `json:"category"`
+	Calories            float64                 `json:"calories"`
+	Protein             float64                 `json:"protein"`
+	Carbohydrates       float64                 `json:"carbohydrates"`
+	Fat                 float64                 `json:"fat"`
+	Fiber               float64                 `json:"fiber"`
+	Sugar               float64                 `json:"sugar"`
+	Sodium              float64                 `json:"sodium"`
+	Ingredients         string                  `json:"ingredients,omitempty"`
+	IngredientTree      *IngredientListResponse `json:"ingredient_tree,omitempty"`
+	AllergenInfo        string                  `json:"allergen_info,omitempty"`
+	ServingSize         string                  `json:"serving_size"`
+	ServingSizeUnit     string                  `json:"serving_size_unit"`
+	Serving             *ServingResponse        `json:"serving,omitempty"`
+	PackageSize         *ServingResponse        `json:"package_size,omitempty"`
+	Nutrition100g       *NutrientsResponse      `json:"nutrition_100g,omitempty"`
+	NutritionPerServing map[string]float64      `json:"nutrition_per_serving"`
+	NutriScoreGrade     string                  `json:"nutri_score_grade,omitempty" example:"B"`
+	NutriScore          *NutriScoreResponse     `json:"nutri_score,omitempty"`
+	NovaGroup           int                     `json:"nova_group,omitempty" example:"4"`
+	NovaMarkers         []NovaMarkerResponse    `json:"nova_markers,omitempty"`
+	ImageURL            string                  `json:"image_url,omitempty"`
+	Metadata            map[string]interface{}  `json:"metadata,omitempty"`
+	RetiredAt           *time.Time              `json:"retired_at,omitempty"` // Set when a newer dataset release dropped the food or an admin deleted it
+}
+
+// ServingResponse represents a serving or package size. Other details from
+// the source, such as separate common and metric measures, appear as extra keys.
+type ServingResponse struct {
+	Amount float64 `json:"amount,omitempty" example:"1"`
+	Unit   string  `json:"unit,omitempty" example:"cup"`
+	Grams  float64 `json:"grams,omitempty" example:"240"`
+}
+
+// IngredientAnalysisResponse represents what a source found in a food's
+// ingredients. Other details from the source appear as extra keys.
+type IngredientAnalysisResponse struct {
+	Additives   []string `json:"additives,omitempty" example:"en:e330"`
+	Ingredients []string `json:"ingredients,omitempty" example:"en:sugar"`
+	Tags        []string `json:"tags,omitempty" example:"en:palm-oil-free"`
+}
+
+// NutrientsResponse represents nutrient amounts per 100g. Nutrients without a
+// field, and aliases such as fat, appear as extra keys.
+type NutrientsResponse struct {
+	Calories             *float64 `json:"calories,omitempty" example:"61"`
+	Protein              *float64 `json:"protein,omitempty" example:"3.2"`
+	Carbohydrates        *float64 `json:"carbohydrates,omitempty" example:"4.8"`
+	TotalFat             *float64 `json:"total_fat,omitempty" example:"3.3"`
+	SaturatedFats        *float64 `json:"saturated_fats,omitempty" example:"1.9"`
+	TransFats            *float64 `json:"trans_fats,omitempty"`
+	Cholesterol          *float64 `json:"cholesterol,omitempty" example:"10"`
+	DietaryFiber         *float64 `json:"dietary_fiber,omitempty"`
+	TotalSugars          *float64 `json:"total_sugars,omitempty" example:"5.1"`
+	AddedSugars          *float64 `json:"added_sugars,omitempty"`
+	Sodium               *float64 `json:"sodium,omitempty" example:"43"`
+	Potassium            *float64 `json:"potassium,omitempty" example:"132"`
+	Calcium              *float64 `json:"calcium,omitempty" example:"113"`
+	Iron                 *float64 `json:"iron,omitempty"`
+	Magnesium            *float64 `json:"magnesium,omitempty" example:"10"`
+	Zinc                 *float64 `json:"zinc,omitempty" example:"0.4"`
+	VitaminA             *float64 `json:"vitamin_a,omitempty" example:"46"`
+	VitaminC             *float64 `json:"vitamin_c,omitempty"`
+	VitaminD             *float64 `json:"vitamin_d,omitempty" example:"1.3"`
+	VitaminB12           *float64 `json:"vitamin_b12,omitempty" example:"0.45"`
+	Folate               *float64 `json:"folate,omitempty" example:"5"`
+	Omega3               *float64 `json:"omega_3,omitempty"`
+	FruitsVegetablesNuts *float64 `json:"fruits_vegetables_nuts,omitempty"`
+}
+
+// NutriScoreResponse represents a food's Nutri-Score and the points behind it
+type NutriScoreResponse struct {
+	Grade          string                   `json:"grade" example:"B"`
+	Score          int                      `json:"score" example:"1"`
+	Points         NutriScorePointsResponse `json:"points"`
+	ProteinCounted bool                     `json:"protein_counted" example:"true"`
+}
+
+// IngredientListResponse represents an ingredient statement parsed into a tree
+type IngredientListResponse struct {
+	Ingredients []IngredientResponse `json:"ingredients"`
+	Contains    []string             `json:"contains,omitempty"`
+	MayContain  []string             `json:"may_contain,omitempty"`
+}
+
+// IngredientResponse represents one ingredient and its components
+type IngredientResponse struct {
+	Name     string               `json:"name" example:"milk chocolate"`
+	Percent  float64              `json:"percent,omitempty" example:"12.5"`
+	ENumber  string               `json:"e_number,omitempty" example:"E322"`
+	Children []IngredientResponse `json:"children,omitempty"`
+}
+
+// NovaMarkerResponse represents an ingredient or additive that placed a food in a NOVA group
+type NovaMarkerResponse struct {
+	Marker string `json:"marker" example:"E471"`
+	Group  int    `json:"group" example:"4"`
+}
+
+// NutriScorePointsResponse represents the per-component Nutri-Score points
+type NutriScorePointsResponse struct {
+	Energy       int `json:"energy" example:"3"`
+	Sugars       int `json:"sugars" example:"1"`
+	SaturatedFat int `json:"saturated_fat" example:"2"`
+	Sodium       int `json:"sodium" example:"1"`
+	FruitVeg     int `json:"fruit_veg" example:"0"`
+	Fibre        int `json:"fibre" example:"3"`
+	Protein      int `json:"protein" example:"3"`
+	Negative     int `json:"negative" example:"7"`
+	Positive     int `json:"positive" example:"6"`
 }
 
 // RatingResponse represents a user's rating for a food item
@@ -162,10 +263,11 @@ type RatingResponse struct {
 
 // PaginationMeta represents pagination metadata
 type PaginationMeta struct {
-	CurrentPage int `json:"current_page"`
-	PageSize    int `json:"page_size"`
-	TotalItems  int `json:"total_items"`
-	TotalPages  int `json:"total_pages"`
+	CurrentPage int    `json:"current_page"`
+	PageSize    int    `json:"page_size"`
+	TotalItems  int    `json:"total_items"`
+	TotalPages  int    `json:"total_pages"`
+	NextCursor  string `json:"next_cursor,omitempty"`
 }
 
 // ErrorResponse represents an error response
@@ -188,3 +290,453 @@ type RatingRequest struct {
 	Rating   int    `json:"rating" validate:"required,min=1,max=5"`
 	Comments string `json:"comments,omitempty"`
 }
+
+// IntakeRequest represents the request body for logging food intake
+type IntakeRequest struct {
+	FoodID      string    `json:"food_id" example:"FOOD123"`
+	AmountGrams float64   `json:"amount_g" example:"150"`
+	MealType    string    `json:"meal_type,omitempty" example:"lunch"`
+	ConsumedAt  time.Time `json:"consumed_at,omitempty"`
+}
+
+// IntakeResponse represents a logged intake entry
+type IntakeResponse struct {
+	ID          uuid.UUID `json:"id"`
+	ProfileID   uuid.UUID `json:"profile_id"`
+	FoodID      string    `json:"food_id"`
+	AmountGrams float64   `json:"amount_g"`
+	MealType    string    `json:"meal_type,omitempty"`
+	ConsumedAt  time.Time `json:"consumed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NutrientTarget represents a daily intake target for a nutrient
+type NutrientTarget struct {
+	Nutrient string   `json:"nutrient" example:"sodium"`
+	Unit     string   `json:"unit" example:"mg"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty" example:"2300"`
+	Sources  []string `json:"sources"`
+}
+
+// NutrientGap represents a nutrient whose daily average misses its target
+type NutrientGap struct {
+	Nutrient        string           `json:"nutrient" example:"dietary_fiber"`
+	Unit            string           `json:"unit" example:"g"`
+	DailyAverage    float64          `json:"daily_average" example:"14.2"`
+	Target          NutrientTarget   `json:"target"`
+	Status          string           `json:"status" example:"deficit"`
+	Gap             float64          `json:"gap" example:"11.8"`
+	PercentOfTarget float64          `json:"percent_of_target" example:"54.6"`
+	SuggestedFoods  []FoodResponse   `json:"suggested_foods,omitempty"`
+	TopContributors []FoodAmountItem `json:"top_contributors,omitempty"`
+}
+
+// FoodAmountItem represents how much of a nutrient a food contributed
+type FoodAmountItem struct {
+	FoodID string  `json:"food_id"`
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// GapReportResponse represents a nutrient gap report for a profile
+type GapReportResponse struct {
+	ProfileID  uuid.UUID     `json:"profile_id"`
+	Source     string        `json:"source" example:"intake"`
+	From       *time.Time    `json:"from,omitempty"`
+	To         *time.Time    `json:"to,omitempty"`
+	Days       int           `json:"days" example:"7"`
+	Gaps       []NutrientGap `json:"gaps"`
+	MetTargets []string      `json:"met_targets"`
+	Unassessed []string      `json:"unassessed"`
+}
+
+// MeasurementRequest represents the request body for recording a body measurement
+type MeasurementRequest struct {
+	Type       string    `json:"type" example:"weight"`
+	Value      float64   `json:"value" example:"82.4"`
+	MeasuredAt time.Time `json:"measured_at,omitempty"`
+	Notes      string    `json:"notes,omitempty"`
+}
+
+// MeasurementResponse represents a recorded body measurement
+type MeasurementResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Type       string    `json:"type" example:"weight"`
+	Value      float64   `json:"value" example:"82.4"`
+	Unit       string    `json:"unit" example:"kg"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Notes      string    `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TrendPoint represents a measurement with its smoothed trend value
+type TrendPoint struct {
+	MeasuredAt time.Time `json:"measured_at"`
+	Value      float64   `json:"value" example:"82.4"`
+	Trend      float64   `json:"trend" example:"82.9"`
+}
+
+// MeasurementSeriesResponse represents a measurement history with its trend
+type MeasurementSeriesResponse struct {
+	Type        string       `json:"type" example:"weight"`
+	Unit        string       `json:"unit" example:"kg"`
+	Points      []TrendPoint `json:"points"`
+	Latest      *float64     `json:"latest_trend,omitempty" example:"82.9"`
+	WeeklyRate  *float64     `json:"weekly_rate,omitempty" example:"-0.35"`
+	RateWindow  int          `json:"rate_window_days" example:"28"`
+	SampleCount int          `json:"sample_count" example:"12"`
+}
+
+// CalorieAdjustmentResponse represents an automatic calorie target change
+type CalorieAdjustmentResponse struct {
+	ID                 uuid.UUID `json:"id"`
+	ProfileID          uuid.UUID `json:"profile_id"`
+	PreviousTarget     int       `json:"previous_target" example:"2200"`
+	NewTarget          int       `json:"new_target" example:"2050"`
+	ObservedRateKgWeek float64   `json:"observed_rate_kg_week" example:"-0.1"`
+	GoalRateKgWeek     float64   `json:"goal_rate_kg_week" example:"-0.5"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// MeasurementRecordResponse represents the result of recording a measurement
+type MeasurementRecordResponse struct {
+	Measurement MeasurementResponse        `json:"measurement"`
+	Trend       *MeasurementSeriesResponse `json:"trend,omitempty"`
+	Adjustment  *CalorieAdjustmentResponse `json:"calorie_adjustment,omitempty"`
+}
+
+// HouseholdRequest represents the request body for creating a household
+type HouseholdRequest struct {
+	Name       string      `json:"name" example:"The Smiths"`
+	ProfileIDs []uuid.UUID `json:"profile_ids,omitempty"`
+}
+
+// HouseholdMemberRequest represents a profile to add to a household
+type HouseholdMemberRequest struct {
+	ProfileID uuid.UUID `json:"profile_id"`
+}
+
+// HouseholdInvitationRequest represents the request body for inviting a user to a household
+type HouseholdInvitationRequest struct {
+	Email string `json:"email" example:"partner@example.com"`
+}
+
+// HouseholdMember represents a profile that belongs to a household
+type HouseholdMember struct {
+	ProfileID     uuid.UUID `json:"profile_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	ProfileName   string    `json:"profile_name" example:"Kids"`
+	CalorieTarget int       `json:"calorie_target,omitempty" example:"1600"`
+	JoinedAt      time.Time `json:"joined_at"`
+}
+
+// HouseholdResponse represents a household and its members
+type HouseholdResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      string            `json:"name" example:"The Smiths"`
+	OwnerID   uuid.UUID         `json:"owner_id"`
+	Members   []HouseholdMember `json:"members"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// HouseholdInvitationResponse represents an invitation to join a household
+type HouseholdInvitationResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	HouseholdID  uuid.UUID  `json:"household_id"`
+	InvitedBy    uuid.UUID  `json:"invited_by"`
+	InviteeEmail string     `json:"invitee_email" example:"partner@example.com"`
+	Status       string     `json:"status" example:"pending"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RespondedAt  *time.Time `json:"responded_at,omitempty"`
+}
+
+// MemberPortion represents one member's share of a food in a shared meal
+type MemberPortion struct {
+	ProfileID   uuid.UUID `json:"profile_id"`
+	ProfileName string    `json:"profile_name" example:"Kids"`
+	FoodID      string    `json:"food_id" example:"FOOD123"`
+	Grams       float64   `json:"grams" example:"85.5"`
+	Calories    float64   `json:"calories,omitempty" example:"133.3"`
+}
+
+// HouseholdMeal represents a shared meal with per-member portions
+type HouseholdMeal struct {
+	Type     string          `json:"type" example:"dinner"`
+	Foods    []FoodResponse  `json:"foods"`
+	Portions []MemberPortion `json:"portions"`
+}
+
+// HouseholdDailyPlan represents one day of a household meal plan
+type HouseholdDailyPlan struct {
+	Date  string          `json:"date" example:"Day 1"`
+	Meals []HouseholdMeal `json:"meals"`
+}
+
+// HouseholdMealPlanResponse represents a shared household meal plan
+type HouseholdMealPlanResponse struct {
+	HouseholdID  string               `json:"household_id"`
+	Days         []HouseholdDailyPlan `json:"days"`
+	TotalDays    int                  `json:"total_days" example:"7"`
+	AppliedRules []string             `json:"applied_rules"`
+}
+
+// RuleResponse represents a recommendation rule derived from a profile
+type RuleResponse struct {
+	Type      string      `json:"type" example:"allergen"`
+	Operation string      `json:"operation" example:"exclude"`
+	Target    string      `json:"target" example:"peanuts"`
+	Value     interface{} `json:"value,omitempty"`
+	Priority  int         `json:"priority" example:"100"`
+}
+
+// ViolationResponse represents a profile rule a food breaks
+type ViolationResponse struct {
+	Rule     RuleResponse `json:"rule"`
+	Severity string       `json:"severity" example:"high"`
+	Reason   string       `json:"reason" example:"contains allergen \"peanuts\""`
+	Matched  string       `json:"matched,omitempty" example:"roasted peanuts"`
+	Value    float64      `json:"value,omitempty" example:"812.5"`
+}
+
+// CheckResponse represents the compatibility verdict for a single food
+type CheckResponse struct {
+	Food       FoodResponse        `json:"food"`
+	ProfileID  uuid.UUID           `json:"profile_id"`
+	Verdict    string              `json:"verdict" example:"avoid"`
+	Violations []ViolationResponse `json:"violations"`
+}
+
+// SuggestionResponse represents an autocomplete match on a food name
+type SuggestionResponse struct {
+	FoodID      string `json:"food_id" example:"FOOD123"`
+	Text        string `json:"text" example:"Broccoli, raw"`
+	Highlighted string `json:"highlighted" example:"<em>Broc</em>coli, raw"`
+	Alternate   bool   `json:"alternate" example:"false"`
+}
+
+// FacetCount represents the number of matching foods with a given value
+type FacetCount struct {
+	Value string `json:"value" example:"vegan"`
+	Count int64  `json:"count" example:"1240"`
+}
+
+// HistogramBucket represents the matching foods with a nutrient amount in [min, max)
+type HistogramBucket struct {
+	Min   float64 `json:"min,omitempty" example:"10"`
+	Max   float64 `json:"max,omitempty" example:"20"`
+	Count int64   `json:"count" example:"310"`
+}
+
+// NutrientHistogram represents the distribution of a nutrient across matching foods
+type NutrientHistogram struct {
+	Nutrient string            `json:"nutrient" example:"protein"`
+	Unit     string            `json:"unit" example:"g"`
+	Buckets  []HistogramBucket `json:"buckets"`
+}
+
+// Facets represents the filter sidebar counts for a food search
+type Facets struct {
+	FoodTypes []FacetCount        `json:"food_types"`
+	Labels    []FacetCount        `json:"labels"`
+	Nutrients []NutrientHistogram `json:"nutrients"`
+}
+
+// SearchMeta represents pagination and facet metadata for a food search
+type SearchMeta struct {
+	Page       int    `json:"page" example:"1"`
+	Limit      int    `json:"limit" example:"10"`
+	Total      int    `json:"total" example:"42"`
+	TotalPages int    `json:"totalPages" example:"5"`
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJvIjoibmFtZSIsIm4iOiJBcHBsZSIsImkiOiJmZF8xIn0"`
+	Fuzzy      bool   `json:"fuzzy" example:"false"`
+	Facets     Facets `json:"facets,omitempty"` // Only with facets=true
+}
+
+// FoodCategoryResponse represents a node in the food category taxonomy
+type FoodCategoryResponse struct {
+	ID        int                    `json:"id" example:"12"`
+	Slug      string                 `json:"slug" example:"cheese"`
+	Name      string                 `json:"name" example:"Cheese"`
+	ParentID  int                    `json:"parent_id,omitempty" example:"1"`
+	FoodTypes []string               `json:"food_types"`
+	FoodCount int64                  `json:"food_count" example:"830"`
+	Children  []FoodCategoryResponse `json:"children,omitempty"`
+}
+
+// FoodTypeCount represents the number of foods with a food type
+type FoodTypeCount struct {
+	FoodType string `json:"food_type" example:"cheese"`
+	Count    int64  `json:"count" example:"512"`
+}
+
+// CategoryListingResponse represents the food types and category taxonomy with counts
+type CategoryListingResponse struct {
+	FoodTypes []FoodTypeCount        `json:"food_types"`
+	Taxonomy  []FoodCategoryResponse `json:"taxonomy"`
+}
+
+// CompareFoodsRequest represents the foods to compare side by side
+type CompareFoodsRequest struct {
+	FoodIDs []string `json:"food_ids" example:"FOOD123,FOOD456"`
+}
+
+// ComparedFoodResponse represents one food in a comparison
+type ComparedFoodResponse struct {
+	ID        string   `json:"id" example:"FOOD123"`
+	Name      string   `json:"name" example:"Greek yogurt, plain"`
+	FoodType  string   `json:"food_type,omitempty" example:"yogurt"`
+	Labels    []string `json:"labels"`
+	Allergens []string `json:"allergens"`
+}
+
+// NutrientComparisonResponse represents one nutrient across the compared foods,
+// with values and percent differences in the order of the compared foods
+type NutrientComparisonResponse struct {
+	Nutrient    string     `json:"nutrient" example:"protein"`
+	Name        string     `json:"name" example:"Protein"`
+	Unit        string     `json:"unit" example:"g"`
+	Values      []*float64 `json:"values"`
+	PercentDiff []*float64 `json:"percent_diff"`
+	Better      string     `json:"better,omitempty" example:"higher"`
+	BestFoodIDs []string   `json:"best_food_ids,omitempty"`
+}
+
+// SetDifferenceResponse represents the values shared by every compared food
+// and those only some foods have, keyed by food ID
+type SetDifferenceResponse struct {
+	Common []string            `json:"common"`
+	Only   map[string][]string `json:"only"`
+}
+
+// FoodComparisonResponse represents a side-by-side comparison of foods
+type FoodComparisonResponse struct {
+	Foods     []ComparedFoodResponse       `json:"foods"`
+	Nutrients []NutrientComparisonResponse `json:"nutrients"`
+	Labels    SetDifferenceResponse        `json:"labels"`
+	Allergens SetDifferenceResponse        `json:"allergens"`
+}
+
+// AdminFoodRequest represents a food added to the catalog by an admin
+type AdminFoodRequest struct {
+	ID                 string                      `json:"id,omitempty" example:"FOOD123"`
+	Name               string                      `json:"name" example:"Greek yogurt, plain"`
+	AlternateNames     []string                    `json:"alternate_names,omitempty"`
+	Description        string                      `json:"description,omitempty"`
+	FoodType           string                      `json:"food_type,omitempty" example:"yogurt"`
+	Serving            *ServingResponse            `json:"serving,omitempty"`
+	Nutrition100g      *NutrientsResponse          `json:"nutrition_100g,omitempty"`
+	EAN13              string                      `json:"ean_13,omitempty" example:"4006381333931"`
+	Labels             []string                    `json:"labels,omitempty"`
+	PackageSize        *ServingResponse            `json:"package_size,omitempty"`
+	Ingredients        string                      `json:"ingredients,omitempty" example:"Milk, live cultures"`
+	IngredientAnalysis *IngredientAnalysisResponse `json:"ingredient_analysis,omitempty"`
+}
+
+// FieldChangeResponse represents the old and new value of a changed food field
+type FieldChangeResponse struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// FoodRevisionResponse represents one recorded change to a food
+type FoodRevisionResponse struct {
+	ID                 string                         `json:"id" example:"4b7e2a1c-3f5d-4e8a-9c6b-1d2e3f4a5b6c"`
+	FoodID             string                         `json:"food_id" example:"FOOD123"`
+	Action             string                         `json:"action" example:"update" enums:"create,update,delete,revert,merge"`
+	AuthorID           string                         `json:"author_id,omitempty" example:"6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f"`
+	Diff               map[string]FieldChangeResponse `json:"diff"`
+	Before             *FoodDetailResponse            `json:"before,omitempty"`
+	After              *FoodDetailResponse            `json:"after,omitempty"`
+	RevertedRevisionID string                         `json:"reverted_revision_id,omitempty"`
+	CreatedAt          time.Time                      `json:"created_at"`
+}
+
+// FoodSubmissionRequest represents a food submitted by a user for review
+type FoodSubmissionRequest struct {
+	Name          string             `json:"name" example:"Oat drink, barista"`
+	EAN13         string             `json:"ean_13,omitempty" example:"7394376616037"`
+	FoodType      string             `json:"food_type,omitempty" example:"plant_milk"`
+	Description   string             `json:"description,omitempty"`
+	Serving       *ServingResponse   `json:"serving,omitempty"`
+	Nutrition100g map[string]float64 `json:"nutrition_100g"`
+	Ingredients   string             `json:"ingredients,omitempty" example:"Water, oats (10%), rapeseed oil, salt"`
+	Labels        []string           `json:"labels,omitempty"`
+}
+
+// FoodSubmissionResponse represents a user-submitted food and its review state
+type FoodSubmissionResponse struct {
+	ID              string             `json:"id" example:"8d2f1c3e-5b4a-4c7d-9e8f-0a1b2c3d4e5f"`
+	SubmitterID     string             `json:"submitter_id" example:"6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f"`
+	Status          string             `json:"status" example:"pending" enums:"pending,approved,rejected"`
+	Food            FoodDetailResponse `json:"food"`
+	RejectionReason string             `json:"rejection_reason,omitempty" example:"Nutrition values do not match the label"`
+	ReviewerID      string             `json:"reviewer_id,omitempty"`
+	ReviewedAt      *time.Time         `json:"reviewed_at,omitempty"`
+	FoodID          string             `json:"food_id,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// RejectSubmissionRequest represents the reason for rejecting a submission
+type RejectSubmissionRequest struct {
+	Reason string `json:"reason" example:"Duplicate of an existing food"`
+}
+
+// FoodTranslationRequest represents a food's name and description in a locale
+type FoodTranslationRequest struct {
+	Name           string   `json:"name" example:"Boisson à l'avoine, barista"`
+	AlternateNames []string `json:"alternate_names,omitempty"`
+	Description    string   `json:"description,omitempty"`
+}
+
+// FoodTranslationResponse represents a food's translation into a locale
+type FoodTranslationResponse struct {
+	FoodID         string    `json:"food_id" example:"FOOD123"`
+	Locale         string    `json:"locale" example:"fr"`
+	Name           string    `json:"name" example:"Boisson à l'avoine, barista"`
+	AlternateNames []string  `json:"alternate_names,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// UserLocaleRequest represents a user's preferred language
+type UserLocaleRequest struct {
+	Locale string `json:"locale" example:"fr" enums:"en,fr,de,ak,ee,gaa"`
+}
+
+// DuplicateFoodResponse identifies one food of a likely duplicate pair
+type DuplicateFoodResponse struct {
+	ID       string `json:"id" example:"FOOD123"`
+	Name     string `json:"name" example:"Oat Milk, Barista"`
+	FoodType string `json:"food_type,omitempty" example:"everyday"`
+	EAN13    string `json:"ean_13,omitempty" example:"5901234123457"`
+}
+
+// DuplicatePairResponse represents a scored pair of likely duplicate foods
+type DuplicatePairResponse struct {
+	Foods            []DuplicateFoodResponse `json:"foods"`
+	Score            float64                 `json:"score" example:"0.912"`
+	SameBarcode      bool                    `json:"same_barcode" example:"false"`
+	NameSimilarity   float64                 `json:"name_similarity" example:"0.857"`
+	NutrientDistance *float64                `json:"nutrient_distance,omitempty" example:"0.034"`
+}
+
+// MergeFoodsRequest names the food a duplicate is merged into
+type MergeFoodsRequest struct {
+	Into string `json:"into" example:"FOOD456"`
+}
+
+// MergeResultResponse counts what a merge moved to the surviving food
+type MergeResultResponse struct {
+	SourceID     string               `json:"source_id" example:"FOOD123"`
+	TargetID     string               `json:"target_id" example:"FOOD456"`
+	Ratings      int64                `json:"ratings" example:"4"`
+	SavedFoods   int64                `json:"saved_foods" example:"12"`
+	IntakeLogs   int64                `json:"intake_logs" example:"37"`
+	Translations int64                `json:"translations" example:"1"`
+	Revision     FoodRevisionResponse `json:"revision"`
+}
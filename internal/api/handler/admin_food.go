@@ -0,0 +1,446 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
+)
+
+// AdminFoodHandler serves the admin-only food catalog editing endpoints
+type AdminFoodHandler struct {
+	BaseHandler
+	foodService service.FoodService
+}
+
+func NewAdminFoodHandler(foodService service.FoodService, logger zerolog.Logger) *AdminFoodHandler {
+	return &AdminFoodHandler{
+		BaseHandler: NewBaseHandler(logger),
+		foodService: foodService,
+	}
+}
+
+// RegisterRoutes registers the admin food routes. The caller must restrict
+// them to admins.
+func (h *AdminFoodHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/", h.CreateFood)
+	r.Get("/duplicates", h.ListDuplicates)
+	r.Patch("/{id}", h.PatchFood)
+	r.Delete("/{id}", h.DeleteFood)
+	r.Get("/{id}/revisions", h.ListRevisions)
+	r.Get("/{id}/revisions/{revisionId}", h.GetRevision)
+	r.Post("/{id}/revisions/{revisionId}/revert", h.RevertRevision)
+	r.Get("/{id}/translations", h.ListTranslations)
+	r.Put("/{id}/translations/{locale}", h.PutTranslation)
+	r.Delete("/{id}/translations/{locale}", h.DeleteTranslation)
+	r.Post("/{id}/merge", h.MergeFood)
+}
+
+// @Summary Create a food
+// @Description Add a food to the catalog. The ID is generated when omitted, the barcode is normalized to GTIN-13 and the Nutri-Score, NOVA group and ingredient tree are computed. Records a create revision.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param food body docs.AdminFoodRequest true "Food"
+// @Security BearerAuth
+// @Success 201 {object} docs.Response{data=docs.FoodDetailResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 409 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods [post]
+func (h *AdminFoodHandler) CreateFood(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	var input food.Food
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+
+	created, err := h.foodService.CreateFood(r.Context(), userID, &input)
+	if err != nil {
+		h.editError(w, err, input.ID, "Failed to create food")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, created)
+}
+
+// @Summary Update a food
+// @Description Apply a JSON merge patch (RFC 7386) to a food. Nested objects such as nutrition_100g are merged key by key and null removes a value, so {"nutrition_100g": {"protein": 12.5}} corrects one nutrient. Derived fields are recomputed. Records an update revision unless nothing changed.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Food ID"
+// @Param patch body object true "Merge patch of editable food fields"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodDetailResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id} [patch]
+func (h *AdminFoodHandler) PatchFood(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+
+	updated, err := h.foodService.PatchFood(r.Context(), userID, id, patch)
+	if err != nil {
+		h.editError(w, err, id, "Failed to update food")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, updated)
+}
+
+// @Summary Delete a food
+// @Description Retire a food, removing it from search and listings. Its ratings, saved foods and intake logs are kept, and a delete revision holding its last state is recorded, which can be reverted.
+// @Tags admin
+// @Param id path string true "Food ID"
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id} [delete]
+func (h *AdminFoodHandler) DeleteFood(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if err := h.foodService.DeleteFood(r.Context(), userID, id); err != nil {
+		h.editError(w, err, id, "Failed to delete food")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// @Summary List food revisions
+// @Description List the revisions of a food, newest first, with author, changed fields and timestamp. Deleted foods keep their history.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Food ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=[]docs.FoodRevisionResponse,meta=docs.PaginationMeta}
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id}/revisions [get]
+func (h *AdminFoodHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	revisions, total, err := h.foodService.ListFoodRevisions(r.Context(), id, page, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Str("food_id", id).Msg("Failed to list food revisions")
+		response.Error(w, apperrors.Internal("Failed to list food revisions", err))
+		return
+	}
+
+	response.JSONWithMeta(w, http.StatusOK, revisions, response.PaginationMeta(page, limit, total))
+}
+
+// @Summary Get a food revision
+// @Description Get one revision of a food with its full before and after states
+// @Tags admin
+// @Produce json
+// @Param id path string true "Food ID"
+// @Param revisionId path string true "Revision ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodRevisionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id}/revisions/{revisionId} [get]
+func (h *AdminFoodHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	revisionID, err := uuid.Parse(chi.URLParam(r, "revisionId"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid revision ID", err))
+		return
+	}
+
+	rev, err := h.foodService.GetFoodRevision(r.Context(), id, revisionID)
+	if err != nil {
+		h.editError(w, err, id, "Failed to get food revision")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, rev)
+}
+
+// @Summary Revert a food revision
+// @Description Restore a food to its state before a revision: reverting a create retires the food, reverting a delete restores it and reverting an update restores the previous values of the fields it changed, keeping later edits to other fields. The revert is recorded as a new revision.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Food ID"
+// @Param revisionId path string true "Revision ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodRevisionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id}/revisions/{revisionId}/revert [post]
+func (h *AdminFoodHandler) RevertRevision(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	revisionID, err := uuid.Parse(chi.URLParam(r, "revisionId"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid revision ID", err))
+		return
+	}
+
+	rev, err := h.foodService.RevertFoodRevision(r.Context(), userID, id, revisionID)
+	if err != nil {
+		h.editError(w, err, id, "Failed to revert food revision")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, rev)
+}
+
+// @Summary List food translations
+// @Description List a food's names, alternate names and descriptions in locales other than English
+// @Tags admin
+// @Produce json
+// @Param id path string true "Food ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=[]docs.FoodTranslationResponse}
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id}/translations [get]
+func (h *AdminFoodHandler) ListTranslations(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	translations, err := h.foodService.ListFoodTranslations(r.Context(), id)
+	if err != nil {
+		h.editError(w, err, id, "Failed to list food translations")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, translations)
+}
+
+// @Summary Set a food translation
+// @Description Create or replace a food's name, alternate names and description in a locale. English is edited on the food itself.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Food ID"
+// @Param locale path string true "Locale" Enums(fr, de, ak, ee, gaa)
+// @Param translation body docs.FoodTranslationRequest true "Translation"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodTranslationResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id}/translations/{locale} [put]
+func (h *AdminFoodHandler) PutTranslation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var input food.Translation
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+	input.FoodID = id
+	input.Locale = chi.URLParam(r, "locale")
+
+	translation, err := h.foodService.PutFoodTranslation(r.Context(), &input)
+	if err != nil {
+		h.editError(w, err, id, "Failed to set food translation")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, translation)
+}
+
+// @Summary Delete a food translation
+// @Description Remove a food's translation into a locale, so that it is shown in English there
+// @Tags admin
+// @Param id path string true "Food ID"
+// @Param locale path string true "Locale"
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id}/translations/{locale} [delete]
+func (h *AdminFoodHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := h.foodService.DeleteFoodTranslation(r.Context(), id, chi.URLParam(r, "locale")); err != nil {
+		h.editError(w, err, id, "Failed to delete food translation")
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// @Summary List likely duplicate foods
+// @Description List pairs of foods that are likely the same food, most likely first. Candidates share a barcode or have similar names; each pair is scored from 0 to 1 by barcode, name similarity and the distance between their nutrients per 100g. A food_type or food_id is required.
+// @Tags admin
+// @Produce json
+// @Param food_type query string false "Only pairs of foods of this type (food_type or food_id is required)"
+// @Param food_id query string false "Only pairs including this food (food_type or food_id is required)"
+// @Param min_score query number false "Minimum score, 0 to 1" default(0.6)
+// @Param min_name_similarity query number false "Minimum name similarity of candidates without a shared barcode, 0 to 1" default(0.5)
+// @Param limit query int false "Maximum number of pairs, at most 100" default(20)
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=[]docs.DuplicatePairResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/duplicates [get]
+func (h *AdminFoodHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := food.DuplicateFilter{
+		FoodType:          query.Get("food_type"),
+		FoodID:            query.Get("food_id"),
+		MinNameSimilarity: 0.5,
+	}
+	minScore := 0.6
+	for name, target := range map[string]*float64{"min_score": &minScore, "min_name_similarity": &filter.MinNameSimilarity} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			response.Error(w, apperrors.InvalidInput(name+" must be a number between 0 and 1", err))
+			return
+		}
+		*target = parsed
+	}
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	pairs, err := h.foodService.FindDuplicates(r.Context(), filter, minScore, limit)
+	if err != nil {
+		h.editError(w, err, filter.FoodID, "Failed to list duplicate foods")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, pairs)
+}
+
+// @Summary Merge a duplicate food
+// @Description Merge a food into the food that survives: its ratings, saved foods and intake logs move to the target, along with translations into locales the target lacks. The merged food is deleted, and its ID redirects to the target from then on. Recorded as a merge revision of the merged food, which cannot be reverted.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the food to merge away"
+// @Param merge body docs.MergeFoodsRequest true "Food to merge into"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.MergeResultResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/admin/foods/{id}/merge [post]
+func (h *AdminFoodHandler) MergeFood(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	var input struct {
+		Into string `json:"into"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+	if input.Into == "" {
+		response.Error(w, apperrors.InvalidInput("into is required", nil))
+		return
+	}
+
+	result, err := h.foodService.MergeFoods(r.Context(), userID, id, input.Into)
+	if err != nil {
+		h.editError(w, err, id, "Failed to merge food")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// editError maps catalog editing errors to API errors
+func (h *AdminFoodHandler) editError(w http.ResponseWriter, err error, foodID, message string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, food.ErrRevisionOtherFood):
+		response.Error(w, apperrors.NotFound("food or revision", err))
+	case errors.Is(err, food.ErrFoodExists):
+		response.Error(w, apperrors.DuplicateEntity(err.Error(), err))
+	case errors.Is(err, food.ErrFieldNotEditable), errors.Is(err, food.ErrInvalidPatch),
+		errors.Is(err, food.ErrNameRequired), errors.Is(err, food.ErrNothingToRevert),
+		errors.Is(err, food.ErrInvalidBarcode), errors.Is(err, food.ErrInvalidCheckDigit),
+		errors.Is(err, food.ErrUnsupportedLocale), errors.Is(err, food.ErrDefaultLocale),
+		errors.Is(err, food.ErrTranslationRequired), errors.Is(err, food.ErrMergeIntoSelf),
+		errors.Is(err, food.ErrRevertMerge), errors.Is(err, food.ErrInvalidMinScore),
+		errors.Is(err, food.ErrDuplicateScopeNeeded):
+		response.Error(w, apperrors.InvalidInput(err.Error(), err))
+	default:
+		h.logger.Error().Err(err).Str("food_id", foodID).Msg(message)
+		response.Error(w, apperrors.Internal(message, err))
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
+)
+
+type CategoryHandler struct {
+	BaseHandler
+	foodService service.FoodService
+}
+
+func NewCategoryHandler(foodService service.FoodService, logger zerolog.Logger) *CategoryHandler {
+	return &CategoryHandler{
+		BaseHandler: NewBaseHandler(logger),
+		foodService: foodService,
+	}
+}
+
+func (h *CategoryHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.ListCategories)
+}
+
+// @Summary List food categories
+// @Description List the distinct food types in the catalog with counts, and the category taxonomy with the number of foods under each category including its subcategories
+// @Tags foods
+// @Produce json
+// @Success 200 {object} docs.Response{data=docs.CategoryListingResponse}
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/categories [get]
+func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	listing, err := h.foodService.ListCategories(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list categories")
+		response.Error(w, apperrors.Internal("Failed to list categories", err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, listing)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
+)
+
+type ExportHandler struct {
+	BaseHandler
+	exportService service.ExportService
+}
+
+func NewExportHandler(exportService service.ExportService, logger zerolog.Logger) *ExportHandler {
+	return &ExportHandler{
+		BaseHandler:   NewBaseHandler(logger),
+		exportService: exportService,
+	}
+}
+
+func (h *ExportHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/foods", h.ExportFoods)
+}
+
+// @Summary Export the food catalog
+// @Description Stream all foods in the catalog, or those of a food type or updated since a time, as NDJSON (one food per line) or as CSV with a column per nutrient named after its key and unit, e.g. protein_g
+// @Tags foods
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Param format query string false "Export format" Enums(ndjson, csv) default(ndjson)
+// @Param food_type query string false "Only foods of this type"
+// @Param updated_since query string false "Only foods updated at or after this time, RFC 3339 or YYYY-MM-DD"
+// @Param include_retired query bool false "Also export retired foods, with their retired_at" default(false)
+// @Success 200 {string} string "Exported foods"
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/exports/foods [get]
+func (h *ExportHandler) ExportFoods(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := strings.ToLower(q.Get("format"))
+	if format == "" {
+		format = food.ExportNDJSON
+	}
+	if format != food.ExportNDJSON && format != food.ExportCSV {
+		response.Error(w, apperrors.InvalidInput(food.ErrUnknownExportFormat.Error(), food.ErrUnknownExportFormat))
+		return
+	}
+
+	filter := food.ExportFilter{FoodType: q.Get("food_type")}
+	if v := q.Get("updated_since"); v != "" {
+		since, err := parseUpdatedSince(v)
+		if err != nil {
+			response.Error(w, apperrors.InvalidInput("updated_since must be RFC 3339 or YYYY-MM-DD", err))
+			return
+		}
+		filter.UpdatedSince = &since
+	}
+	if v := q.Get("include_retired"); v != "" {
+		includeRetired, err := strconv.ParseBool(v)
+		if err != nil {
+			response.Error(w, apperrors.InvalidInput("include_retired must be true or false", err))
+			return
+		}
+		filter.IncludeRetired = includeRetired
+	}
+
+	// The export outlives the server's write timeout on a large catalog
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", food.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="foods-%s.%s"`, time.Now().UTC().Format("20060102"), format))
+	w.WriteHeader(http.StatusOK)
+
+	count, err := h.exportService.ExportFoods(r.Context(), filter, format, w)
+	if err != nil {
+		// The status is already sent, so the client sees a truncated body
+		h.logger.Error().Err(err).Int("exported", count).Msg("Failed to export foods")
+	}
+}
+
+// parseUpdatedSince parses an RFC 3339 time or a date, taken as midnight UTC
+func parseUpdatedSince(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(dateLayout, v)
+}
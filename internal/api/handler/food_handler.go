@@ -1,15 +1,23 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog"
 	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
 	"github.com/yeboahd24/nutrimatch/internal/config"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
 	"github.com/yeboahd24/nutrimatch/internal/service"
 	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
 	"github.com/yeboahd24/nutrimatch/pkg/response"
@@ -33,6 +41,9 @@ func NewFoodHandler(foodService service.FoodService, logger zerolog.Logger, jwtC
 
 func (h *FoodHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/", h.SearchFoods)
+	r.Get("/suggest", h.SuggestFoods)
+	r.Get("/barcode/{code}", h.GetFoodByBarcode)
+	r.Post("/compare", h.CompareFoods)
 	r.Get("/{id}", h.GetFood)
 	r.Get("/category/{category}", h.GetFoodsByCategory)
 
@@ -47,19 +58,28 @@ func (h *FoodHandler) RegisterRoutes(r chi.Router) {
 }
 
 // @Summary Search foods
-// @Description Search for foods by name or other criteria
+// @Description Full-text search over food names, alternate names, descriptions and ingredients, ranked by relevance. Supports quoted phrases, OR and -exclusions, and falls back to typo-tolerant name matching when nothing matches. Without q, lists all foods.
+// @Description Structured filters narrow the results. With facets=true, meta.facets holds counts per food type and label plus nutrient histograms for the matching foods.
 // @Tags foods
 // @Accept json
 // @Produce json
 // @Param q query string false "Search query"
+// @Param food_type query string false "Food type"
+// @Param labels query string false "Comma-separated labels"
+// @Param labels_match query string false "Match any or all labels" Enums(any, all) default(any)
+// @Param has_barcode query bool false "Only foods with (true) or without (false) an EAN-13 barcode"
+// @Param nutri_score query string false "Comma-separated Nutri-Score grades, e.g. A,B"
+// @Param sort query string false "Result order" Enums(relevance, nutri_score) default(relevance)
+// @Param facets query bool false "Also return facet counts for the matching foods" default(false)
+// @Param protein_min query number false "Minimum per 100g; any nutrient key accepts _min and _max, e.g. sodium_max=300"
 // @Param page query int false "Page number" default(1)
-// @Param limit query int false "Number of items per page" default(10)
-// @Success 200 {object} docs.Response{data=[]docs.FoodResponse,meta=docs.PaginationMeta}
+// @Param limit query int false "Number of items per page, at most 100" default(10)
+// @Param cursor query string false "meta.next_cursor of the previous page; continues after it instead of using page"
+// @Success 200 {object} docs.Response{data=[]docs.FoodResponse,meta=docs.SearchMeta}
 // @Failure 400 {object} docs.ErrorResponse
 // @Failure 500 {object} docs.ErrorResponse
 // @Router /api/v1/foods [get]
 func (h *FoodHandler) SearchFoods(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query().Get("q")
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	if page < 1 {
 		page = 1
@@ -68,16 +88,170 @@ func (h *FoodHandler) SearchFoods(w http.ResponseWriter, r *http.Request) {
 	if limit < 1 {
 		limit = 10
 	}
+	if limit > 100 {
+		limit = 100
+	}
 
-	foods, total, err := h.foodService.SearchFoods(r.Context(), query, page, limit)
+	filter, err := parseSearchFilter(r)
 	if err != nil {
-		h.logger.Error().Err(err).Str("query", query).Msg("Failed to search foods")
+		response.Error(w, apperrors.InvalidInput(err.Error(), err))
+		return
+	}
+
+	result, err := h.foodService.SearchFoods(r.Context(), filter, page, limit, r.URL.Query().Get("cursor"))
+	if errors.Is(err, food.ErrInvalidCursor) {
+		response.Error(w, apperrors.InvalidInput("Invalid cursor", err))
+		return
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", filter.Query).Msg("Failed to search foods")
 		response.Error(w, apperrors.Internal("Failed to search foods", err))
 		return
 	}
 
-	meta := response.PaginationMeta(page, limit, total)
-	response.JSONWithMeta(w, http.StatusOK, foods, meta)
+	meta := response.CursorPaginationMeta(page, limit, result.Total, result.NextCursor)
+	meta["fuzzy"] = result.Fuzzy
+	if result.Facets != nil {
+		meta["facets"] = result.Facets
+	}
+	response.JSONWithMeta(w, http.StatusOK, result.Foods, meta)
+}
+
+// parseSearchFilter reads the structured search filters from the query string
+func parseSearchFilter(r *http.Request) (food.SearchFilter, error) {
+	q := r.URL.Query()
+	filter := food.SearchFilter{
+		Query:    q.Get("q"),
+		FoodType: q.Get("food_type"),
+	}
+
+	for _, v := range q["labels"] {
+		for _, label := range strings.Split(v, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				filter.Labels = append(filter.Labels, label)
+			}
+		}
+	}
+	switch q.Get("labels_match") {
+	case "", "any":
+	case "all":
+		filter.MatchAllLabels = true
+	default:
+		return filter, errors.New("labels_match must be any or all")
+	}
+
+	if v := q.Get("has_barcode"); v != "" {
+		hasBarcode, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("has_barcode must be true or false")
+		}
+		filter.HasBarcode = &hasBarcode
+	}
+
+	if v := q.Get("facets"); v != "" {
+		facets, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("facets must be true or false")
+		}
+		filter.Facets = facets
+	}
+
+	for _, v := range q["nutri_score"] {
+		for _, grade := range strings.Split(v, ",") {
+			grade = strings.ToUpper(strings.TrimSpace(grade))
+			if grade == "" {
+				continue
+			}
+			if !food.ValidNutriScoreGrade(grade) {
+				return filter, fmt.Errorf("nutri_score grade %q must be one of A to E", grade)
+			}
+			filter.NutriScoreGrades = append(filter.NutriScoreGrades, grade)
+		}
+	}
+
+	switch q.Get("sort") {
+	case "", "relevance":
+	case food.SortNutriScore:
+		filter.Sort = food.SortNutriScore
+	default:
+		return filter, errors.New("sort must be relevance or nutri_score")
+	}
+
+	ranges := map[string]*food.NutrientRange{}
+	var order []string
+	for param, values := range q {
+		var key string
+		var isMin bool
+		switch {
+		case strings.HasSuffix(param, "_min"):
+			key, isMin = strings.TrimSuffix(param, "_min"), true
+		case strings.HasSuffix(param, "_max"):
+			key = strings.TrimSuffix(param, "_max")
+		default:
+			continue
+		}
+
+		nutrient, ok := food.LookupNutrient(key)
+		if !ok {
+			return filter, fmt.Errorf("unknown nutrient %q in %s", key, param)
+		}
+		value, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be a number", param)
+		}
+
+		nr, ok := ranges[nutrient.Key]
+		if !ok {
+			nr = &food.NutrientRange{Nutrient: nutrient.Key}
+			ranges[nutrient.Key] = nr
+			order = append(order, nutrient.Key)
+		}
+		if isMin {
+			nr.Min = &value
+		} else {
+			nr.Max = &value
+		}
+	}
+	sort.Strings(order)
+	for _, key := range order {
+		filter.NutrientRanges = append(filter.NutrientRanges, *ranges[key])
+	}
+
+	return filter, nil
+}
+
+// @Summary Autocomplete food names
+// @Description Suggest food names and alternate names with a word starting with q, with the matched prefix highlighted
+// @Tags foods
+// @Produce json
+// @Param q query string true "Name prefix"
+// @Param limit query int false "Number of suggestions" default(10)
+// @Success 200 {object} docs.Response{data=[]docs.SuggestionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/foods/suggest [get]
+func (h *FoodHandler) SuggestFoods(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if strings.TrimSpace(query) == "" {
+		response.Error(w, apperrors.InvalidInput("q is required", nil))
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	suggestions, err := h.foodService.SuggestFoods(r.Context(), query, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", query).Msg("Failed to suggest foods")
+		response.Error(w, apperrors.Internal("Failed to suggest foods", err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, suggestions)
 }
 
 // @Summary Get food by ID
@@ -87,13 +261,23 @@ func (h *FoodHandler) SearchFoods(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param id path string true "Food ID"
 // @Success 200 {object} docs.Response{data=docs.FoodDetailResponse}
+// @Success 301 "The food was merged into another food, named by the Location header"
 // @Failure 404 {object} docs.ErrorResponse
 // @Failure 500 {object} docs.ErrorResponse
 // @Router /api/v1/foods/{id} [get]
 func (h *FoodHandler) GetFood(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	food, err := h.foodService.GetFood(r.Context(), id)
+	f, err := h.foodService.GetFood(r.Context(), id)
 	if err != nil {
+		var moved *food.MovedError
+		if errors.As(err, &moved) {
+			location := path.Join(path.Dir(r.URL.Path), url.PathEscape(moved.FoodID))
+			if r.URL.RawQuery != "" {
+				location += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, location, http.StatusMovedPermanently)
+			return
+		}
 		if err.Error() == "sql: no rows in result set" {
 			response.Error(w, apperrors.NotFound("food", err))
 			return
@@ -103,17 +287,87 @@ func (h *FoodHandler) GetFood(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.JSON(w, http.StatusOK, food)
+	response.JSON(w, http.StatusOK, f)
+}
+
+// @Summary Get food by barcode
+// @Description Look up a food by EAN-13, EAN-8, UPC-A or UPC-E barcode. The check digit is validated and the code normalized to GTIN-13 before lookup.
+// @Tags foods
+// @Produce json
+// @Param code path string true "Barcode"
+// @Success 200 {object} docs.Response{data=docs.FoodDetailResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/foods/barcode/{code} [get]
+func (h *FoodHandler) GetFoodByBarcode(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	f, err := h.foodService.GetFoodByBarcode(r.Context(), code)
+	if err != nil {
+		switch {
+		case errors.Is(err, food.ErrInvalidBarcode), errors.Is(err, food.ErrInvalidCheckDigit):
+			response.Error(w, apperrors.InvalidInput(err.Error(), err))
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apperrors.NotFound("food", err))
+		default:
+			h.logger.Error().Err(err).Str("barcode", code).Msg("Failed to get food by barcode")
+			response.Error(w, apperrors.Internal("Failed to get food by barcode", err))
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, f)
+}
+
+// @Summary Compare foods
+// @Description Compare 2 to 10 foods side by side: an aligned per-100g nutrient table with percent differences relative to the first food and the best food per nutrient, plus label and allergen differences
+// @Tags foods
+// @Accept json
+// @Produce json
+// @Param request body docs.CompareFoodsRequest true "Foods to compare"
+// @Success 200 {object} docs.Response{data=docs.FoodComparisonResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/foods/compare [post]
+func (h *FoodHandler) CompareFoods(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		FoodIDs []string `json:"food_ids" validate:"required,min=2,max=10,dive,required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+
+	if err := h.validator.Struct(input); err != nil {
+		response.Error(w, apperrors.InvalidInput("food_ids must list 2 to 10 food IDs", err))
+		return
+	}
+
+	comparison, err := h.foodService.CompareFoods(r.Context(), input.FoodIDs)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apperrors.NotFound(err.Error(), err))
+			return
+		}
+		h.logger.Error().Err(err).Strs("food_ids", input.FoodIDs).Msg("Failed to compare foods")
+		response.Error(w, apperrors.Internal("Failed to compare foods", err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, comparison)
 }
 
 // @Summary Get foods by category
-// @Description Get foods filtered by category
+// @Description Get foods in a taxonomy category, including its subcategories, or with a given food_type when category is not a category slug
 // @Tags foods
 // @Accept json
 // @Produce json
-// @Param category path string true "Food category"
+// @Param category path string true "Category slug or food type"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Number of items per page" default(10)
+// @Param cursor query string false "meta.next_cursor of the previous page; continues after it instead of using page"
 // @Success 200 {object} docs.Response{data=map[string]interface{},meta=docs.PaginationMeta}
 // @Failure 400 {object} docs.ErrorResponse
 // @Failure 500 {object} docs.ErrorResponse
@@ -129,16 +383,20 @@ func (h *FoodHandler) GetFoodsByCategory(w http.ResponseWriter, r *http.Request)
 		limit = 10
 	}
 
-	foods, total, err := h.foodService.GetFoodsByCategory(r.Context(), category, page, limit)
+	result, err := h.foodService.GetFoodsByCategory(r.Context(), category, page, limit, r.URL.Query().Get("cursor"))
+	if errors.Is(err, food.ErrInvalidCursor) {
+		response.Error(w, apperrors.InvalidInput("Invalid cursor", err))
+		return
+	}
 	if err != nil {
 		h.logger.Error().Err(err).Str("category", category).Msg("Failed to get foods by category")
 		response.Error(w, apperrors.Internal("Failed to get foods by category", err))
 		return
 	}
 
-	meta := response.PaginationMeta(page, limit, total)
+	meta := response.CursorPaginationMeta(page, limit, result.Total, result.NextCursor)
 	data := map[string]interface{}{
-		"foods":    foods,
+		"foods":    result.Foods,
 		"category": category,
 	}
 
@@ -0,0 +1,509 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
+	"github.com/yeboahd24/nutrimatch/internal/domain/household"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
+)
+
+type HouseholdHandler struct {
+	BaseHandler
+	householdService      service.HouseholdService
+	recommendationService service.RecommendationService
+	validator             *validator.Validate
+}
+
+func NewHouseholdHandler(householdService service.HouseholdService, recommendationService service.RecommendationService, logger zerolog.Logger) *HouseholdHandler {
+	return &HouseholdHandler{
+		BaseHandler:           NewBaseHandler(logger),
+		householdService:      householdService,
+		recommendationService: recommendationService,
+		validator:             validator.New(),
+	}
+}
+
+func (h *HouseholdHandler) RegisterRoutes(r chi.Router) {
+	// Specific routes first
+	r.Get("/invitations", h.ListInvitations)
+	r.Post("/invitations/{invitationId}/accept", h.AcceptInvitation)
+	r.Post("/invitations/{invitationId}/decline", h.DeclineInvitation)
+
+	r.Post("/", h.CreateHousehold)
+	r.Get("/", h.ListHouseholds)
+	r.Get("/{id}", h.GetHousehold)
+	r.Delete("/{id}", h.DeleteHousehold)
+	r.Post("/{id}/members", h.AddMember)
+	r.Delete("/{id}/members/{profileId}", h.RemoveMember)
+	r.Post("/{id}/invitations", h.InviteMember)
+	r.Get("/{id}/meal-plan", h.GetMealPlan)
+}
+
+type createHouseholdRequest struct {
+	Name       string      `json:"name" validate:"required,max=100"`
+	ProfileIDs []uuid.UUID `json:"profile_ids"`
+}
+
+type householdMemberRequest struct {
+	ProfileID uuid.UUID `json:"profile_id" validate:"required"`
+}
+
+type householdInvitationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// @Summary Create household
+// @Description Create a household owned by the authenticated user, optionally adding some of their profiles
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param household body docs.HouseholdRequest true "Household"
+// @Success 201 {object} docs.Response{data=docs.HouseholdResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households [post]
+func (h *HouseholdHandler) CreateHousehold(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	var req createHouseholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request body", err))
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid household", err))
+		return
+	}
+
+	result, err := h.householdService.CreateHousehold(r.Context(), userID, req.Name, req.ProfileIDs)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create household")
+		response.Error(w, householdError(err, "Failed to create household"))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, result)
+}
+
+// @Summary List households
+// @Description List the households the authenticated user owns or has a profile in
+// @Tags households
+// @Produce json
+// @Success 200 {object} docs.Response{data=[]docs.HouseholdResponse}
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households [get]
+func (h *HouseholdHandler) ListHouseholds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	households, err := h.householdService.ListHouseholds(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list households")
+		response.Error(w, apperrors.Internal("Failed to list households", err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, households)
+}
+
+// @Summary Get household
+// @Description Get a household and its member profiles
+// @Tags households
+// @Produce json
+// @Param id path string true "Household ID"
+// @Success 200 {object} docs.Response{data=docs.HouseholdResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/{id} [get]
+func (h *HouseholdHandler) GetHousehold(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid household ID", err))
+		return
+	}
+
+	result, err := h.householdService.GetHousehold(r.Context(), userID, id)
+	if err != nil {
+		response.Error(w, householdError(err, "Failed to get household"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// @Summary Delete household
+// @Description Delete a household. Only the owner can delete it; member profiles are not affected.
+// @Tags households
+// @Param id path string true "Household ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/{id} [delete]
+func (h *HouseholdHandler) DeleteHousehold(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid household ID", err))
+		return
+	}
+
+	if err := h.householdService.DeleteHousehold(r.Context(), userID, id); err != nil {
+		h.logger.Error().Err(err).Str("household_id", id.String()).Msg("Failed to delete household")
+		response.Error(w, householdError(err, "Failed to delete household"))
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// @Summary Add household member
+// @Description Add one of the authenticated user's profiles to a household they belong to
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID"
+// @Param member body docs.HouseholdMemberRequest true "Profile to add"
+// @Success 200 {object} docs.Response{data=docs.HouseholdResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/{id}/members [post]
+func (h *HouseholdHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid household ID", err))
+		return
+	}
+
+	var req householdMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request body", err))
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		response.Error(w, apperrors.InvalidInput("profile_id is required", err))
+		return
+	}
+
+	result, err := h.householdService.AddMember(r.Context(), userID, id, req.ProfileID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("household_id", id.String()).Msg("Failed to add household member")
+		response.Error(w, householdError(err, "Failed to add household member"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// @Summary Remove household member
+// @Description Remove a profile from a household. The owner can remove any profile; other members only their own.
+// @Tags households
+// @Param id path string true "Household ID"
+// @Param profileId path string true "Profile ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/{id}/members/{profileId} [delete]
+func (h *HouseholdHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid household ID", err))
+		return
+	}
+	profileID, err := uuid.Parse(chi.URLParam(r, "profileId"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid profile ID", err))
+		return
+	}
+
+	if err := h.householdService.RemoveMember(r.Context(), userID, id, profileID); err != nil {
+		h.logger.Error().Err(err).Str("household_id", id.String()).Msg("Failed to remove household member")
+		response.Error(w, householdError(err, "Failed to remove household member"))
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// @Summary Invite to household
+// @Description Invite another user, by email, to add one of their profiles to the household. Owner only.
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID"
+// @Param invitation body docs.HouseholdInvitationRequest true "Invitee"
+// @Success 201 {object} docs.Response{data=docs.HouseholdInvitationResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/{id}/invitations [post]
+func (h *HouseholdHandler) InviteMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid household ID", err))
+		return
+	}
+
+	var req householdInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request body", err))
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		response.Error(w, apperrors.InvalidInput("A valid email is required", err))
+		return
+	}
+
+	inv, err := h.householdService.InviteMember(r.Context(), userID, id, req.Email)
+	if err != nil {
+		h.logger.Error().Err(err).Str("household_id", id.String()).Msg("Failed to invite household member")
+		response.Error(w, householdError(err, "Failed to create invitation"))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, inv)
+}
+
+// @Summary List household invitations
+// @Description List pending household invitations sent to the authenticated user's email
+// @Tags households
+// @Produce json
+// @Success 200 {object} docs.Response{data=[]docs.HouseholdInvitationResponse}
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/invitations [get]
+func (h *HouseholdHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	invitations, err := h.householdService.ListInvitations(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list invitations")
+		response.Error(w, apperrors.Internal("Failed to list invitations", err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, invitations)
+}
+
+// @Summary Accept household invitation
+// @Description Accept an invitation by adding one of the authenticated user's profiles to the household
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param invitationId path string true "Invitation ID"
+// @Param member body docs.HouseholdMemberRequest true "Profile to add"
+// @Success 200 {object} docs.Response{data=docs.HouseholdResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/invitations/{invitationId}/accept [post]
+func (h *HouseholdHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitationId"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid invitation ID", err))
+		return
+	}
+
+	var req householdMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request body", err))
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		response.Error(w, apperrors.InvalidInput("profile_id is required", err))
+		return
+	}
+
+	result, err := h.householdService.AcceptInvitation(r.Context(), userID, invitationID, req.ProfileID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("invitation_id", invitationID.String()).Msg("Failed to accept invitation")
+		response.Error(w, householdError(err, "Failed to accept invitation"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// @Summary Decline household invitation
+// @Description Decline an invitation sent to the authenticated user
+// @Tags households
+// @Param invitationId path string true "Invitation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/invitations/{invitationId}/decline [post]
+func (h *HouseholdHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	invitationID, err := uuid.Parse(chi.URLParam(r, "invitationId"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid invitation ID", err))
+		return
+	}
+
+	if err := h.householdService.DeclineInvitation(r.Context(), userID, invitationID); err != nil {
+		h.logger.Error().Err(err).Str("invitation_id", invitationID.String()).Msg("Failed to decline invitation")
+		response.Error(w, householdError(err, "Failed to decline invitation"))
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// @Summary Get household meal plan
+// @Description Build one meal plan that excludes every member's allergens and dietary restrictions, with per-member portions scaled to each calorie target
+// @Tags households
+// @Produce json
+// @Param id path string true "Household ID"
+// @Param days query int false "Number of days, at most 30" default(7)
+// @Success 200 {object} docs.Response{data=docs.HouseholdMealPlanResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/households/{id}/meal-plan [get]
+func (h *HouseholdHandler) GetMealPlan(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid household ID", err))
+		return
+	}
+
+	days, err := parseMealPlanDays(r)
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput(fmt.Sprintf("days must be between 1 and %d", maxMealPlanDays), err))
+		return
+	}
+
+	mealPlan, err := h.recommendationService.GetHouseholdMealPlan(r.Context(), userID, id, days)
+	if err != nil {
+		h.logger.Error().Err(err).Str("household_id", id.String()).Msg("Failed to build household meal plan")
+		response.Error(w, householdError(err, "Failed to build household meal plan"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, mealPlan)
+}
+
+// maxMealPlanDays bounds the days of a generated meal plan, each of which
+// scores the whole candidate food list
+const maxMealPlanDays = 30
+
+// parseMealPlanDays reads the days query parameter, defaulting to a week
+func parseMealPlanDays(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("days")
+	if value == "" {
+		return 7, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if days < 1 || days > maxMealPlanDays {
+		return 0, fmt.Errorf("days %d out of range", days)
+	}
+	return days, nil
+}
+
+// householdError maps household service errors onto API errors
+func householdError(err error, message string) *apperrors.AppError {
+	switch {
+	case errors.Is(err, household.ErrNotOwner),
+		errors.Is(err, household.ErrNotMember),
+		errors.Is(err, household.ErrInvitationNotForUser),
+		errors.Is(err, profile.ErrUnauthorized):
+		return apperrors.Forbidden(err.Error(), err)
+	case errors.Is(err, household.ErrInvitationNotPending),
+		errors.Is(err, household.ErrNoMembers):
+		return apperrors.InvalidInput(err.Error(), err)
+	case errors.Is(err, sql.ErrNoRows):
+		return apperrors.NotFound("Household, invitation or profile not found", err)
+	}
+	return apperrors.Internal(message, err)
+}
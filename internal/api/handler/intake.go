@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
+	"github.com/yeboahd24/nutrimatch/internal/domain/intake"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
+)
+
+const dateLayout = "2006-01-02"
+
+type IntakeHandler struct {
+	BaseHandler
+	intakeService service.IntakeService
+	validator     *validator.Validate
+}
+
+func NewIntakeHandler(intakeService service.IntakeService, logger zerolog.Logger) *IntakeHandler {
+	return &IntakeHandler{
+		BaseHandler:   NewBaseHandler(logger),
+		intakeService: intakeService,
+		validator:     validator.New(),
+	}
+}
+
+func (h *IntakeHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/{id}/intake", h.LogIntake)
+	r.Get("/{id}/intake", h.ListIntake)
+	r.Delete("/{id}/intake/{entryId}", h.DeleteIntake)
+}
+
+// @Summary Log food intake
+// @Description Record an amount of a food eaten by a profile
+// @Tags intake
+// @Accept json
+// @Produce json
+// @Param id path string true "Profile ID"
+// @Param entry body docs.IntakeRequest true "Intake entry"
+// @Success 201 {object} docs.Response{data=docs.IntakeResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/profiles/{id}/intake [post]
+func (h *IntakeHandler) LogIntake(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	profileID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid profile ID", err))
+		return
+	}
+
+	var entry intake.Entry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request body", err))
+		return
+	}
+	if err := h.validator.Struct(entry); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid intake entry", err))
+		return
+	}
+	entry.ProfileID = profileID
+
+	created, err := h.intakeService.LogIntake(r.Context(), userID, &entry)
+	if err != nil {
+		h.logger.Error().Err(err).Str("profile_id", profileID.String()).Msg("Failed to log intake")
+		response.Error(w, profileAccessError(err, "Failed to log intake"))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, created)
+}
+
+// @Summary List food intake
+// @Description List a profile's logged intake between two dates (inclusive)
+// @Tags intake
+// @Produce json
+// @Param id path string true "Profile ID"
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 6 days before to"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} docs.Response{data=[]docs.IntakeResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/profiles/{id}/intake [get]
+func (h *IntakeHandler) ListIntake(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	profileID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid profile ID", err))
+		return
+	}
+
+	from, to, err := parseDateRange(r, 7)
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid date range, expected YYYY-MM-DD", err))
+		return
+	}
+
+	entries, err := h.intakeService.ListIntake(r.Context(), userID, profileID, from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Str("profile_id", profileID.String()).Msg("Failed to list intake")
+		response.Error(w, profileAccessError(err, "Failed to list intake"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, entries)
+}
+
+// @Summary Delete intake entry
+// @Description Remove a logged intake entry
+// @Tags intake
+// @Param id path string true "Profile ID"
+// @Param entryId path string true "Intake entry ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/profiles/{id}/intake/{entryId} [delete]
+func (h *IntakeHandler) DeleteIntake(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	profileID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid profile ID", err))
+		return
+	}
+	entryID, err := uuid.Parse(chi.URLParam(r, "entryId"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid intake entry ID", err))
+		return
+	}
+
+	if err := h.intakeService.DeleteIntake(r.Context(), userID, profileID, entryID); err != nil {
+		h.logger.Error().Err(err).Str("profile_id", profileID.String()).Msg("Failed to delete intake")
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apperrors.NotFound("Profile or intake entry not found", err))
+			return
+		}
+		response.Error(w, profileAccessError(err, "Failed to delete intake"))
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// profileAccessError maps service errors for profile-scoped endpoints onto API errors
+func profileAccessError(err error, message string) *apperrors.AppError {
+	switch {
+	case errors.Is(err, profile.ErrUnauthorized):
+		return apperrors.Forbidden("You do not have access to this profile", err)
+	case errors.Is(err, sql.ErrNoRows):
+		return apperrors.NotFound("Profile or food not found", err)
+	}
+	return apperrors.Internal(message, err)
+}
+
+// parseDateRange reads the from/to query parameters as whole days. The
+// returned end is exclusive (midnight after to). Without from, the range
+// covers the defaultDays days up to and including to.
+func parseDateRange(r *http.Request, defaultDays int) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if v := r.URL.Query().Get("to"); v != "" {
+		parsed, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultDays - 1))
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+
+	end := to.AddDate(0, 0, 1)
+	if !from.Before(end) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+	return from, end, nil
+}
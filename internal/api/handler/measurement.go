@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
+	"github.com/yeboahd24/nutrimatch/internal/domain/measurement"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
+)
+
+type MeasurementHandler struct {
+	BaseHandler
+	measurementService service.MeasurementService
+	validator          *validator.Validate
+}
+
+func NewMeasurementHandler(measurementService service.MeasurementService, logger zerolog.Logger) *MeasurementHandler {
+	return &MeasurementHandler{
+		BaseHandler:        NewBaseHandler(logger),
+		measurementService: measurementService,
+		validator:          validator.New(),
+	}
+}
+
+func (h *MeasurementHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/measurements", h.RecordMeasurement)
+	r.Get("/measurements", h.ListMeasurements)
+	r.Delete("/measurements/{id}", h.DeleteMeasurement)
+}
+
+// @Summary Record body measurement
+// @Description Record a weight or body measurement. Measurement times in the future are rejected. Weight readings update the smoothed trend and may adjust the default profile's calorie target when the trend drifts from its goal.
+// @Tags measurements
+// @Accept json
+// @Produce json
+// @Param measurement body docs.MeasurementRequest true "Measurement"
+// @Success 201 {object} docs.Response{data=docs.MeasurementRecordResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users/me/measurements [post]
+func (h *MeasurementHandler) RecordMeasurement(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	var m measurement.Measurement
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request body", err))
+		return
+	}
+	if err := h.validator.Struct(m); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid measurement", err))
+		return
+	}
+
+	result, err := h.measurementService.RecordMeasurement(r.Context(), userID, &m)
+	if err != nil {
+		if errors.Is(err, measurement.ErrFutureTime) {
+			response.Error(w, apperrors.InvalidInput("Measurement time cannot be in the future", err))
+			return
+		}
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to record measurement")
+		response.Error(w, apperrors.Internal("Failed to record measurement", err))
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, result)
+}
+
+// @Summary List body measurements
+// @Description List measurements of one type with their exponential moving average trend and weekly rate of change
+// @Tags measurements
+// @Produce json
+// @Param type query string false "Measurement type (weight, body_fat, waist, hip, chest, neck)" default(weight)
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 90 days before to"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} docs.Response{data=docs.MeasurementSeriesResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users/me/measurements [get]
+func (h *MeasurementHandler) ListMeasurements(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	measurementType := r.URL.Query().Get("type")
+	if measurementType == "" {
+		measurementType = measurement.TypeWeight
+	}
+
+	from, to, err := parseDateRange(r, 90)
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid date range, expected YYYY-MM-DD", err))
+		return
+	}
+
+	series, err := h.measurementService.ListMeasurements(r.Context(), userID, measurementType, from, to)
+	if err != nil {
+		if errors.Is(err, measurement.ErrUnknownType) {
+			response.Error(w, apperrors.InvalidInput("Unknown measurement type", err))
+			return
+		}
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list measurements")
+		response.Error(w, apperrors.Internal("Failed to list measurements", err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, series)
+}
+
+// @Summary Delete body measurement
+// @Description Delete a recorded measurement
+// @Tags measurements
+// @Param id path string true "Measurement ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/users/me/measurements/{id} [delete]
+func (h *MeasurementHandler) DeleteMeasurement(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid measurement ID", err))
+		return
+	}
+
+	if err := h.measurementService.DeleteMeasurement(r.Context(), userID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(w, apperrors.NotFound("Measurement not found", err))
+			return
+		}
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to delete measurement")
+		response.Error(w, apperrors.Internal("Failed to delete measurement", err))
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// @Summary List calorie target adjustments
+// @Description List automatic calorie target changes made for a profile from its weight trend
+// @Tags measurements
+// @Produce json
+// @Param id path string true "Profile ID"
+// @Success 200 {object} docs.Response{data=[]docs.CalorieAdjustmentResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/profiles/{id}/calorie-adjustments [get]
+func (h *MeasurementHandler) ListCalorieAdjustments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	profileID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid profile ID", err))
+		return
+	}
+
+	adjustments, err := h.measurementService.ListCalorieAdjustments(r.Context(), userID, profileID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("profile_id", profileID.String()).Msg("Failed to list calorie adjustments")
+		response.Error(w, profileAccessError(err, "Failed to list calorie adjustments"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, adjustments)
+}
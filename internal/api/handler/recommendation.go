@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -9,8 +12,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
 	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
 	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
 )
 
 type RecommendationHandler struct {
@@ -29,6 +36,7 @@ func (h *RecommendationHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/", h.GetRecommendations)
 	r.Post("/filter", h.FilterRecommendations)
 	r.Get("/alternatives/{foodId}", h.GetFoodAlternatives)
+	r.Get("/check", h.CheckFood)
 }
 
 func (h *RecommendationHandler) GetDailyRecommendations(w http.ResponseWriter, r *http.Request) {
@@ -128,6 +136,7 @@ func (h *RecommendationHandler) GetFoodAlternatives(w http.ResponseWriter, r *ht
 // @Produce json
 // @Param limit query int false "Number of recommendations to return" default(10)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param cursor query string false "pagination.next_cursor of the previous page; continues after it instead of using offset"
 // @Param profileId query string false "Profile ID to use for recommendations"
 // @Success 200 {object} docs.Response{data=docs.RecommendationResponse}
 // @Failure 401 {object} docs.ErrorResponse
@@ -162,10 +171,15 @@ func (h *RecommendationHandler) GetRecommendations(w http.ResponseWriter, r *htt
 		ProfileID: profileUUID,
 		Limit:     limit,
 		Offset:    offset,
+		Cursor:    r.URL.Query().Get("cursor"),
 	}
 
 	// Get recommendations
 	resp, err := h.recommendationService.GetRecommendations(userID, req)
+	if errors.Is(err, food.ErrInvalidCursor) {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to get recommendations")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -181,8 +195,9 @@ func (h *RecommendationHandler) GetRecommendations(w http.ResponseWriter, r *htt
 			"total_count":     resp.TotalCount,
 			"applied_rules":   resp.AppliedRules,
 			"pagination": map[string]interface{}{
-				"limit":  limit,
-				"offset": offset,
+				"limit":       limit,
+				"offset":      offset,
+				"next_cursor": resp.NextCursor,
 			},
 		},
 	})
@@ -217,6 +232,10 @@ func (h *RecommendationHandler) FilterRecommendations(w http.ResponseWriter, r *
 
 	// Get filtered recommendations
 	resp, err := h.recommendationService.GetRecommendations(userID, req)
+	if errors.Is(err, food.ErrInvalidCursor) {
+		http.Error(w, "invalid cursor", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to filter recommendations")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -232,9 +251,133 @@ func (h *RecommendationHandler) FilterRecommendations(w http.ResponseWriter, r *
 			"total_count":     resp.TotalCount,
 			"applied_rules":   resp.AppliedRules,
 			"pagination": map[string]interface{}{
-				"limit":  req.Limit,
-				"offset": req.Offset,
+				"limit":       req.Limit,
+				"offset":      req.Offset,
+				"next_cursor": resp.NextCursor,
 			},
 		},
 	})
 }
+
+// @Summary Get nutrient gap report
+// @Description Compare a profile's average daily intake against targets derived from age/sex reference intakes, the calorie target and health conditions, and suggest foods that close each deficit
+// @Tags recommendations
+// @Produce json
+// @Param id path string true "Profile ID"
+// @Param source query string false "Intake source: intake (logged food) or meal_plan" default(intake)
+// @Param from query string false "Start date (YYYY-MM-DD) for source=intake"
+// @Param to query string false "End date (YYYY-MM-DD) for source=intake"
+// @Param days query int false "Number of meal plan days for source=meal_plan, at most 30" default(7)
+// @Param limit query int false "Number of suggested foods per gap" default(5)
+// @Success 200 {object} docs.Response{data=docs.GapReportResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/profiles/{id}/gaps [get]
+func (h *RecommendationHandler) GetNutrientGaps(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	profileID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid profile ID", err))
+		return
+	}
+
+	req := recommendation.GapRequest{
+		ProfileID: profileID,
+		Source:    r.URL.Query().Get("source"),
+	}
+	req.SuggestionLimit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+
+	switch req.Source {
+	case "", recommendation.GapSourceIntake:
+		req.Source = recommendation.GapSourceIntake
+		req.From, req.To, err = parseDateRange(r, 7)
+		if err != nil {
+			response.Error(w, apperrors.InvalidInput("Invalid date range, expected YYYY-MM-DD", err))
+			return
+		}
+	case recommendation.GapSourceMealPlan:
+		req.Days, err = parseMealPlanDays(r)
+		if err != nil {
+			response.Error(w, apperrors.InvalidInput(fmt.Sprintf("days must be between 1 and %d", maxMealPlanDays), err))
+			return
+		}
+	default:
+		response.Error(w, apperrors.InvalidInput("source must be intake or meal_plan", nil))
+		return
+	}
+
+	report, err := h.recommendationService.GetNutrientGaps(r.Context(), userID, req)
+	if err != nil {
+		h.logger.Error().Err(err).Str("profile_id", profileID.String()).Msg("Failed to build nutrient gap report")
+		response.Error(w, profileAccessError(err, "Failed to build nutrient gap report"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, report)
+}
+
+// @Summary Check food compatibility
+// @Description Look up a food by ID or EAN-13 barcode and evaluate it against the user's default or specified profile. The verdict is avoid when an allergen or dietary restriction is violated, caution for any other violated rule, and safe otherwise.
+// @Tags recommendations
+// @Produce json
+// @Param food_id query string false "Food ID (food_id or ean is required)"
+// @Param ean query string false "EAN-13, EAN-8, UPC-A or UPC-E barcode"
+// @Param profileId query string false "Profile ID to check against, defaults to the default profile"
+// @Success 200 {object} docs.Response{data=docs.CheckResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/recommendations/check [get]
+func (h *RecommendationHandler) CheckFood(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("unauthorized", nil))
+		return
+	}
+
+	req := recommendation.CheckRequest{
+		FoodID: r.URL.Query().Get("food_id"),
+		EAN13:  r.URL.Query().Get("ean"),
+	}
+	if (req.FoodID == "") == (req.EAN13 == "") {
+		response.Error(w, apperrors.InvalidInput("Exactly one of food_id or ean is required", nil))
+		return
+	}
+
+	if v := r.URL.Query().Get("profileId"); v != "" {
+		profileID, err := uuid.Parse(v)
+		if err != nil {
+			response.Error(w, apperrors.InvalidInput("Invalid profile ID", err))
+			return
+		}
+		req.ProfileID = &profileID
+	}
+
+	result, err := h.recommendationService.CheckFood(r.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, food.ErrInvalidBarcode), errors.Is(err, food.ErrInvalidCheckDigit):
+			response.Error(w, apperrors.InvalidInput(err.Error(), err))
+		case errors.Is(err, profile.ErrUnauthorized):
+			response.Error(w, apperrors.Forbidden("You do not have access to this profile", err))
+		case errors.Is(err, sql.ErrNoRows):
+			response.Error(w, apperrors.NotFound("Food or profile not found", err))
+		default:
+			h.logger.Error().Err(err).Msg("Failed to check food")
+			response.Error(w, apperrors.Internal("Failed to check food", err))
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
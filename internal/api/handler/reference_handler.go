@@ -25,6 +25,7 @@ func (h *ReferenceHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/allergens", h.GetAllergens)
 	r.Get("/health-conditions", h.GetHealthConditions)
 	r.Get("/dietary-patterns", h.GetDietaryPatterns)
+	r.Get("/food-categories", h.GetFoodCategories)
 }
 
 // @Summary Get allergens
@@ -83,3 +84,21 @@ func (h *ReferenceHandler) GetDietaryPatterns(w http.ResponseWriter, r *http.Req
 
 	response.JSON(w, http.StatusOK, patterns)
 }
+
+// @Summary Get food category taxonomy
+// @Description Get the hierarchical food category taxonomy with the food types mapped to each category
+// @Tags reference
+// @Produce json
+// @Success 200 {object} docs.Response{data=[]docs.FoodCategoryResponse}
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/reference/food-categories [get]
+func (h *ReferenceHandler) GetFoodCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.referenceService.GetFoodCategories(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to get food categories")
+		response.Error(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, categories)
+}
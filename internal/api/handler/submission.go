@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/submission"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+	apperrors "github.com/yeboahd24/nutrimatch/pkg/errors"
+	"github.com/yeboahd24/nutrimatch/pkg/response"
+)
+
+// SubmissionHandler serves user food submissions and their moderation
+type SubmissionHandler struct {
+	BaseHandler
+	submissionService service.SubmissionService
+	validator         *validator.Validate
+}
+
+func NewSubmissionHandler(submissionService service.SubmissionService, logger zerolog.Logger) *SubmissionHandler {
+	return &SubmissionHandler{
+		BaseHandler:       NewBaseHandler(logger),
+		submissionService: submissionService,
+		validator:         validator.New(),
+	}
+}
+
+// RegisterRoutes registers the submitter routes
+func (h *SubmissionHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/", h.Submit)
+	r.Get("/", h.ListMySubmissions)
+	r.Get("/{id}", h.GetMySubmission)
+}
+
+// RegisterModerationRoutes registers the moderator routes. The caller must
+// restrict them to moderators.
+func (h *SubmissionHandler) RegisterModerationRoutes(r chi.Router) {
+	r.Get("/", h.ListSubmissions)
+	r.Get("/{id}", h.GetSubmission)
+	r.Patch("/{id}", h.EditSubmission)
+	r.Post("/{id}/approve", h.ApproveSubmission)
+	r.Post("/{id}/reject", h.RejectSubmission)
+}
+
+// @Summary Submit a food
+// @Description Submit a food missing from the catalog. It stays pending, and out of search, until a moderator approves it.
+// @Tags submissions
+// @Accept json
+// @Produce json
+// @Param submission body docs.FoodSubmissionRequest true "Food"
+// @Security BearerAuth
+// @Success 201 {object} docs.Response{data=docs.FoodSubmissionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 409 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/food-submissions [post]
+func (h *SubmissionHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	var input submission.Input
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+	if err := h.validator.Struct(input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Validation failed", err))
+		return
+	}
+
+	sub, err := h.submissionService.Submit(r.Context(), userID, input)
+	if err != nil {
+		h.submissionError(w, err, "Failed to submit food")
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, sub)
+}
+
+// @Summary List my food submissions
+// @Description List the current user's food submissions and their review status, newest first
+// @Tags submissions
+// @Produce json
+// @Param status query string false "Review status" Enums(pending, approved, rejected)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=[]docs.FoodSubmissionResponse,meta=docs.PaginationMeta}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/food-submissions [get]
+func (h *SubmissionHandler) ListMySubmissions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	page, limit := pageParams(r)
+	subs, total, err := h.submissionService.ListMySubmissions(r.Context(), userID, r.URL.Query().Get("status"), page, limit)
+	if err != nil {
+		h.submissionError(w, err, "Failed to list food submissions")
+		return
+	}
+
+	response.JSONWithMeta(w, http.StatusOK, subs, response.PaginationMeta(page, limit, total))
+}
+
+// @Summary Get my food submission
+// @Description Get one of the current user's food submissions, including the rejection reason when rejected
+// @Tags submissions
+// @Produce json
+// @Param id path string true "Submission ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodSubmissionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/food-submissions/{id} [get]
+func (h *SubmissionHandler) GetMySubmission(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid submission ID", err))
+		return
+	}
+
+	sub, err := h.submissionService.GetMySubmission(r.Context(), userID, id)
+	if err != nil {
+		h.submissionError(w, err, "Failed to get food submission")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sub)
+}
+
+// @Summary List food submissions for moderation
+// @Description List food submissions with a status, oldest first. Defaults to the pending queue.
+// @Tags moderation
+// @Produce json
+// @Param status query string false "Review status" Enums(pending, approved, rejected) default(pending)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=[]docs.FoodSubmissionResponse,meta=docs.PaginationMeta}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/moderation/submissions [get]
+func (h *SubmissionHandler) ListSubmissions(w http.ResponseWriter, r *http.Request) {
+	page, limit := pageParams(r)
+	subs, total, err := h.submissionService.ListSubmissions(r.Context(), r.URL.Query().Get("status"), page, limit)
+	if err != nil {
+		h.submissionError(w, err, "Failed to list food submissions")
+		return
+	}
+
+	response.JSONWithMeta(w, http.StatusOK, subs, response.PaginationMeta(page, limit, total))
+}
+
+// @Summary Get a food submission for moderation
+// @Description Get any food submission
+// @Tags moderation
+// @Produce json
+// @Param id path string true "Submission ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodSubmissionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/moderation/submissions/{id} [get]
+func (h *SubmissionHandler) GetSubmission(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid submission ID", err))
+		return
+	}
+
+	sub, err := h.submissionService.GetSubmission(r.Context(), id)
+	if err != nil {
+		h.submissionError(w, err, "Failed to get food submission")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sub)
+}
+
+// @Summary Edit a food submission
+// @Description Apply a JSON merge patch (RFC 7386) to the food of a pending submission before approving it
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Submission ID"
+// @Param patch body object true "Merge patch of editable food fields"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodSubmissionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 409 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/moderation/submissions/{id} [patch]
+func (h *SubmissionHandler) EditSubmission(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid submission ID", err))
+		return
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+
+	sub, err := h.submissionService.EditSubmission(r.Context(), id, patch)
+	if err != nil {
+		h.submissionError(w, err, "Failed to edit food submission")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sub)
+}
+
+// @Summary Approve a food submission
+// @Description Promote a pending submission into the food catalog. The created food's ID is returned in food_id.
+// @Tags moderation
+// @Produce json
+// @Param id path string true "Submission ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodSubmissionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 409 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/moderation/submissions/{id}/approve [post]
+func (h *SubmissionHandler) ApproveSubmission(w http.ResponseWriter, r *http.Request) {
+	moderatorID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid submission ID", err))
+		return
+	}
+
+	sub, err := h.submissionService.ApproveSubmission(r.Context(), moderatorID, id)
+	if err != nil {
+		h.submissionError(w, err, "Failed to approve food submission")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sub)
+}
+
+// @Summary Reject a food submission
+// @Description Reject a pending submission with a reason shown to the submitter
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Submission ID"
+// @Param request body docs.RejectSubmissionRequest true "Rejection reason"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response{data=docs.FoodSubmissionResponse}
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 403 {object} docs.ErrorResponse
+// @Failure 404 {object} docs.ErrorResponse
+// @Failure 409 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/moderation/submissions/{id}/reject [post]
+func (h *SubmissionHandler) RejectSubmission(w http.ResponseWriter, r *http.Request) {
+	moderatorID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid submission ID", err))
+		return
+	}
+
+	var input struct {
+		Reason string `json:"reason" validate:"required,max=1000"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+	if err := h.validator.Struct(input); err != nil {
+		response.Error(w, apperrors.InvalidInput("A rejection reason is required", err))
+		return
+	}
+
+	sub, err := h.submissionService.RejectSubmission(r.Context(), moderatorID, id, input.Reason)
+	if err != nil {
+		h.submissionError(w, err, "Failed to reject food submission")
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sub)
+}
+
+// submissionError maps submission errors to API errors. Another user's
+// submission is reported as not found rather than revealing it exists.
+func (h *SubmissionHandler) submissionError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, submission.ErrNotSubmitter):
+		response.Error(w, apperrors.NotFound("submission", err))
+	case errors.Is(err, submission.ErrNotPending), errors.Is(err, submission.ErrBarcodeInCatalog),
+		errors.Is(err, food.ErrFoodExists):
+		response.Error(w, apperrors.DuplicateEntity(err.Error(), err))
+	case errors.Is(err, submission.ErrUnknownStatus), errors.Is(err, food.ErrFieldNotEditable),
+		errors.Is(err, food.ErrInvalidPatch), errors.Is(err, food.ErrNameRequired),
+		errors.Is(err, food.ErrInvalidBarcode), errors.Is(err, food.ErrInvalidCheckDigit):
+		response.Error(w, apperrors.InvalidInput(err.Error(), err))
+	default:
+		h.logger.Error().Err(err).Msg(message)
+		response.Error(w, apperrors.Internal(message, err))
+	}
+}
+
+// pageParams reads the page and limit query parameters, defaulting to the
+// first page of 10 and capping the limit at 100
+func pageParams(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return page, limit
+}
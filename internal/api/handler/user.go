@@ -208,3 +208,44 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	response.JSON(w, http.StatusOK, updatedUser)
 }
+
+// @Summary Set the preferred language
+// @Description Set the language food names and descriptions are shown in when signed in. It takes precedence over the Accept-Language header; foods without a translation are shown in English.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param settings body docs.UserLocaleRequest true "Preferred language"
+// @Security BearerAuth
+// @Success 200 {object} docs.Response
+// @Failure 400 {object} docs.ErrorResponse
+// @Failure 401 {object} docs.ErrorResponse
+// @Failure 500 {object} docs.ErrorResponse
+// @Router /api/v1/users/me/locale [put]
+func (h *UserHandler) UpdateLocale(w http.ResponseWriter, r *http.Request) {
+	userID, ok := auth.GetUserID(r)
+	if !ok {
+		response.Error(w, apperrors.Unauthorized("Unauthorized", nil))
+		return
+	}
+
+	var input struct {
+		Locale string `json:"locale" validate:"required,oneof=en fr de ak ee gaa"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		response.Error(w, apperrors.InvalidInput("Invalid request payload", err))
+		return
+	}
+	if err := h.validator.Struct(input); err != nil {
+		response.Error(w, apperrors.InvalidInput("locale must be one of en, fr, de, ak, ee, gaa", err))
+		return
+	}
+
+	updatedUser, err := h.userService.UpdateUser(r.Context(), userID.String(), &user.UpdateUserInput{Locale: &input.Locale})
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to update user locale")
+		response.Error(w, apperrors.Internal("Failed to update user locale", err))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, updatedUser)
+}
@@ -66,6 +66,32 @@ func Middleware(cfg config.JWTConfig) func(http.Handler) http.Handler {
 	}
 }
 
+// OptionalMiddleware adds the user information to the request context when
+// the request carries a valid bearer token, and otherwise passes it on
+// unchanged, so that public routes can tailor responses to signed-in users
+func OptionalMiddleware(cfg config.JWTConfig) func(http.Handler) http.Handler {
+	jwtService := auth.NewJWTService(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			claims, err := jwtService.ValidateAccessToken(token)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+			ctx = context.WithValue(ctx, EmailKey, claims.Email)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 // GetUserID gets the user ID from the request context
 func GetUserID(r *http.Request) (uuid.UUID, bool) {
 	userID, ok := r.Context().Value(UserIDKey).(uuid.UUID)
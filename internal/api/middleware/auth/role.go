@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// RoleLookup returns the role of a user
+type RoleLookup func(userID uuid.UUID) (string, error)
+
+// RequireRole creates a middleware that only lets users with one of the given
+// roles through. It must run after Middleware, which sets the user ID. The role
+// is looked up on every request so that revoking it takes effect immediately.
+func RequireRole(lookup RoleLookup, roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := GetUserID(r)
+			if !ok {
+				http.Error(w, "Authentication required", http.StatusUnauthorized)
+				return
+			}
+
+			userRole, err := lookup(userID)
+			if err != nil {
+				http.Error(w, "Failed to check user role", http.StatusInternalServerError)
+				return
+			}
+			for _, role := range roles {
+				if userRole == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			http.Error(w, "Insufficient permissions", http.StatusForbidden)
+		})
+	}
+}
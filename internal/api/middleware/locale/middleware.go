@@ -0,0 +1,39 @@
+package locale
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// Lookup returns the preferred locale of a user, empty when they have none
+type Lookup func(userID uuid.UUID) (string, error)
+
+// Middleware sets the locale food names and descriptions are shown in: the
+// signed-in user's preferred locale, else the best supported language in
+// the Accept-Language header, else English. It must run after
+// auth.OptionalMiddleware for the user's preference to apply.
+func Middleware(lookup Lookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := food.DefaultLocale
+			if preferred, ok := food.ParseAcceptLanguage(r.Header.Get("Accept-Language")); ok {
+				locale = preferred
+			}
+			if userID, ok := auth.GetUserID(r); ok {
+				// A failed lookup falls back to the header rather than failing the request
+				if preferred, err := lookup(userID); err == nil {
+					if normalized, ok := food.NormalizeLocale(preferred); ok {
+						locale = normalized
+					}
+				}
+			}
+
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r.WithContext(food.ContextWithLocale(r.Context(), locale)))
+		})
+	}
+}
@@ -12,12 +12,15 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/go-chi/httprate"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/yeboahd24/nutrimatch/internal/api/handler"
 	authMiddleware "github.com/yeboahd24/nutrimatch/internal/api/middleware/auth"
 	errorsMiddleware "github.com/yeboahd24/nutrimatch/internal/api/middleware/errors"
+	localeMiddleware "github.com/yeboahd24/nutrimatch/internal/api/middleware/locale"
 	"github.com/yeboahd24/nutrimatch/internal/config"
+	"github.com/yeboahd24/nutrimatch/internal/domain/user"
 	"github.com/yeboahd24/nutrimatch/internal/repository/postgres"
 	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
 	"github.com/yeboahd24/nutrimatch/internal/service"
@@ -55,7 +58,7 @@ func NewServer(cfg *config.AppConfig, logger zerolog.Logger) (*Server, error) {
 	// CORS
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
@@ -93,6 +96,13 @@ func (s *Server) registerRoutes() error {
 	foodRepo := postgres.NewFoodRepository(queries)
 	authRepo := postgres.NewAuthRepository(queries)
 	referenceRepo := postgres.NewReferenceRepository(queries)
+	intakeRepo := postgres.NewIntakeRepository(queries)
+	measurementRepo := postgres.NewMeasurementRepository(s.DB, queries)
+	householdRepo := postgres.NewHouseholdRepository(queries)
+	submissionRepo := postgres.NewSubmissionRepository(s.DB, queries)
+	exportRepo := postgres.NewFoodExportRepository(s.DB, queries)
+	editRepo := postgres.NewFoodEditRepository(s.DB, queries)
+	mergeRepo := postgres.NewFoodMergeRepository(s.DB, queries)
 
 	// Create services
 	passwordService := auth.NewPasswordService(s.Config.Security)
@@ -100,17 +110,49 @@ func (s *Server) registerRoutes() error {
 	userService := service.NewUserService(userRepo, authRepo, jwtService, passwordService, s.Logger)
 	authService := service.NewAuthService(userRepo, authRepo, jwtService, passwordService, s.Logger)
 	profileService := service.NewProfileService(profileRepo, userRepo, s.Logger)
-	foodService := service.NewFoodService(foodRepo, s.Logger)
-	recommendationService := service.NewRecommendationService(foodRepo, profileRepo, s.Logger)
+	foodService := service.NewFoodService(foodRepo, editRepo, mergeRepo, referenceRepo, s.Logger)
+	recommendationService := service.NewRecommendationService(foodRepo, profileRepo, userRepo, referenceRepo, intakeRepo, householdRepo, s.Logger)
 	referenceService := service.NewReferenceService(referenceRepo, s.Logger)
+	intakeService := service.NewIntakeService(intakeRepo, profileRepo, foodRepo, s.Logger)
+	measurementService := service.NewMeasurementService(measurementRepo, profileRepo, userRepo, s.Logger)
+	householdService := service.NewHouseholdService(householdRepo, profileRepo, userRepo, s.Logger)
+	submissionService := service.NewSubmissionService(submissionRepo, foodRepo, s.Logger)
+	exportService := service.NewExportService(exportRepo, s.Logger)
 
 	// Create handlers
 	authHandler := handler.NewAuthHandler(authService, s.Logger)
 	userHandler := handler.NewUserHandler(userService, s.Logger)
 	profileHandler := handler.NewProfileHandler(profileService, s.Logger)
 	foodHandler := handler.NewFoodHandler(foodService, s.Logger, s.Config.JWT)
+	adminFoodHandler := handler.NewAdminFoodHandler(foodService, s.Logger)
 	recommendationHandler := handler.NewRecommendationHandler(recommendationService, s.Logger)
 	referenceHandler := handler.NewReferenceHandler(referenceService, s.Logger)
+	intakeHandler := handler.NewIntakeHandler(intakeService, s.Logger)
+	measurementHandler := handler.NewMeasurementHandler(measurementService, s.Logger)
+	categoryHandler := handler.NewCategoryHandler(foodService, s.Logger)
+	householdHandler := handler.NewHouseholdHandler(householdService, recommendationService, s.Logger)
+	submissionHandler := handler.NewSubmissionHandler(submissionService, s.Logger)
+	exportHandler := handler.NewExportHandler(exportService, s.Logger)
+
+	userRole := func(userID uuid.UUID) (string, error) {
+		u, err := userService.GetByID(userID)
+		if err != nil {
+			return "", err
+		}
+		return u.Role, nil
+	}
+	userLocale := func(userID uuid.UUID) (string, error) {
+		u, err := userService.GetByID(userID)
+		if err != nil {
+			return "", err
+		}
+		return u.Locale, nil
+	}
+
+	// Pick the language of food names for every request, honouring the
+	// signed-in user's preference on public routes too
+	s.Router.Use(authMiddleware.OptionalMiddleware(s.Config.JWT))
+	s.Router.Use(localeMiddleware.Middleware(userLocale))
 
 	// Public routes
 	s.Router.Group(func(r chi.Router) {
@@ -161,6 +203,9 @@ func (s *Server) registerRoutes() error {
 		// Food routes (public)
 		r.Route("/api/v1/foods", foodHandler.RegisterRoutes)
 
+		// Food category routes (public)
+		r.Route("/api/v1/categories", categoryHandler.RegisterRoutes)
+
 		// Reference data routes (public)
 		r.Route("/api/v1/reference", referenceHandler.RegisterRoutes)
 
@@ -180,13 +225,41 @@ func (s *Server) registerRoutes() error {
 		r.Route("/api/v1/users/me", func(r chi.Router) {
 			r.Get("/", userHandler.GetProfile)
 			r.Put("/", userHandler.UpdateProfile)
+			r.Put("/locale", userHandler.UpdateLocale)
+			measurementHandler.RegisterRoutes(r)
 		})
 
 		// Profile routes
-		r.Route("/api/v1/profiles", profileHandler.RegisterRoutes)
+		r.Route("/api/v1/profiles", func(r chi.Router) {
+			profileHandler.RegisterRoutes(r)
+			intakeHandler.RegisterRoutes(r)
+			r.Get("/{id}/gaps", recommendationHandler.GetNutrientGaps)
+			r.Get("/{id}/calorie-adjustments", measurementHandler.ListCalorieAdjustments)
+		})
 
 		// Recommendation routes
 		r.Route("/api/v1/recommendations", recommendationHandler.RegisterRoutes)
+
+		// Household routes
+		r.Route("/api/v1/households", householdHandler.RegisterRoutes)
+
+		// Food submission routes
+		r.Route("/api/v1/food-submissions", submissionHandler.RegisterRoutes)
+
+		// Catalog export routes
+		r.Route("/api/v1/exports", exportHandler.RegisterRoutes)
+
+		// Moderation routes
+		r.Route("/api/v1/moderation/submissions", func(r chi.Router) {
+			r.Use(authMiddleware.RequireRole(userRole, user.RoleModerator, user.RoleAdmin))
+			submissionHandler.RegisterModerationRoutes(r)
+		})
+
+		// Admin food catalog routes
+		r.Route("/api/v1/admin/foods", func(r chi.Router) {
+			r.Use(authMiddleware.RequireRole(userRole, user.RoleAdmin))
+			adminFoodHandler.RegisterRoutes(r)
+		})
 	})
 
 	return nil
@@ -0,0 +1,96 @@
+package food
+
+import (
+	"errors"
+	"strings"
+)
+
+// Barcode validation errors
+var (
+	ErrInvalidBarcode     = errors.New("barcode must be an EAN-13, EAN-8, UPC-A or UPC-E code")
+	ErrInvalidCheckDigit  = errors.New("barcode check digit is invalid")
+	errUnsupportedBarcode = errors.New("unsupported UPC-E number system")
+)
+
+// NormalizeBarcode validates an EAN-13, EAN-8, UPC-A or UPC-E barcode and
+// returns the GTIN-13 candidates it can stand for. Eight digit codes can be
+// either EAN-8 or UPC-E, so they may yield two candidates, EAN-8 first.
+func NormalizeBarcode(code string) ([]string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return nil, ErrInvalidBarcode
+		}
+	}
+
+	switch len(digits) {
+	case 13:
+		if !validCheckDigit(digits) {
+			return nil, ErrInvalidCheckDigit
+		}
+		return []string{digits}, nil
+	case 12:
+		if !validCheckDigit(digits) {
+			return nil, ErrInvalidCheckDigit
+		}
+		return []string{"0" + digits}, nil
+	case 8:
+		var candidates []string
+		if validCheckDigit(digits) {
+			candidates = append(candidates, "00000"+digits)
+		}
+		if upcA, err := expandUPCE(digits); err == nil && validCheckDigit(upcA) {
+			candidates = append(candidates, "0"+upcA)
+		}
+		if len(candidates) == 0 {
+			return nil, ErrInvalidCheckDigit
+		}
+		return candidates, nil
+	}
+	return nil, ErrInvalidBarcode
+}
+
+// NormalizeGTIN returns the preferred GTIN-13 for a barcode
+func NormalizeGTIN(code string) (string, error) {
+	candidates, err := NormalizeBarcode(code)
+	if err != nil {
+		return "", err
+	}
+	return candidates[0], nil
+}
+
+// validCheckDigit verifies the trailing GS1 check digit: digits are weighted
+// 3 and 1 alternately from the right, excluding the check digit itself
+func validCheckDigit(digits string) bool {
+	sum := 0
+	for i := len(digits) - 2; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10-sum%10)%10 == int(digits[len(digits)-1]-'0')
+}
+
+// expandUPCE converts an 8-digit UPC-E code (number system, six digits,
+// check digit) into the equivalent 12-digit UPC-A code
+func expandUPCE(upcE string) (string, error) {
+	ns, d, check := upcE[0], upcE[1:7], upcE[7]
+	if ns != '0' && ns != '1' {
+		return "", errUnsupportedBarcode
+	}
+
+	var manufacturer, product string
+	switch d[5] {
+	case '0', '1', '2':
+		manufacturer, product = d[0:2]+d[5:6]+"00", "00"+d[2:5]
+	case '3':
+		manufacturer, product = d[0:3]+"00", "000"+d[3:5]
+	case '4':
+		manufacturer, product = d[0:4]+"0", "0000"+d[4:5]
+	default:
+		manufacturer, product = d[0:5], "0000"+d[5:6]
+	}
+	return string(ns) + manufacturer + product + string(check), nil
+}
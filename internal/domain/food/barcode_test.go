@@ -0,0 +1,70 @@
+package food
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []string
+		err  error
+	}{
+		{name: "EAN-13", code: "4006381333931", want: []string{"4006381333931"}},
+		{name: "EAN-13 with spaces and dashes", code: " 400-6381 333931 ", want: []string{"4006381333931"}},
+		{name: "EAN-13 bad check digit", code: "4006381333932", err: ErrInvalidCheckDigit},
+		{name: "UPC-A", code: "036000291452", want: []string{"0036000291452"}},
+		{name: "UPC-A bad check digit", code: "036000291453", err: ErrInvalidCheckDigit},
+		{name: "EAN-8", code: "96385074", want: []string{"0000096385074"}},
+		{name: "UPC-E ending 0 to 2", code: "04252614", want: []string{"0042100005264"}},
+		{name: "UPC-E ending 3", code: "01234531", want: []string{"0012300000451"}},
+		{name: "UPC-E ending 4", code: "01234543", want: []string{"0012340000053"}},
+		{name: "UPC-E ending 5 to 9", code: "01234565", want: []string{"0000001234565", "0012345000065"}},
+		{name: "UPC-E number system 1, also a valid EAN-8", code: "11234562", want: []string{"0000011234562", "0112345000062"}},
+		{name: "eight digits valid as neither", code: "04252615", err: ErrInvalidCheckDigit},
+		{name: "letters", code: "40063813339AB", err: ErrInvalidBarcode},
+		{name: "wrong length", code: "12345", err: ErrInvalidBarcode},
+		{name: "empty", code: "", err: ErrInvalidBarcode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBarcode(tt.code)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NormalizeBarcode(%q) error = %v, want %v", tt.code, err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeBarcode(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandUPCE(t *testing.T) {
+	tests := []struct {
+		upcE string
+		want string
+		err  error
+	}{
+		{upcE: "04252614", want: "042100005264"},
+		{upcE: "01234531", want: "012300000451"},
+		{upcE: "01234543", want: "012340000053"},
+		{upcE: "01234565", want: "012345000065"},
+		{upcE: "21234565", err: errUnsupportedBarcode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.upcE, func(t *testing.T) {
+			got, err := expandUPCE(tt.upcE)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expandUPCE(%q) error = %v, want %v", tt.upcE, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("expandUPCE(%q) = %q, want %q", tt.upcE, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package food
+
+// Which direction of a nutrient is better when comparing foods
+const (
+	BetterHigher = "higher"
+	BetterLower  = "lower"
+)
+
+// NutrientPreference says whether more or less of a nutrient is better. Nutrients
+// without an entry, such as calories, have no best food in a comparison.
+var NutrientPreference = map[string]string{
+	"protein":        BetterHigher,
+	"dietary_fiber":  BetterHigher,
+	"potassium":      BetterHigher,
+	"calcium":        BetterHigher,
+	"iron":           BetterHigher,
+	"magnesium":      BetterHigher,
+	"zinc":           BetterHigher,
+	"vitamin_a":      BetterHigher,
+	"vitamin_c":      BetterHigher,
+	"vitamin_d":      BetterHigher,
+	"vitamin_b12":    BetterHigher,
+	"folate":         BetterHigher,
+	"omega_3":        BetterHigher,
+	"total_fat":      BetterLower,
+	"saturated_fats": BetterLower,
+	"trans_fats":     BetterLower,
+	"cholesterol":    BetterLower,
+	"total_sugars":   BetterLower,
+	"added_sugars":   BetterLower,
+	"sodium":         BetterLower,
+}
+
+// ComparedFood summarises one food in a comparison
+type ComparedFood struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	FoodType  string   `json:"food_type,omitempty"`
+	Labels    []string `json:"labels"`
+	Allergens []string `json:"allergens"`
+}
+
+// NutrientComparison is one row of the aligned nutrient table. Values and
+// PercentDiff follow the order of Comparison.Foods; nil means not reported.
+type NutrientComparison struct {
+	Nutrient    string     `json:"nutrient"`
+	Name        string     `json:"name"`
+	Unit        string     `json:"unit"`
+	Values      []*float64 `json:"values"`       // Per 100g
+	PercentDiff []*float64 `json:"percent_diff"` // Relative to the first food
+	Better      string     `json:"better,omitempty"`
+	BestFoodIDs []string   `json:"best_food_ids,omitempty"` // Ties list every food
+}
+
+// SetDifference splits values into those shared by every food and those
+// specific to some foods, keyed by food ID
+type SetDifference struct {
+	Common []string            `json:"common"`
+	Only   map[string][]string `json:"only"`
+}
+
+// Comparison is a side-by-side comparison of foods
+type Comparison struct {
+	Foods     []ComparedFood       `json:"foods"`
+	Nutrients []NutrientComparison `json:"nutrients"`
+	Labels    SetDifference        `json:"labels"`
+	Allergens SetDifference        `json:"allergens"`
+}
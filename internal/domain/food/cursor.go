@@ -0,0 +1,77 @@
+package food
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Orderings a cursor can continue
+const (
+	OrderName       = "name"       // Name, then ID
+	OrderRelevance  = "relevance"  // Full-text rank, name, ID
+	OrderSimilarity = "similarity" // Trigram name similarity, name, ID
+	OrderFiltered   = "filtered"   // Nutri-Score points when sorted by them, full-text rank, name, ID
+)
+
+// ErrInvalidCursor is returned for a malformed cursor or one from another ordering
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorKeys is the number of numeric sort keys before the name in each ordering
+var cursorKeys = map[string]int{
+	OrderName:       0,
+	OrderRelevance:  1,
+	OrderSimilarity: 1,
+	OrderFiltered:   2,
+}
+
+// UngradedPoints is the Nutri-Score sort key of ungraded foods, above any
+// real score so that they sort last
+const UngradedPoints = 32767
+
+// Cursor is a keyset pagination position: the sort keys, name and ID of the
+// last food on a page. The next page starts after it, so rows added or
+// removed meanwhile neither shift nor repeat results. Keys are the numeric
+// sort keys preceding the name, all ascending, so descending ones such as
+// rank are negated.
+type Cursor struct {
+	Order string    `json:"o"`
+	Keys  []float64 `json:"k,omitempty"`
+	Name  string    `json:"n"`
+	ID    string    `json:"i"`
+}
+
+// NameCursor returns the cursor after f in name order
+func NameCursor(f *Food) *Cursor {
+	return &Cursor{Order: OrderName, Name: f.Name, ID: f.ID}
+}
+
+// Encode returns the opaque string form of the cursor
+func (c *Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Key returns the i-th sort key, or 0 when absent
+func (c *Cursor) Key(i int) float64 {
+	if i < len(c.Keys) {
+		return c.Keys[i]
+	}
+	return 0
+}
+
+// DecodeCursor parses an opaque cursor, which must belong to the given ordering
+func DecodeCursor(s, order string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Order != order || c.ID == "" || len(c.Keys) != cursorKeys[order] {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
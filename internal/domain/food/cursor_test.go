@@ -0,0 +1,68 @@
+package food
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{name: "name order", cursor: Cursor{Order: OrderName, Name: "Apple", ID: "fd_1"}},
+		{name: "relevance", cursor: Cursor{Order: OrderRelevance, Keys: []float64{-0.75}, Name: "Apple pie", ID: "fd_2"}},
+		{name: "similarity", cursor: Cursor{Order: OrderSimilarity, Keys: []float64{-0.4}, Name: "Äpfel", ID: "fd_3"}},
+		{name: "filtered", cursor: Cursor{Order: OrderFiltered, Keys: []float64{UngradedPoints, 0}, Name: "", ID: "fd_4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor.Encode(), tt.cursor.Order)
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.cursor) {
+				t.Errorf("DecodeCursor() = %+v, want %+v", *got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+		order  string
+	}{
+		{name: "not base64", cursor: "!!!", order: OrderName},
+		{name: "not JSON", cursor: encode("apple"), order: OrderName},
+		{name: "other ordering", cursor: NameCursor(&Food{ID: "fd_1", Name: "Apple"}).Encode(), order: OrderRelevance},
+		{name: "missing ID", cursor: encode(`{"o":"name","n":"Apple"}`), order: OrderName},
+		{name: "too few keys", cursor: encode(`{"o":"filtered","k":[1],"n":"Apple","i":"fd_1"}`), order: OrderFiltered},
+		{name: "too many keys", cursor: encode(`{"o":"name","k":[1],"n":"Apple","i":"fd_1"}`), order: OrderName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor, tt.order); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor() error = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestCursorKey(t *testing.T) {
+	c := Cursor{Order: OrderFiltered, Keys: []float64{3, -0.5}}
+	for i, want := range []float64{3, -0.5, 0} {
+		if got := c.Key(i); got != want {
+			t.Errorf("Key(%d) = %v, want %v", i, got, want)
+		}
+	}
+}
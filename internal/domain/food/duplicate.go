@@ -0,0 +1,144 @@
+package food
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Merge errors
+var (
+	ErrMergeIntoSelf        = errors.New("a food cannot be merged into itself")
+	ErrRevertMerge          = errors.New("merges cannot be reverted")
+	ErrInvalidMinScore      = errors.New("min_score must be between 0 and 1")
+	ErrDuplicateScopeNeeded = errors.New("food_type or food_id is required")
+)
+
+// MovedError is returned when looking up the ID of a food that was merged
+// into another food
+type MovedError struct {
+	FoodID string // ID of the food it was merged into
+}
+
+func (e *MovedError) Error() string {
+	return fmt.Sprintf("food was merged into %s", e.FoodID)
+}
+
+// DuplicateCandidate is a pair of foods that may be the same food, as found
+// by sharing a barcode or having similar names
+type DuplicateCandidate struct {
+	FoodID         string
+	OtherID        string
+	SameBarcode    bool
+	NameSimilarity float64 // Trigram similarity of the names, 0 to 1
+}
+
+// DuplicateFilter narrows the search for duplicates. Comparing every food
+// with every other is too slow for the whole catalog, so a search needs
+// FoodType or FoodID.
+type DuplicateFilter struct {
+	FoodType          string  // Only pairs of foods of this type
+	FoodID            string  // Only pairs including this food
+	MinNameSimilarity float64 // Names at least this similar, 0 to 1; shared barcodes always count
+}
+
+// DuplicateFood identifies one food of a duplicate pair
+type DuplicateFood struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	FoodType string `json:"food_type,omitempty"`
+	EAN13    string `json:"ean_13,omitempty"`
+}
+
+// DuplicatePair is a scored pair of likely duplicate foods
+type DuplicatePair struct {
+	Foods            [2]DuplicateFood `json:"foods"`
+	Score            float64          `json:"score"` // 0 to 1, higher is more likely the same food
+	SameBarcode      bool             `json:"same_barcode"`
+	NameSimilarity   float64          `json:"name_similarity"`
+	NutrientDistance *float64         `json:"nutrient_distance,omitempty"` // 0 for identical nutrients, nil with too few in common
+}
+
+// MergeResult counts what a merge moved to the surviving food
+type MergeResult struct {
+	SourceID     string    `json:"source_id"`
+	TargetID     string    `json:"target_id"`
+	Ratings      int64     `json:"ratings"`
+	SavedFoods   int64     `json:"saved_foods"`
+	IntakeLogs   int64     `json:"intake_logs"`
+	Translations int64     `json:"translations"`
+	Revision     *Revision `json:"revision,omitempty"`
+}
+
+// MergeRepository merges duplicate foods
+type MergeRepository interface {
+	// Merge moves the ratings, saved foods, intake logs and translations of
+	// source to target, deletes source, leaves a redirect from its ID and
+	// records rev, all in one transaction
+	Merge(ctx context.Context, sourceID, targetID string, mergedBy uuid.UUID, rev *Revision) (*MergeResult, error)
+}
+
+// minSharedNutrients is the number of nutrients two foods must both report
+// for their nutrient distance to count
+const minSharedNutrients = 3
+
+// NutrientDistance compares two foods' nutrients per 100g: the root mean
+// square of the relative difference of each canonical nutrient both report,
+// from 0 for identical values to 1 for wholly different ones. It is false
+// when the foods have fewer than three nutrients in common.
+func NutrientDistance(a, b *Food) (float64, bool) {
+	sum, shared := 0.0, 0
+	for _, n := range CanonicalNutrients {
+		x, okA := a.NutrientValue(n.Key)
+		y, okB := b.NutrientValue(n.Key)
+		if !okA || !okB {
+			continue
+		}
+		shared++
+		scale := math.Max(math.Abs(x), math.Abs(y))
+		if scale == 0 {
+			continue
+		}
+		d := math.Abs(x-y) / scale
+		sum += d * d
+	}
+	if shared < minSharedNutrients {
+		return 0, false
+	}
+	return math.Sqrt(sum / float64(shared)), true
+}
+
+// ScoreDuplicate scores how likely a candidate pair is the same food. Name
+// similarity and nutrient similarity count equally, or the name alone when
+// the nutrients cannot be compared; a shared barcode lifts the score into
+// the upper half, since distinct products rarely share one.
+func ScoreDuplicate(a, b *Food, c DuplicateCandidate) DuplicatePair {
+	pair := DuplicatePair{
+		Foods:          [2]DuplicateFood{duplicateFood(a), duplicateFood(b)},
+		SameBarcode:    c.SameBarcode,
+		NameSimilarity: round3(c.NameSimilarity),
+	}
+
+	score := c.NameSimilarity
+	if distance, ok := NutrientDistance(a, b); ok {
+		distance = round3(distance)
+		pair.NutrientDistance = &distance
+		score = (c.NameSimilarity + 1 - distance) / 2
+	}
+	if c.SameBarcode {
+		score = 0.5 + score/2
+	}
+	pair.Score = round3(score)
+	return pair
+}
+
+func duplicateFood(f *Food) DuplicateFood {
+	return DuplicateFood{ID: f.ID, Name: f.Name, FoodType: f.FoodType, EAN13: f.EAN13}
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,159 @@
+package food
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Catalog export formats
+const (
+	ExportNDJSON = "ndjson" // One JSON food per line
+	ExportCSV    = "csv"    // One flattened row per food with a column per nutrient
+)
+
+// ErrUnknownExportFormat is returned for an export format other than ndjson or csv
+var ErrUnknownExportFormat = errors.New("export format must be ndjson or csv")
+
+// ExportFilter selects the foods in a catalog export. Zero values match all
+// foods still in the catalog.
+type ExportFilter struct {
+	FoodType       string
+	UpdatedSince   *time.Time // Only foods updated at or after this time
+	IncludeRetired bool       // Also export retired foods, which carry retired_at
+}
+
+// ExportRepository streams the food catalog
+type ExportRepository interface {
+	// Export calls fn with each food matching filter, in ID order, reading
+	// them from a database cursor so that the catalog is never held in memory
+	Export(ctx context.Context, filter ExportFilter, fn func(f *Food) error) error
+}
+
+// ExportWriter writes foods in an export format
+type ExportWriter interface {
+	Write(f *Food) error
+	// Flush writes any buffered data and reports earlier write errors
+	Flush() error
+}
+
+// ContentType returns the MIME type of an export format
+func ContentType(format string) string {
+	if format == ExportCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// NewExportWriter returns a writer for an export format
+func NewExportWriter(w io.Writer, format string) (ExportWriter, error) {
+	switch format {
+	case ExportNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w)}, nil
+	case ExportCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	}
+	return nil, ErrUnknownExportFormat
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(f *Food) error {
+	return n.enc.Encode(f)
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return nil
+}
+
+// csvColumns are the food columns of a CSV export, before the nutrient columns
+var csvColumns = []string{
+	"id", "name", "food_type", "ean_13", "description", "alternate_names",
+	"labels", "ingredients", "serving_grams", "nutri_score_grade", "nova_group",
+	"created_at", "updated_at", "retired_at",
+}
+
+// csvListSeparator joins list values, such as labels, within a CSV cell
+const csvListSeparator = "|"
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+// Write writes a food as a CSV row, preceded by the header on the first call.
+// Nutrients get a column each, named after the key and unit such as
+// protein_g, so the columns are the same for every food.
+func (c *csvWriter) Write(f *Food) error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvHeader()); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+
+	row := []string{
+		f.ID,
+		f.Name,
+		f.FoodType,
+		f.EAN13,
+		f.Description,
+		strings.Join(f.AlternateNames, csvListSeparator),
+		strings.Join(f.Labels, csvListSeparator),
+		f.Ingredients,
+		strconv.FormatFloat(f.ServingGrams(), 'f', -1, 64),
+		f.NutriScoreGrade,
+		"",
+		formatExportTime(f.CreatedAt),
+		formatExportTime(f.UpdatedAt),
+		"",
+	}
+	if f.NovaGroup != 0 {
+		row[10] = strconv.Itoa(f.NovaGroup)
+	}
+	if f.RetiredAt != nil {
+		row[13] = formatExportTime(*f.RetiredAt)
+	}
+	for _, n := range CanonicalNutrients {
+		value := ""
+		if v, ok := f.NutrientValue(n.Key); ok {
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		row = append(row, value)
+	}
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Flush() error {
+	if !c.wroteHeader {
+		// An empty export still gets a header
+		if err := c.w.Write(csvHeader()); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func csvHeader() []string {
+	header := append([]string{}, csvColumns...)
+	for _, n := range CanonicalNutrients {
+		header = append(header, n.Key+"_"+n.Unit)
+	}
+	return header
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -2,6 +2,7 @@ package food
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,21 +10,59 @@ import (
 
 // Food represents a food item in the system
 type Food struct {
-	ID                 string                 `json:"id"`
-	Name               string                 `json:"name"`
-	AlternateNames     []string               `json:"alternate_names,omitempty"`
-	Description        string                 `json:"description,omitempty"`
-	FoodType           string                 `json:"food_type,omitempty"`
-	Source             []map[string]string    `json:"source,omitempty"`
-	Serving            map[string]interface{} `json:"serving,omitempty"`
-	Nutrition100g      map[string]interface{} `json:"nutrition_100g,omitempty"`
-	EAN13              string                 `json:"ean_13,omitempty"`
-	Labels             []string               `json:"labels,omitempty"`
-	PackageSize        map[string]interface{} `json:"package_size,omitempty"`
-	Ingredients        string                 `json:"ingredients,omitempty"`
-	IngredientAnalysis map[string]interface{} `json:"ingredient_analysis,omitempty"`
-	CreatedAt          time.Time              `json:"created_at"`
-	UpdatedAt          time.Time              `json:"updated_at"`
+	ID                 string              `json:"id"`
+	Name               string              `json:"name"`
+	AlternateNames     []string            `json:"alternate_names,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	Locale             string              `json:"locale,omitempty"` // Locale of the name and description when translated
+	FoodType           string              `json:"food_type,omitempty"`
+	Source             []map[string]string `json:"source,omitempty"`
+	Serving            Serving             `json:"serving,omitzero"`
+	Nutrition100g      Nutrients           `json:"nutrition_100g,omitzero"`
+	EAN13              string              `json:"ean_13,omitempty"`          // Normalized GTIN-13
+	InvalidBarcode     string              `json:"invalid_barcode,omitempty"` // Source barcode that failed validation
+	Labels             []string            `json:"labels,omitempty"`
+	PackageSize        Serving             `json:"package_size,omitzero"` // Amount and weight of the package contents
+	Ingredients        string              `json:"ingredients,omitempty"`
+	IngredientAnalysis IngredientAnalysis  `json:"ingredient_analysis,omitzero"`
+	IngredientTree     *IngredientList     `json:"ingredient_tree,omitempty"`   // Ingredients parsed into a tree
+	NutriScoreGrade    string              `json:"nutri_score_grade,omitempty"` // A (best) to E, empty when ungraded
+	NutriScore         *NutriScore         `json:"nutri_score,omitempty"`       // Points breakdown behind the grade
+	NovaGroup          int                 `json:"nova_group,omitempty"`        // 1 (unprocessed) to 4 (ultra-processed), 0 when unclassified
+	NovaMarkers        []NovaMarker        `json:"nova_markers,omitempty"`      // Ingredients and additives behind the group
+	RetiredAt          *time.Time          `json:"retired_at,omitempty"`        // Set when a newer dataset release dropped the food or an admin deleted it
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+}
+
+// Classify sets the fields derived from a food's nutrition and ingredients:
+// its ingredient tree, Nutri-Score and NOVA group
+func (f *Food) Classify() {
+	f.IngredientTree = ParseIngredients(f.Ingredients)
+
+	f.NutriScore = ComputeNutriScore(f)
+	f.NutriScoreGrade = ""
+	if f.NutriScore != nil {
+		f.NutriScoreGrade = f.NutriScore.Grade
+	}
+
+	f.NovaGroup, f.NovaMarkers = 0, nil
+	if nova := ClassifyNOVA(f); nova != nil {
+		f.NovaGroup, f.NovaMarkers = nova.Group, nova.Markers
+	}
+}
+
+// MentionsIngredient returns the ingredient or allergen declaration mentioning
+// term, searching the parsed tree when there is one and the raw ingredient
+// text otherwise. "May contain" declarations count when traces is set.
+func (f *Food) MentionsIngredient(term string, traces bool) (string, bool) {
+	if f.IngredientTree != nil {
+		return f.IngredientTree.Find(term, traces)
+	}
+	if term != "" && strings.Contains(strings.ToLower(f.Ingredients), strings.ToLower(term)) {
+		return term, true
+	}
+	return "", false
 }
 
 // FoodRating represents a user's rating of a food item
@@ -46,17 +85,142 @@ type SavedFood struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Name holds the names a food can be found by, used to build the autocomplete index
+type Name struct {
+	FoodID         string
+	Name           string
+	AlternateNames []string
+}
+
+// Suggestion represents an autocomplete match on a food name or alternate name
+type Suggestion struct {
+	FoodID      string `json:"food_id"`
+	Text        string `json:"text"`
+	Highlighted string `json:"highlighted"` // Text with the matched prefix wrapped in <em> tags
+	Alternate   bool   `json:"alternate"`   // Matched an alternate name rather than the primary name
+}
+
+// SearchFilter holds the structured filters for browsing foods
+type SearchFilter struct {
+	Query            string          `json:"query,omitempty"`
+	FoodType         string          `json:"food_type,omitempty"`
+	Labels           []string        `json:"labels,omitempty"`
+	MatchAllLabels   bool            `json:"match_all_labels,omitempty"` // Require every label instead of any
+	NutrientRanges   []NutrientRange `json:"nutrient_ranges,omitempty"`
+	HasBarcode       *bool           `json:"has_barcode,omitempty"`
+	NutriScoreGrades []string        `json:"nutri_score_grades,omitempty"`
+	Sort             string          `json:"sort,omitempty"` // SortRelevance or SortNutriScore
+	Locale           string          `json:"-"`              // Also match the query against translations in this locale
+	Facets           bool            `json:"-"`              // Also count the matching foods' facets
+}
+
+// Search result orderings. Relevance falls back to name without a query.
+const (
+	SortRelevance  = ""
+	SortNutriScore = "nutri_score" // Best grade first, ungraded foods last
+)
+
+// HasStructuredFilters reports whether any filter or ordering beyond the text query is set
+func (f SearchFilter) HasStructuredFilters() bool {
+	return f.FoodType != "" || len(f.Labels) > 0 || len(f.NutrientRanges) > 0 || f.HasBarcode != nil ||
+		len(f.NutriScoreGrades) > 0 || f.Sort != SortRelevance
+}
+
+// NutrientRange bounds a nutrient's amount per 100g; either end may be open
+type NutrientRange struct {
+	Nutrient string   `json:"nutrient"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+}
+
+// FacetCount is the number of matching foods with a given value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// HistogramBucket counts matching foods whose nutrient amount falls in
+// [Min, Max). The first bucket has no Min and the last has no Max.
+type HistogramBucket struct {
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int64    `json:"count"`
+}
+
+// NutrientHistogram is the distribution of a nutrient across matching foods
+type NutrientHistogram struct {
+	Nutrient string            `json:"nutrient"`
+	Unit     string            `json:"unit"`
+	Buckets  []HistogramBucket `json:"buckets"`
+}
+
+// Facets summarises the foods matching a search for filter sidebars
+type Facets struct {
+	FoodTypes []FacetCount        `json:"food_types"`
+	Labels    []FacetCount        `json:"labels"`
+	Nutrients []NutrientHistogram `json:"nutrients"`
+}
+
+// HistogramBounds are the bucket boundaries, per 100g, used for nutrient facets
+var HistogramBounds = map[string][]float64{
+	"calories":      {50, 100, 200, 300, 400, 500},
+	"protein":       {5, 10, 20, 30},
+	"carbohydrates": {10, 25, 50, 75},
+	"total_fat":     {3, 10, 20, 30},
+	"total_sugars":  {5, 12.5, 22.5},
+	"dietary_fiber": {3, 6},
+	"sodium":        {120, 300, 600},
+}
+
+// SearchResult is a page of foods matching a search
+type SearchResult struct {
+	Foods      []Food  `json:"foods"`
+	Total      int     `json:"total"`
+	Fuzzy      bool    `json:"fuzzy"`                 // Results came from the typo-tolerant fallback
+	Facets     *Facets `json:"facets,omitempty"`      // Omitted for fuzzy results
+	NextCursor string  `json:"next_cursor,omitempty"` // Set when the page is full and more results may follow
+}
+
 // Repository defines the interface for food data access
 type Repository interface {
 	Create(food *Food) error
 	GetByID(id string) (*Food, error)
 	GetByEAN13(ean13 string) (*Food, error)
-	List(limit, offset int) ([]Food, error)
+	List(after *Cursor, limit, offset int) ([]Food, error)
 	ListByType(foodType string, limit, offset int) ([]Food, error)
-	Search(query string, limit, offset int) ([]Food, error)
+	Search(query, locale string, after *Cursor, limit, offset int) ([]Food, error)
+	ListTopByNutrient(nutrient string, limit int) ([]Food, error)
 	Count() (int64, error)
+	CountSearch(query, locale string) (int64, error)
+	FuzzySearch(query, locale string, after *Cursor, limit, offset int) ([]Food, error)
+	CountFuzzySearch(query, locale string) (int64, error)
+	ListNames() ([]Name, error)
+	Filter(filter SearchFilter, after *Cursor, limit, offset int) ([]Food, error)
+	CountFiltered(filter SearchFilter) (int64, error)
+	Facets(filter SearchFilter, labelLimit int) (*Facets, error)
+	CountByType() ([]FacetCount, error)
+	ListByTypes(foodTypes []string, after *Cursor, limit, offset int) ([]Food, error)
+	SearchRank(query, locale, id string) (float64, error)
+	NameSimilarity(query, locale, id string) (float64, error)
+	CountByTypes(foodTypes []string) (int64, error)
+	Update(food *Food) error
 	Delete(id string) error
 
+	// Revision methods
+	GetRevision(id uuid.UUID) (*Revision, error)
+	ListRevisions(foodID string, limit, offset int) ([]Revision, error)
+	CountRevisions(foodID string) (int64, error)
+
+	// Duplicate methods
+	ListDuplicateCandidates(filter DuplicateFilter, limit int) ([]DuplicateCandidate, error)
+	GetRedirect(oldID string) (string, error)
+
+	// Translation methods
+	ListTranslations(foodIDs []string, locale string) (map[string]*Translation, error)
+	ListTranslationsForFood(foodID string) ([]Translation, error)
+	UpsertTranslation(t *Translation) error
+	DeleteTranslation(foodID, locale string) error
+
 	// Rating methods
 	CreateRating(rating *FoodRating) error
 	UpdateRating(rating *FoodRating) error
@@ -0,0 +1,303 @@
+package food
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Ingredient is one entry of an ingredient list. Compound ingredients such as
+// "chocolate (sugar, cocoa butter)" hold their components in Children.
+type Ingredient struct {
+	Name     string       `json:"name"`
+	Percent  *float64     `json:"percent,omitempty"`
+	ENumber  string       `json:"e_number,omitempty"` // Normalized additive code, e.g. "E150d"
+	Children []Ingredient `json:"children,omitempty"`
+}
+
+// IngredientList is a parsed ingredient statement
+type IngredientList struct {
+	Ingredients []Ingredient `json:"ingredients"`
+	Contains    []string     `json:"contains,omitempty"`    // Declared in a "contains" clause
+	MayContain  []string     `json:"may_contain,omitempty"` // Declared in a "may contain" clause
+}
+
+var (
+	percentPattern    = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*%`)
+	eNumberOnly       = regexp.MustCompile(`(?i)^E[ -]?(\d{3,4})([a-z]?)$`)
+	ingredientsPrefix = regexp.MustCompile(`(?i)^\s*ingredients?\s*:\s*`)
+	mayContainPrefix  = regexp.MustCompile(`(?i)^(?:may contain|may also contain|made in a factory that (?:also )?handles)\s*(?:traces of\s*)?:?\s*`)
+	containsPrefix    = regexp.MustCompile(`(?i)^(?:contains|allergens?)\s*:?\s*`)
+	andSeparator      = regexp.MustCompile(`(?i)\s+(?:and|&)\s+`)
+)
+
+// ParseIngredients parses an ingredient statement such as "Wheat flour (62%),
+// sugar, emulsifier: lecithins (soya). May contain nuts." into a tree. It
+// handles nested parentheses and brackets, percentages, "contains" and "may
+// contain" clauses and E-numbers, and returns nil for an empty statement.
+func ParseIngredients(text string) *IngredientList {
+	text = strings.TrimSpace(ingredientsPrefix.ReplaceAllString(text, ""))
+	if text == "" {
+		return nil
+	}
+
+	list := &IngredientList{Ingredients: []Ingredient{}}
+	for _, sentence := range splitTopLevel(text, isSentenceEnd) {
+		sentence = strings.TrimSpace(sentence)
+		switch {
+		case sentence == "":
+		case mayContainPrefix.MatchString(sentence):
+			list.MayContain = append(list.MayContain, splitDeclaration(mayContainPrefix.ReplaceAllString(sentence, ""))...)
+		case containsPrefix.MatchString(sentence) && len(list.Ingredients) > 0:
+			list.Contains = append(list.Contains, splitDeclaration(containsPrefix.ReplaceAllString(sentence, ""))...)
+		default:
+			list.Ingredients = append(list.Ingredients, parseIngredientItems(sentence)...)
+		}
+	}
+	return list
+}
+
+// Names returns every ingredient name in the tree, parents before their
+// children, excluding "contains" and "may contain" declarations
+func (l *IngredientList) Names() []string {
+	var names []string
+	var walk func([]Ingredient)
+	walk = func(items []Ingredient) {
+		for _, item := range items {
+			if item.Name != "" {
+				names = append(names, item.Name)
+			}
+			walk(item.Children)
+		}
+	}
+	walk(l.Ingredients)
+	return names
+}
+
+// ENumbers returns the additive codes found anywhere in the tree
+func (l *IngredientList) ENumbers() []string {
+	var codes []string
+	var walk func([]Ingredient)
+	walk = func(items []Ingredient) {
+		for _, item := range items {
+			if item.ENumber != "" {
+				codes = append(codes, item.ENumber)
+			}
+			walk(item.Children)
+		}
+	}
+	walk(l.Ingredients)
+	return codes
+}
+
+// Find returns the first ingredient or declaration mentioning term, case
+// insensitively. Underscores in term match spaces, so "tree_nuts" finds
+// "tree nuts". "May contain" declarations are only searched when traces is set.
+func (l *IngredientList) Find(term string, traces bool) (string, bool) {
+	term = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(term), "_", " "))
+	if term == "" {
+		return "", false
+	}
+	mentions := func(s string) bool { return strings.Contains(strings.ToLower(s), term) }
+
+	for _, name := range l.Names() {
+		if mentions(name) {
+			return name, true
+		}
+	}
+	for _, declared := range l.Contains {
+		if mentions(declared) {
+			return declared, true
+		}
+	}
+	if traces {
+		for _, declared := range l.MayContain {
+			if mentions(declared) {
+				return "may contain " + declared, true
+			}
+		}
+	}
+	return "", false
+}
+
+// TopLevelCount returns the number of top-level ingredients
+func (l *IngredientList) TopLevelCount() int {
+	return len(l.Ingredients)
+}
+
+// parseIngredientItems parses a comma or semicolon separated ingredient list
+func parseIngredientItems(text string) []Ingredient {
+	var items []Ingredient
+	for _, part := range splitTopLevel(text, isListSeparator) {
+		if item, ok := parseIngredient(part); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseIngredient parses one entry: a name with an optional percentage, an
+// optional bracketed sub-list, percentage or E-number, and an optional
+// "class: members" form such as "emulsifier: E471"
+func parseIngredient(text string) (Ingredient, bool) {
+	text = strings.Trim(strings.TrimSpace(text), ".*")
+	if text == "" {
+		return Ingredient{}, false
+	}
+
+	// "emulsifier: lecithins (soya)" names a class and its members
+	if i := topLevelIndex(text, ':'); i > 0 {
+		item := Ingredient{Name: strings.TrimSpace(text[:i])}
+		item.Children = parseIngredientItems(text[i+1:])
+		return item, item.Name != "" || len(item.Children) > 0
+	}
+
+	var item Ingredient
+	name := text
+	if open := strings.IndexAny(text, "(["); open >= 0 {
+		end := matchingBracket(text, open)
+		inner := text[open+1 : end]
+		rest := ""
+		if end < len(text) {
+			rest = text[end+1:]
+		}
+		name = text[:open] + " " + rest
+
+		switch innerTrim := strings.TrimSpace(inner); {
+		case percentPattern.MatchString(innerTrim) && strings.TrimSpace(percentPattern.ReplaceAllString(innerTrim, "")) == "":
+			item.Percent = parsePercent(innerTrim)
+		case eNumberOnly.MatchString(innerTrim):
+			item.ENumber = normalizeENumber(innerTrim)
+		default:
+			item.Children = parseIngredientItems(inner)
+		}
+	}
+
+	if m := percentPattern.FindStringSubmatch(name); m != nil {
+		if item.Percent == nil {
+			item.Percent = parsePercent(m[0])
+		}
+		name = percentPattern.ReplaceAllString(name, "")
+	}
+	item.Name = strings.Join(strings.Fields(name), " ")
+	if eNumberOnly.MatchString(item.Name) {
+		item.ENumber = normalizeENumber(item.Name)
+	}
+	return item, item.Name != "" || len(item.Children) > 0 || item.ENumber != ""
+}
+
+// splitDeclaration splits a "contains" or "may contain" clause into allergens
+func splitDeclaration(text string) []string {
+	var result []string
+	for _, part := range splitTopLevel(andSeparator.ReplaceAllString(text, ","), isListSeparator) {
+		if part = strings.Trim(strings.TrimSpace(part), ".*"); part != "" {
+			result = append(result, strings.ToLower(part))
+		}
+	}
+	return result
+}
+
+// splitTopLevel splits text wherever isSep is true outside brackets
+func splitTopLevel(text string, isSep func(s string, i int) bool) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		default:
+			if depth == 0 && isSep(text, i) {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// isListSeparator reports whether text[i] separates list entries, treating a
+// comma between digits as a decimal comma as in "12,5%"
+func isListSeparator(text string, i int) bool {
+	switch text[i] {
+	case ';':
+		return true
+	case ',':
+		return i == 0 || i+1 >= len(text) || !isDigit(text[i-1]) || !isDigit(text[i+1])
+	}
+	return false
+}
+
+// isSentenceEnd reports whether text[i] is a full stop ending a clause,
+// rather than a decimal point as in "2.5%"
+func isSentenceEnd(text string, i int) bool {
+	if text[i] != '.' {
+		return false
+	}
+	return i+1 >= len(text) || !isDigit(text[i+1]) || i == 0 || !isDigit(text[i-1])
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// topLevelIndex returns the index of the first c outside brackets, or -1
+func topLevelIndex(text string, c byte) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		case c:
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// matchingBracket returns the index of the bracket closing the one at open,
+// or len(text) when it is never closed
+func matchingBracket(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(text)
+}
+
+func parsePercent(text string) *float64 {
+	m := percentPattern.FindStringSubmatch(text)
+	if m == nil {
+		return nil
+	}
+	v, err := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func normalizeENumber(code string) string {
+	m := eNumberOnly.FindStringSubmatch(strings.TrimSpace(code))
+	if m == nil {
+		return ""
+	}
+	return "E" + m[1] + strings.ToLower(m[2])
+}
@@ -0,0 +1,98 @@
+package food
+
+import (
+	"reflect"
+	"testing"
+)
+
+func percent(v float64) *float64 {
+	return &v
+}
+
+func TestParseIngredients(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *IngredientList
+	}{
+		{name: "empty", text: "  ", want: nil},
+		{name: "prefix only", text: "Ingredients: ", want: nil},
+		{
+			name: "percentages, classes and may contain",
+			text: "Ingredients: Wheat flour (62%), sugar, emulsifier: lecithins (soya). May contain nuts and milk.",
+			want: &IngredientList{
+				Ingredients: []Ingredient{
+					{Name: "Wheat flour", Percent: percent(62)},
+					{Name: "sugar"},
+					{Name: "emulsifier", Children: []Ingredient{
+						{Name: "lecithins", Children: []Ingredient{{Name: "soya"}}},
+					}},
+				},
+				MayContain: []string{"nuts", "milk"},
+			},
+		},
+		{
+			name: "nested brackets, decimal comma, E-number and contains",
+			text: "Chocolate 12,5% (sugar, cocoa butter [cocoa]), colour (E 150d). Contains: milk; soy.",
+			want: &IngredientList{
+				Ingredients: []Ingredient{
+					{Name: "Chocolate", Percent: percent(12.5), Children: []Ingredient{
+						{Name: "sugar"},
+						{Name: "cocoa butter", Children: []Ingredient{{Name: "cocoa"}}},
+					}},
+					{Name: "colour", ENumber: "E150d"},
+				},
+				Contains: []string{"milk", "soy"},
+			},
+		},
+		{
+			name: "decimal point is not a sentence end",
+			text: "Water, salt (2.5%)",
+			want: &IngredientList{
+				Ingredients: []Ingredient{{Name: "Water"}, {Name: "salt", Percent: percent(2.5)}},
+			},
+		},
+		{
+			name: "bare E-number",
+			text: "Apples, E330",
+			want: &IngredientList{
+				Ingredients: []Ingredient{{Name: "Apples"}, {Name: "E330", ENumber: "E330"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseIngredients(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIngredients(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIngredientListFind(t *testing.T) {
+	list := ParseIngredients("Wheat flour, tree nuts (almonds). Contains: soy. May contain milk.")
+
+	tests := []struct {
+		term    string
+		traces  bool
+		want    string
+		wantHit bool
+	}{
+		{term: "wheat", want: "Wheat flour", wantHit: true},
+		{term: "tree_nuts", want: "tree nuts", wantHit: true},
+		{term: "almond", want: "almonds", wantHit: true},
+		{term: "soy", want: "soy", wantHit: true},
+		{term: "milk", wantHit: false},
+		{term: "milk", traces: true, want: "may contain milk", wantHit: true},
+		{term: " ", wantHit: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := list.Find(tt.term, tt.traces)
+		if got != tt.want || ok != tt.wantHit {
+			t.Errorf("Find(%q, %v) = %q, %v; want %q, %v", tt.term, tt.traces, got, ok, tt.want, tt.wantHit)
+		}
+	}
+}
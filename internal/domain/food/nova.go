@@ -0,0 +1,230 @@
+package food
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NOVA processing groups
+const (
+	NovaUnprocessed        = 1 // Unprocessed or minimally processed foods
+	NovaCulinaryIngredient = 2 // Processed culinary ingredients
+	NovaProcessed          = 3 // Processed foods
+	NovaUltraProcessed     = 4 // Ultra-processed foods
+)
+
+// NovaMarker is an ingredient or additive that placed a food in a NOVA group
+type NovaMarker struct {
+	Marker string `json:"marker"` // E-number such as "E471" or an ingredient such as "maltodextrin"
+	Group  int    `json:"group"`
+}
+
+// NovaClassification is a food's NOVA group and the markers behind it
+type NovaClassification struct {
+	Group   int          `json:"group"`
+	Markers []NovaMarker `json:"markers"`
+}
+
+// novaUltraProcessedIngredients are industrial ingredients rarely used in home
+// cooking; any of them makes a food ultra-processed
+var novaUltraProcessedIngredients = []string{
+	"maltodextrin", "dextrose", "glucose syrup", "glucose-fructose syrup", "fructose syrup",
+	"high fructose corn syrup", "corn syrup", "invert sugar", "hydrolysed protein",
+	"hydrolyzed protein", "hydrolysed vegetable protein", "hydrolyzed vegetable protein",
+	"protein isolate", "soy protein isolate", "whey protein isolate", "textured vegetable protein",
+	"mechanically separated", "modified starch", "modified corn starch", "modified food starch",
+	"hydrogenated", "interesterified", "flavouring", "flavoring", "artificial flavor",
+	"artificial flavour", "natural flavor", "natural flavour", "flavour enhancer", "flavor enhancer",
+	"colouring", "coloring", "artificial colour", "artificial color", "emulsifier", "sweetener",
+	"aspartame", "sucralose", "acesulfame", "saccharin", "thickener", "humectant", "glazing agent",
+	"anti-caking agent", "anticaking agent", "bulking agent", "carbonating agent", "firming agent",
+	"gelling agent", "sequestrant", "casein", "lactose", "whey powder", "gluten",
+}
+
+// novaProcessingIngredients are culinary ingredients that make a food processed
+// when added to it, or a processed culinary ingredient on their own
+var novaProcessingIngredients = []string{
+	"sugar", "salt", "oil", "butter", "vinegar", "honey", "syrup", "lard", "starch", "cream",
+}
+
+// eNumberPattern matches additive codes such as E330, E 471 or E-150d
+var eNumberPattern = regexp.MustCompile(`(?i)\bE[ -]?(\d{3,4})[a-z]?\b`)
+
+// ClassifyNOVA assigns a NOVA group from a food's parsed ingredient tree, or
+// its raw ingredient list when unparsed, and the additives and ingredients in
+// its ingredient analysis. Ultra-processing additives (colours, emulsifiers and
+// thickeners, flavour enhancers, glazing agents, sweeteners, modified
+// starches) or industrial ingredients give group 4, other additives or added
+// sugar, salt and fats give group 3, a lone culinary ingredient gives group 2
+// and anything else group 1. It returns nil when the food has no ingredient data.
+func ClassifyNOVA(f *Food) *NovaClassification {
+	// The parsed tree leaves out "may contain" declarations, which say nothing
+	// about how the food itself was made
+	names := analysisIngredients(f.IngredientAnalysis)
+	tree := f.IngredientTree
+	if tree == nil {
+		tree = ParseIngredients(f.Ingredients)
+	}
+	topLevel := 0
+	if tree != nil {
+		names = append(names, tree.Names()...)
+		names = append(names, tree.ENumbers()...)
+		topLevel = tree.TopLevelCount()
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	markers := map[string]int{}
+	for _, name := range names {
+		name = strings.ToLower(name)
+		for _, m := range eNumberPattern.FindAllStringSubmatch(name, -1) {
+			code, _ := strconv.Atoi(m[1])
+			markers["E"+m[1]] = additiveNovaGroup(code)
+		}
+		for _, ingredient := range novaUltraProcessedIngredients {
+			if containsWord(name, ingredient) {
+				markers[ingredient] = NovaUltraProcessed
+			}
+		}
+		for _, ingredient := range novaProcessingIngredients {
+			if isIngredient(name, ingredient) {
+				markers[ingredient] = NovaProcessed
+			}
+		}
+	}
+
+	result := &NovaClassification{Group: NovaUnprocessed, Markers: []NovaMarker{}}
+	for marker, group := range markers {
+		result.Markers = append(result.Markers, NovaMarker{Marker: marker, Group: group})
+		if group > result.Group {
+			result.Group = group
+		}
+	}
+	sort.Slice(result.Markers, func(i, j int) bool {
+		if result.Markers[i].Group != result.Markers[j].Group {
+			return result.Markers[i].Group > result.Markers[j].Group
+		}
+		return result.Markers[i].Marker < result.Markers[j].Marker
+	})
+
+	// A culinary ingredient on its own, such as olive oil or table salt, is
+	// group 2 rather than a processed food
+	if result.Group == NovaProcessed && len(result.Markers) == 1 && topLevel <= 1 && !eNumberOnly.MatchString(result.Markers[0].Marker) {
+		result.Group = NovaCulinaryIngredient
+		result.Markers[0].Group = NovaCulinaryIngredient
+	}
+
+	return result
+}
+
+// IsUltraProcessed reports whether a food is classified as NOVA group 4
+func (f *Food) IsUltraProcessed() bool {
+	return f.NovaGroup == NovaUltraProcessed
+}
+
+// additiveNovaGroup places an E-number additive in group 4 when it belongs to
+// a class used for cosmetic or textural ultra-processing, otherwise group 3
+func additiveNovaGroup(code int) int {
+	switch {
+	case code >= 100 && code < 200: // Colours
+		return NovaUltraProcessed
+	case code >= 400 && code < 500: // Emulsifiers, stabilisers and thickeners
+		return NovaUltraProcessed
+	case code >= 620 && code < 650: // Flavour enhancers
+		return NovaUltraProcessed
+	case code >= 900 && code < 910: // Glazing agents
+		return NovaUltraProcessed
+	case code >= 950 && code < 970: // Sweeteners
+		return NovaUltraProcessed
+	case code >= 1400 && code < 1500: // Modified starches
+		return NovaUltraProcessed
+	default:
+		return NovaProcessed
+	}
+}
+
+// containsWord reports whether phrase, or its plural with a trailing s,
+// appears in text on word boundaries and is not negated, as in "gluten-free"
+func containsWord(text, phrase string) bool {
+	for start := 0; ; {
+		i := strings.Index(text[start:], phrase)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(phrase)
+		if end < len(text) && text[end] == 's' {
+			end++
+		}
+		if (i == 0 || !isLetter(text[i-1])) && (end == len(text) || !isLetter(text[end])) && !isNegated(text, i, end) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+// isIngredient reports whether name, or one of the parts of a name such as
+// "salt and pepper", is the culinary ingredient: the ingredient itself or a
+// kind of it, such as "cane sugar" or "olive oil". Longer food names that
+// merely start with it, such as "sugar snap peas", are not.
+func isIngredient(name, ingredient string) bool {
+	for _, part := range andSeparator.Split(name, -1) {
+		part = strings.TrimSpace(part)
+		end := len(part)
+		if strings.HasSuffix(part, "s") && !strings.HasSuffix(ingredient, "s") {
+			if strings.HasSuffix(part[:end-1], ingredient) {
+				end--
+			}
+		}
+		i := end - len(ingredient)
+		if i < 0 || part[i:end] != ingredient {
+			continue
+		}
+		if (i == 0 || !isLetter(part[i-1])) && !isNegated(part, i, len(part)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNegated reports whether the word at text[i:end] is negated, as in "sugar
+// free", "non-gmo" or "no added salt"
+func isNegated(text string, i, end int) bool {
+	after, before := text[end:], text[:i]
+	if strings.HasPrefix(after, "-free") || strings.HasPrefix(after, " free") {
+		return true
+	}
+	for _, prefix := range []string{"non-", "non ", "no ", "no added ", "without "} {
+		if strings.HasSuffix(before, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z'
+}
+
+// analysisIngredients returns the additives and ingredients listed in an
+// ingredient analysis, without language prefixes such as "en:" and leaving
+// out negated entries such as "palm-oil-free" or "non-vegan". The analysis
+// tags describe the food rather than what went into it.
+func analysisIngredients(analysis IngredientAnalysis) []string {
+	var names []string
+	for _, name := range append(append([]string{}, analysis.Additives...), analysis.Ingredients...) {
+		if i := strings.Index(name, ":"); i >= 0 && i <= 3 {
+			name = name[i+1:]
+		}
+		name = strings.TrimSpace(name)
+		lower := strings.ToLower(name)
+		if name == "" || strings.HasSuffix(lower, "-free") || strings.HasPrefix(lower, "non-") {
+			continue
+		}
+		names = append(names, strings.ReplaceAll(name, "-", " "))
+	}
+	return names
+}
@@ -0,0 +1,140 @@
+package food
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyNOVA(t *testing.T) {
+	tests := []struct {
+		name    string
+		food    Food
+		wantNil bool
+		group   int
+		markers []NovaMarker
+	}{
+		{
+			name:    "no ingredient data",
+			food:    Food{},
+			wantNil: true,
+		},
+		{
+			name:    "single whole food",
+			food:    Food{Ingredients: "Apples"},
+			group:   NovaUnprocessed,
+			markers: []NovaMarker{},
+		},
+		{
+			name: "negated analysis ingredient",
+			food: Food{
+				Ingredients:        "Apples",
+				IngredientAnalysis: IngredientAnalysis{Ingredients: []string{"en:palm-oil-free"}},
+			},
+			group:   NovaUnprocessed,
+			markers: []NovaMarker{},
+		},
+		{
+			name: "analysis tags describe the food, not its ingredients",
+			food: Food{
+				Ingredients:        "Apples",
+				IngredientAnalysis: IngredientAnalysis{Tags: []string{"en:palm-oil-free", "en:vegan"}},
+			},
+			group:   NovaUnprocessed,
+			markers: []NovaMarker{},
+		},
+		{
+			name:    "culinary ingredient at the start of a longer name",
+			food:    Food{Ingredients: "Sugar snap peas"},
+			group:   NovaUnprocessed,
+			markers: []NovaMarker{},
+		},
+		{
+			name:    "negated ingredient",
+			food:    Food{Ingredients: "Apple juice (no added sugar)"},
+			group:   NovaUnprocessed,
+			markers: []NovaMarker{},
+		},
+		{
+			name:    "negated ultra-processed ingredient",
+			food:    Food{Ingredients: "Gluten-free oats"},
+			group:   NovaUnprocessed,
+			markers: []NovaMarker{},
+		},
+		{
+			name:    "culinary ingredient on its own",
+			food:    Food{Ingredients: "Extra virgin olive oil"},
+			group:   NovaCulinaryIngredient,
+			markers: []NovaMarker{{Marker: "oil", Group: NovaCulinaryIngredient}},
+		},
+		{
+			name:    "culinary ingredients joined by and",
+			food:    Food{Ingredients: "Salt and pepper"},
+			group:   NovaCulinaryIngredient,
+			markers: []NovaMarker{{Marker: "salt", Group: NovaCulinaryIngredient}},
+		},
+		{
+			name:    "food with added salt",
+			food:    Food{Ingredients: "Tomatoes, sea salt"},
+			group:   NovaProcessed,
+			markers: []NovaMarker{{Marker: "salt", Group: NovaProcessed}},
+		},
+		{
+			name:    "lone additive is not a culinary ingredient",
+			food:    Food{Ingredients: "Apples, E330"},
+			group:   NovaProcessed,
+			markers: []NovaMarker{{Marker: "E330", Group: NovaProcessed}},
+		},
+		{
+			name:  "ultra-processing additive and ingredient",
+			food:  Food{Ingredients: "Wheat flour, sugar, maltodextrin, emulsifier: E471"},
+			group: NovaUltraProcessed,
+			markers: []NovaMarker{
+				{Marker: "E471", Group: NovaUltraProcessed},
+				{Marker: "emulsifier", Group: NovaUltraProcessed},
+				{Marker: "maltodextrin", Group: NovaUltraProcessed},
+				{Marker: "sugar", Group: NovaProcessed},
+			},
+		},
+		{
+			name:  "additive from the analysis",
+			food:  Food{Ingredients: "Apples", IngredientAnalysis: IngredientAnalysis{Additives: []string{"en:e150d"}}},
+			group: NovaUltraProcessed,
+			markers: []NovaMarker{
+				{Marker: "E150", Group: NovaUltraProcessed},
+			},
+		},
+		{
+			name:    "may contain declarations are ignored",
+			food:    Food{Ingredients: "Oats. May contain traces of sugar."},
+			group:   NovaUnprocessed,
+			markers: []NovaMarker{},
+		},
+		{
+			name: "parsed tree preferred over the statement",
+			food: Food{
+				Ingredients:    "Apples",
+				IngredientTree: &IngredientList{Ingredients: []Ingredient{{Name: "apples"}, {Name: "dextrose"}}},
+			},
+			group:   NovaUltraProcessed,
+			markers: []NovaMarker{{Marker: "dextrose", Group: NovaUltraProcessed}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyNOVA(&tt.food)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("ClassifyNOVA() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("ClassifyNOVA() = nil")
+			}
+			if got.Group != tt.group || !reflect.DeepEqual(got.Markers, tt.markers) {
+				t.Errorf("ClassifyNOVA() = group %d, markers %+v; want %d, %+v", got.Group, got.Markers, tt.group, tt.markers)
+			}
+		})
+	}
+}
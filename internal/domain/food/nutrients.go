@@ -0,0 +1,121 @@
+package food
+
+import (
+	"sort"
+	"strings"
+)
+
+// NutrientInfo describes a canonical nutrient key used in Nutrition100g
+type NutrientInfo struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Unit string `json:"unit"`
+}
+
+// CanonicalNutrients lists the canonical nutrient keys and the unit their values are stored in
+var CanonicalNutrients = []NutrientInfo{
+	{Key: "calories", Name: "Energy", Unit: "kcal"},
+	{Key: "protein", Name: "Protein", Unit: "g"},
+	{Key: "carbohydrates", Name: "Carbohydrates", Unit: "g"},
+	{Key: "total_fat", Name: "Total fat", Unit: "g"},
+	{Key: "saturated_fats", Name: "Saturated fat", Unit: "g"},
+	{Key: "trans_fats", Name: "Trans fat", Unit: "g"},
+	{Key: "cholesterol", Name: "Cholesterol", Unit: "mg"},
+	{Key: "dietary_fiber", Name: "Dietary fiber", Unit: "g"},
+	{Key: "total_sugars", Name: "Total sugars", Unit: "g"},
+	{Key: "added_sugars", Name: "Added sugars", Unit: "g"},
+	{Key: "sodium", Name: "Sodium", Unit: "mg"},
+	{Key: "potassium", Name: "Potassium", Unit: "mg"},
+	{Key: "calcium", Name: "Calcium", Unit: "mg"},
+	{Key: "iron", Name: "Iron", Unit: "mg"},
+	{Key: "magnesium", Name: "Magnesium", Unit: "mg"},
+	{Key: "zinc", Name: "Zinc", Unit: "mg"},
+	{Key: "vitamin_a", Name: "Vitamin A", Unit: "mcg"},
+	{Key: "vitamin_c", Name: "Vitamin C", Unit: "mg"},
+	{Key: "vitamin_d", Name: "Vitamin D", Unit: "mcg"},
+	{Key: "vitamin_b12", Name: "Vitamin B12", Unit: "mcg"},
+	{Key: "folate", Name: "Folate", Unit: "mcg"},
+	{Key: "omega_3", Name: "Omega-3 fatty acids", Unit: "g"},
+}
+
+// nutrientAliases maps alternative spellings found in reference data and
+// third-party datasets onto the canonical keys
+var nutrientAliases = map[string]string{
+	"energy":        "calories",
+	"energy_kcal":   "calories",
+	"kcal":          "calories",
+	"carbs":         "carbohydrates",
+	"carbohydrate":  "carbohydrates",
+	"fat":           "total_fat",
+	"fats":          "total_fat",
+	"saturated_fat": "saturated_fats",
+	"trans_fat":     "trans_fats",
+	"fiber":         "dietary_fiber",
+	"fibre":         "dietary_fiber",
+	"sugar":         "total_sugars",
+	"sugars":        "total_sugars",
+	"added_sugar":   "added_sugars",
+	"vitamin_b_12":  "vitamin_b12",
+	"omega3":        "omega_3",
+}
+
+// CanonicalNutrientKey returns the canonical key for a nutrient name or alias
+func CanonicalNutrientKey(key string) string {
+	k := strings.ToLower(strings.TrimSpace(key))
+	k = strings.ReplaceAll(k, " ", "_")
+	k = strings.ReplaceAll(k, "-", "_")
+	if canonical, ok := nutrientAliases[k]; ok {
+		return canonical
+	}
+	return k
+}
+
+// LookupNutrient returns the canonical description of a nutrient
+func LookupNutrient(key string) (NutrientInfo, bool) {
+	canonical := CanonicalNutrientKey(key)
+	for _, n := range CanonicalNutrients {
+		if n.Key == canonical {
+			return n, true
+		}
+	}
+	return NutrientInfo{}, false
+}
+
+// NutrientValue returns the amount of a nutrient per 100g, accepting canonical keys or aliases
+func (f *Food) NutrientValue(key string) (float64, bool) {
+	for _, k := range NutrientKeys(key) {
+		if v, ok := f.Nutrition100g.Get(k); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// NutrientKeys returns the keys a nutrient may be stored under, in the order
+// NutrientValue reads them: the key itself, its canonical key, then any other
+// alias of the canonical key
+func NutrientKeys(key string) []string {
+	canonical := CanonicalNutrientKey(key)
+	keys := []string{key}
+	if canonical != key {
+		keys = append(keys, canonical)
+	}
+
+	var aliases []string
+	for alias, target := range nutrientAliases {
+		if target == canonical && alias != key {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return append(keys, aliases...)
+}
+
+// ServingGrams returns the weight of one serving in grams, defaulting to 100g
+// when the serving information has no metric weight
+func (f *Food) ServingGrams() float64 {
+	if grams, ok := f.Serving.Weight(); ok {
+		return grams
+	}
+	return 100
+}
@@ -0,0 +1,137 @@
+package food
+
+import "strings"
+
+// NutriScoreGrades lists the Nutri-Score grades from best to worst
+const NutriScoreGrades = "ABCDE"
+
+// FruitVegKey is the Nutrition100g key holding the percentage of fruit,
+// vegetables, pulses and nuts in a food
+const FruitVegKey = "fruits_vegetables_nuts"
+
+// NutriScore is a food's Nutri-Score grade and the points behind it
+type NutriScore struct {
+	Grade          string           `json:"grade"`
+	Score          int              `json:"score"` // Negative minus positive points; lower is better
+	Points         NutriScorePoints `json:"points"`
+	ProteinCounted bool             `json:"protein_counted"`
+}
+
+// NutriScorePoints is the per-component breakdown of a Nutri-Score
+type NutriScorePoints struct {
+	Energy       int `json:"energy"`
+	Sugars       int `json:"sugars"`
+	SaturatedFat int `json:"saturated_fat"`
+	Sodium       int `json:"sodium"`
+	FruitVeg     int `json:"fruit_veg"`
+	Fibre        int `json:"fibre"`
+	Protein      int `json:"protein"`
+	Negative     int `json:"negative"`
+	Positive     int `json:"positive"`
+}
+
+// Nutri-Score point thresholds per 100g for general foods: a component earns
+// one point for each threshold its value exceeds
+var (
+	nutriScoreEnergyKJ     = []float64{335, 670, 1005, 1340, 1675, 2010, 2345, 2680, 3015, 3350}
+	nutriScoreSugars       = []float64{4.5, 9, 13.5, 18, 22.5, 27, 31, 36, 40, 45}
+	nutriScoreSaturatedFat = []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	nutriScoreSodiumMg     = []float64{90, 180, 270, 360, 450, 540, 630, 720, 810, 900}
+	nutriScoreFibre        = []float64{0.9, 1.9, 2.8, 3.7, 4.7}
+	nutriScoreProtein      = []float64{1.6, 3.2, 4.8, 6.4, 8.0}
+)
+
+// ComputeNutriScore grades a food with the general food Nutri-Score algorithm.
+// Energy, sugars, saturated fat and sodium are required; missing fibre,
+// protein or fruit/vegetable share count as zero. It returns nil when the
+// food lacks the required nutrients.
+func ComputeNutriScore(f *Food) *NutriScore {
+	calories, ok1 := f.NutrientValue("calories")
+	sugars, ok2 := f.NutrientValue("total_sugars")
+	saturatedFat, ok3 := f.NutrientValue("saturated_fats")
+	sodium, ok4 := f.NutrientValue("sodium")
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil
+	}
+	fibre, _ := f.NutrientValue("dietary_fiber")
+	protein, _ := f.NutrientValue("protein")
+	fruitVeg, _ := f.Nutrition100g.Get(FruitVegKey)
+
+	p := NutriScorePoints{
+		Energy:       thresholdPoints(calories*4.184, nutriScoreEnergyKJ),
+		Sugars:       thresholdPoints(sugars, nutriScoreSugars),
+		SaturatedFat: thresholdPoints(saturatedFat, nutriScoreSaturatedFat),
+		Sodium:       thresholdPoints(sodium, nutriScoreSodiumMg),
+		FruitVeg:     fruitVegPoints(fruitVeg),
+		Fibre:        thresholdPoints(fibre, nutriScoreFibre),
+		Protein:      thresholdPoints(protein, nutriScoreProtein),
+	}
+	p.Negative = p.Energy + p.Sugars + p.SaturatedFat + p.Sodium
+
+	// Protein only offsets a poor score when the food is mostly fruit and
+	// vegetables; cheese is exempt from the cap
+	proteinCounted := p.Negative < 11 || p.FruitVeg >= 5 || strings.EqualFold(f.FoodType, "cheese")
+	p.Positive = p.FruitVeg + p.Fibre
+	if proteinCounted {
+		p.Positive += p.Protein
+	}
+
+	score := p.Negative - p.Positive
+	return &NutriScore{
+		Grade:          nutriScoreGrade(score),
+		Score:          score,
+		Points:         p,
+		ProteinCounted: proteinCounted,
+	}
+}
+
+// ValidNutriScoreGrade reports whether grade is one of A to E
+func ValidNutriScoreGrade(grade string) bool {
+	return len(grade) == 1 && strings.Contains(NutriScoreGrades, grade)
+}
+
+// NutriScoreRank orders grades from 0 for A to 4 for E, or -1 when ungraded
+func NutriScoreRank(grade string) int {
+	if !ValidNutriScoreGrade(grade) {
+		return -1
+	}
+	return strings.Index(NutriScoreGrades, grade)
+}
+
+func nutriScoreGrade(score int) string {
+	switch {
+	case score <= -1:
+		return "A"
+	case score <= 2:
+		return "B"
+	case score <= 10:
+		return "C"
+	case score <= 18:
+		return "D"
+	default:
+		return "E"
+	}
+}
+
+func thresholdPoints(value float64, thresholds []float64) int {
+	points := 0
+	for _, t := range thresholds {
+		if value > t {
+			points++
+		}
+	}
+	return points
+}
+
+func fruitVegPoints(percent float64) int {
+	switch {
+	case percent > 80:
+		return 5
+	case percent > 60:
+		return 2
+	case percent > 40:
+		return 1
+	default:
+		return 0
+	}
+}
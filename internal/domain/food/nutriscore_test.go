@@ -0,0 +1,130 @@
+package food
+
+import "testing"
+
+func TestComputeNutriScore(t *testing.T) {
+	tests := []struct {
+		name           string
+		foodType       string
+		nutrients      map[string]float64
+		wantNil        bool
+		grade          string
+		score          int
+		proteinCounted bool
+	}{
+		{
+			name:      "missing sodium",
+			nutrients: map[string]float64{"calories": 100, "total_sugars": 1, "saturated_fats": 1},
+			wantNil:   true,
+		},
+		{
+			name:           "nothing scored",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 0, "saturated_fats": 0, "sodium": 0},
+			grade:          "B",
+			score:          0,
+			proteinCounted: true,
+		},
+		{
+			name:           "value on a threshold earns no point",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 4.5, "saturated_fats": 1, "sodium": 90},
+			grade:          "B",
+			score:          0,
+			proteinCounted: true,
+		},
+		{
+			name:           "A at -1",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 0, "saturated_fats": 0, "sodium": 0, "dietary_fiber": 1},
+			grade:          "A",
+			score:          -1,
+			proteinCounted: true,
+		},
+		{
+			name:           "B at 2",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 0, "saturated_fats": 2.5, "sodium": 0},
+			grade:          "B",
+			score:          2,
+			proteinCounted: true,
+		},
+		{
+			name:           "C at 3",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 0, "saturated_fats": 3.5, "sodium": 0},
+			grade:          "C",
+			score:          3,
+			proteinCounted: true,
+		},
+		{
+			name:           "C at 10",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 0, "saturated_fats": 10.5, "sodium": 0},
+			grade:          "C",
+			score:          10,
+			proteinCounted: true,
+		},
+		{
+			name:      "D at 11, protein not counted",
+			nutrients: map[string]float64{"calories": 0, "total_sugars": 5, "saturated_fats": 10.5, "sodium": 0, "protein": 9},
+			grade:     "D",
+			score:     11,
+		},
+		{
+			name:           "cheese counts protein",
+			foodType:       "cheese",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 5, "saturated_fats": 10.5, "sodium": 0, "protein": 9},
+			grade:          "C",
+			score:          6,
+			proteinCounted: true,
+		},
+		{
+			name:           "mostly fruit counts protein",
+			nutrients:      map[string]float64{"calories": 0, "total_sugars": 5, "saturated_fats": 10.5, "sodium": 0, "protein": 9, FruitVegKey: 90},
+			grade:          "B",
+			score:          11 - 10,
+			proteinCounted: true,
+		},
+		{
+			name:      "D at 18",
+			nutrients: map[string]float64{"calories": 0, "total_sugars": 36.5, "saturated_fats": 10.5, "sodium": 0},
+			grade:     "D",
+			score:     18,
+		},
+		{
+			name:      "E at 19",
+			nutrients: map[string]float64{"calories": 0, "total_sugars": 40.5, "saturated_fats": 10.5, "sodium": 0},
+			grade:     "E",
+			score:     19,
+		},
+		{
+			name:           "energy from kcal",
+			nutrients:      map[string]float64{"calories": 200, "total_sugars": 0, "saturated_fats": 0, "sodium": 0},
+			grade:          "B",
+			score:          2,
+			proteinCounted: true,
+		},
+		{
+			name:           "aliased keys",
+			nutrients:      map[string]float64{"energy": 0, "sugars": 10, "saturated_fat": 0, "sodium": 0, "fibre": 2},
+			grade:          "B",
+			score:          0,
+			proteinCounted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Food{FoodType: tt.foodType, Nutrition100g: NutrientsFromMap(tt.nutrients)}
+			got := ComputeNutriScore(f)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("ComputeNutriScore() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("ComputeNutriScore() = nil")
+			}
+			if got.Grade != tt.grade || got.Score != tt.score || got.ProteinCounted != tt.proteinCounted {
+				t.Errorf("ComputeNutriScore() = grade %s, score %d, protein counted %v; want %s, %d, %v",
+					got.Grade, got.Score, got.ProteinCounted, tt.grade, tt.score, tt.proteinCounted)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package food
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Nutrients is a food's nutrient content per 100g, in the units listed in
+// CanonicalNutrients. The canonical nutrients have a field each, nil when unknown;
+// other numeric nutrients, including aliases such as "fat" used by some
+// sources, are kept in Extra under their original key. Values that are not
+// numbers are preserved as-is, so that decoding and re-encoding loses nothing.
+type Nutrients struct {
+	Calories             *float64 `json:"calories,omitempty"`
+	Protein              *float64 `json:"protein,omitempty"`
+	Carbohydrates        *float64 `json:"carbohydrates,omitempty"`
+	TotalFat             *float64 `json:"total_fat,omitempty"`
+	SaturatedFats        *float64 `json:"saturated_fats,omitempty"`
+	TransFats            *float64 `json:"trans_fats,omitempty"`
+	Cholesterol          *float64 `json:"cholesterol,omitempty"`
+	DietaryFiber         *float64 `json:"dietary_fiber,omitempty"`
+	TotalSugars          *float64 `json:"total_sugars,omitempty"`
+	AddedSugars          *float64 `json:"added_sugars,omitempty"`
+	Sodium               *float64 `json:"sodium,omitempty"`
+	Potassium            *float64 `json:"potassium,omitempty"`
+	Calcium              *float64 `json:"calcium,omitempty"`
+	Iron                 *float64 `json:"iron,omitempty"`
+	Magnesium            *float64 `json:"magnesium,omitempty"`
+	Zinc                 *float64 `json:"zinc,omitempty"`
+	VitaminA             *float64 `json:"vitamin_a,omitempty"`
+	VitaminC             *float64 `json:"vitamin_c,omitempty"`
+	VitaminD             *float64 `json:"vitamin_d,omitempty"`
+	VitaminB12           *float64 `json:"vitamin_b12,omitempty"`
+	Folate               *float64 `json:"folate,omitempty"`
+	Omega3               *float64 `json:"omega_3,omitempty"`
+	FruitsVegetablesNuts *float64 `json:"fruits_vegetables_nuts,omitempty"` // Percentage, see FruitVegKey
+
+	Extra map[string]float64 `json:"-"`
+
+	// unparsed holds values that are not numbers, by key
+	unparsed map[string]json.RawMessage
+}
+
+// field returns the field holding a canonical nutrient, or nil for other keys
+func (n *Nutrients) field(key string) **float64 {
+	switch key {
+	case "calories":
+		return &n.Calories
+	case "protein":
+		return &n.Protein
+	case "carbohydrates":
+		return &n.Carbohydrates
+	case "total_fat":
+		return &n.TotalFat
+	case "saturated_fats":
+		return &n.SaturatedFats
+	case "trans_fats":
+		return &n.TransFats
+	case "cholesterol":
+		return &n.Cholesterol
+	case "dietary_fiber":
+		return &n.DietaryFiber
+	case "total_sugars":
+		return &n.TotalSugars
+	case "added_sugars":
+		return &n.AddedSugars
+	case "sodium":
+		return &n.Sodium
+	case "potassium":
+		return &n.Potassium
+	case "calcium":
+		return &n.Calcium
+	case "iron":
+		return &n.Iron
+	case "magnesium":
+		return &n.Magnesium
+	case "zinc":
+		return &n.Zinc
+	case "vitamin_a":
+		return &n.VitaminA
+	case "vitamin_c":
+		return &n.VitaminC
+	case "vitamin_d":
+		return &n.VitaminD
+	case "vitamin_b12":
+		return &n.VitaminB12
+	case "folate":
+		return &n.Folate
+	case "omega_3":
+		return &n.Omega3
+	case FruitVegKey:
+		return &n.FruitsVegetablesNuts
+	}
+	return nil
+}
+
+// fieldKeys lists the keys of the nutrient fields, in field order
+var fieldKeys = func() []string {
+	keys := make([]string, 0, len(CanonicalNutrients)+1)
+	for _, info := range CanonicalNutrients {
+		keys = append(keys, info.Key)
+	}
+	return append(keys, FruitVegKey)
+}()
+
+// NutrientsFromMap builds Nutrients from values keyed by nutrient
+func NutrientsFromMap(values map[string]float64) Nutrients {
+	var n Nutrients
+	for key, v := range values {
+		n.Set(key, v)
+	}
+	return n
+}
+
+// Get returns the value stored under exactly key
+func (n Nutrients) Get(key string) (float64, bool) {
+	if f := n.field(key); f != nil {
+		if *f == nil {
+			return 0, false
+		}
+		return **f, true
+	}
+	v, ok := n.Extra[key]
+	return v, ok
+}
+
+// Set stores a value under key, in its field for a canonical nutrient
+func (n *Nutrients) Set(key string, v float64) {
+	delete(n.unparsed, key)
+	if f := n.field(key); f != nil {
+		*f = &v
+		return
+	}
+	if n.Extra == nil {
+		n.Extra = map[string]float64{}
+	}
+	n.Extra[key] = v
+}
+
+// Map returns all numeric values by key
+func (n Nutrients) Map() map[string]float64 {
+	values := make(map[string]float64, len(fieldKeys)+len(n.Extra))
+	for _, key := range fieldKeys {
+		if v, ok := n.Get(key); ok {
+			values[key] = v
+		}
+	}
+	for key, v := range n.Extra {
+		values[key] = v
+	}
+	return values
+}
+
+// IsZero reports whether no nutrient is known
+func (n Nutrients) IsZero() bool {
+	return len(n.Map()) == 0 && len(n.unparsed) == 0
+}
+
+// MarshalJSON encodes the nutrients as a flat object keyed by nutrient
+func (n Nutrients) MarshalJSON() ([]byte, error) {
+	values := make(map[string]interface{}, len(fieldKeys)+len(n.Extra)+len(n.unparsed))
+	for key, raw := range n.unparsed {
+		values[key] = raw
+	}
+	for key, v := range n.Map() {
+		values[key] = v
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes a flat object keyed by nutrient. Numeric strings such
+// as "3.5" are read as numbers.
+func (n *Nutrients) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	*n = Nutrients{}
+	for key, raw := range values {
+		if v, ok := parseNumber(raw); ok {
+			n.Set(key, v)
+			continue
+		}
+		if n.unparsed == nil {
+			n.unparsed = map[string]json.RawMessage{}
+		}
+		n.unparsed[key] = canonicalJSON(raw)
+	}
+	return nil
+}
+
+// Serving is a food's serving size: an amount in a unit, such as 1 cup, and
+// its weight in grams when known. Other serving details from the source, such
+// as separate common and metric measures, are kept in Extra so that decoding
+// and re-encoding loses nothing. The zero Serving means no serving information.
+type Serving struct {
+	Amount float64                    `json:"amount,omitempty"`
+	Unit   string                     `json:"unit,omitempty"`
+	Grams  float64                    `json:"grams,omitempty"`
+	Extra  map[string]json.RawMessage `json:"-"`
+}
+
+// IsZero reports whether the serving has no information
+func (s Serving) IsZero() bool {
+	return s.Amount == 0 && s.Unit == "" && s.Grams == 0 && len(s.Extra) == 0
+}
+
+// MarshalJSON encodes the serving with its extra details alongside the fields
+func (s Serving) MarshalJSON() ([]byte, error) {
+	values := make(map[string]interface{}, len(s.Extra)+3)
+	for key, raw := range s.Extra {
+		values[key] = raw
+	}
+	if s.Amount != 0 {
+		values["amount"] = s.Amount
+	}
+	if s.Unit != "" {
+		values["unit"] = s.Unit
+	}
+	if s.Grams != 0 {
+		values["grams"] = s.Grams
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes a serving object. "quantity" is read as the amount
+// when there is no "amount", since some sources spell it that way.
+func (s *Serving) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	*s = Serving{}
+	if _, ok := values["amount"]; !ok {
+		if raw, ok := values["quantity"]; ok {
+			values["amount"] = raw
+			delete(values, "quantity")
+		}
+	}
+	for key, raw := range values {
+		switch key {
+		case "amount", "grams":
+			if v, ok := parseNumber(raw); ok {
+				if key == "amount" {
+					s.Amount = v
+				} else {
+					s.Grams = v
+				}
+				continue
+			}
+		case "unit":
+			if err := json.Unmarshal(raw, &s.Unit); err == nil {
+				continue
+			}
+		}
+		if s.Extra == nil {
+			s.Extra = map[string]json.RawMessage{}
+		}
+		s.Extra[key] = canonicalJSON(raw)
+	}
+	return nil
+}
+
+// Weight returns the weight of the serving in grams: Grams when set, else a
+// metric measure in Extra or an amount in grams
+func (s Serving) Weight() (float64, bool) {
+	if s.Grams > 0 {
+		return s.Grams, true
+	}
+	if raw, ok := s.Extra["metric"]; ok {
+		var metric Serving
+		if err := json.Unmarshal(raw, &metric); err == nil {
+			if grams, ok := metric.Weight(); ok {
+				return grams, true
+			}
+		}
+	}
+	if strings.EqualFold(s.Unit, "g") && s.Amount > 0 {
+		return s.Amount, true
+	}
+	return 0, false
+}
+
+// IngredientAnalysis is what a source found in a food's ingredients: the
+// additives and ingredients it identified, and tags such as
+// "en:palm-oil-free" describing the food as a whole. Other details, and
+// values that are not lists of strings, are kept in Extra so that decoding
+// and re-encoding loses nothing.
+type IngredientAnalysis struct {
+	Additives   []string                   `json:"additives,omitempty"`
+	Ingredients []string                   `json:"ingredients,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Extra       map[string]json.RawMessage `json:"-"`
+}
+
+// IsZero reports whether the analysis has no information
+func (a IngredientAnalysis) IsZero() bool {
+	return len(a.Additives) == 0 && len(a.Ingredients) == 0 && len(a.Tags) == 0 && len(a.Extra) == 0
+}
+
+// list returns the field holding a list of strings, or nil for other keys
+func (a *IngredientAnalysis) list(key string) *[]string {
+	switch key {
+	case "additives":
+		return &a.Additives
+	case "ingredients":
+		return &a.Ingredients
+	case "tags":
+		return &a.Tags
+	}
+	return nil
+}
+
+// MarshalJSON encodes the analysis with its extra details alongside the fields
+func (a IngredientAnalysis) MarshalJSON() ([]byte, error) {
+	values := make(map[string]interface{}, len(a.Extra)+3)
+	for key, raw := range a.Extra {
+		values[key] = raw
+	}
+	for _, key := range []string{"additives", "ingredients", "tags"} {
+		if list := *a.list(key); len(list) > 0 {
+			values[key] = list
+		}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes an analysis object
+func (a *IngredientAnalysis) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	*a = IngredientAnalysis{}
+	for key, raw := range values {
+		// Empty and null lists are kept as they are, since the fields would
+		// leave them out
+		if list := a.list(key); list != nil {
+			var items []string
+			if err := json.Unmarshal(raw, &items); err == nil && len(items) > 0 {
+				*list = items
+				continue
+			}
+		}
+		if a.Extra == nil {
+			a.Extra = map[string]json.RawMessage{}
+		}
+		a.Extra[key] = canonicalJSON(raw)
+	}
+	return nil
+}
+
+// canonicalJSON re-encodes a JSON value with sorted object keys and no
+// insignificant whitespace, so that values kept verbatim compare equal however
+// the source, such as a JSONB column, laid them out
+func canonicalJSON(raw json.RawMessage) json.RawMessage {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return raw
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return raw
+	}
+	return out
+}
+
+// parseNumber reads a JSON number, or a string holding one. Null and
+// strings such as "NaN" that are not JSON numbers are not numbers, so they
+// are kept as they are.
+func parseNumber(raw json.RawMessage) (float64, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		s = strings.TrimSpace(s)
+		if strings.HasPrefix(s, `"`) {
+			return 0, false
+		}
+		raw = json.RawMessage(s)
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil || n == "" {
+		return 0, false
+	}
+	v, err := n.Float64()
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
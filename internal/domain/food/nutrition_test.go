@@ -0,0 +1,94 @@
+package food
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNutrientsJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string // Canonical encoding, when it differs from in
+	}{
+		{name: "empty", in: `{}`},
+		{name: "canonical nutrients", in: `{"calories":52,"protein":0.3,"total_fat":0.2,"fruits_vegetables_nuts":100}`},
+		{name: "aliases kept under their key", in: `{"energy":52,"fat":0.2,"fibre":2.4}`},
+		{name: "zero values kept", in: `{"sodium":0,"total_sugars":0}`},
+		{name: "non-numeric values preserved", in: `{"calories":52,"note":"estimated","ranges":{"min":1,"max":2}}`},
+		{name: "numeric strings read as numbers", in: `{"protein":"3.5","sodium":" 120 "}`, want: `{"protein":3.5,"sodium":120}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n Nutrients
+			if err := json.Unmarshal([]byte(tt.in), &n); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			out, err := json.Marshal(n)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			want := tt.want
+			if want == "" {
+				want = tt.in
+			}
+			var got, expected interface{}
+			json.Unmarshal(out, &got)
+			json.Unmarshal([]byte(want), &expected)
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("round trip of %s = %s, want %s", tt.in, out, want)
+			}
+		})
+	}
+}
+
+func TestNutrientsGet(t *testing.T) {
+	var n Nutrients
+	if err := json.Unmarshal([]byte(`{"protein":3.5,"fat":1.2,"note":"x"}`), &n); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		want   float64
+		wantOK bool
+	}{
+		{key: "protein", want: 3.5, wantOK: true},
+		{key: "fat", want: 1.2, wantOK: true},
+		{key: "total_fat"},
+		{key: "calories"},
+		{key: "note"},
+	}
+	for _, tt := range tests {
+		got, ok := n.Get(tt.key)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Get(%q) = %v, %v; want %v, %v", tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFoodNutrientValue(t *testing.T) {
+	f := &Food{Nutrition100g: NutrientsFromMap(map[string]float64{"fibre": 2.4, "sugars": 10, "total_sugars": 12})}
+
+	tests := []struct {
+		key    string
+		want   float64
+		wantOK bool
+	}{
+		{key: "dietary_fiber", want: 2.4, wantOK: true},
+		{key: "fiber", want: 2.4, wantOK: true},
+		{key: "total_sugars", want: 12, wantOK: true},
+		{key: "sugars", want: 10, wantOK: true},
+		{key: "sugar", want: 12, wantOK: true},
+		{key: "protein"},
+	}
+	for _, tt := range tests {
+		got, ok := f.NutrientValue(tt.key)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("NutrientValue(%q) = %v, %v; want %v, %v", tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package food
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revision actions
+const (
+	RevisionCreate = "create"
+	RevisionUpdate = "update"
+	RevisionDelete = "delete"
+	RevisionRevert = "revert"
+	RevisionMerge  = "merge" // The food was merged into another and deleted
+)
+
+// Catalog editing errors
+var (
+	ErrFoodExists        = errors.New("a food with this ID already exists")
+	ErrFieldNotEditable  = errors.New("field cannot be edited")
+	ErrInvalidPatch      = errors.New("invalid patch")
+	ErrNameRequired      = errors.New("name is required")
+	ErrNothingToRevert   = errors.New("the food is already in the state before this revision")
+	ErrRevisionOtherFood = errors.New("revision belongs to another food")
+)
+
+// EditableFields are the JSON fields of a Food that admins can set. The
+// derived classifications, barcode validation and timestamps are recomputed.
+var EditableFields = []string{
+	"name", "alternate_names", "description", "food_type", "source", "serving",
+	"nutrition_100g", "ean_13", "labels", "package_size", "ingredients", "ingredient_analysis",
+}
+
+// FieldChange is the old and new JSON value of a changed field; null means unset
+type FieldChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// Revision is one recorded change to a food. Before is nil for a create and
+// After is nil for a delete.
+type Revision struct {
+	ID                 uuid.UUID              `json:"id"`
+	FoodID             string                 `json:"food_id"`
+	Action             string                 `json:"action"`
+	AuthorID           *uuid.UUID             `json:"author_id,omitempty"`
+	Diff               map[string]FieldChange `json:"diff"`
+	Before             *Food                  `json:"before,omitempty"`
+	After              *Food                  `json:"after,omitempty"`
+	RevertedRevisionID *uuid.UUID             `json:"reverted_revision_id,omitempty"` // Set on revert revisions
+	CreatedAt          time.Time              `json:"created_at"`
+}
+
+// EditRepository writes admin edits to the catalog. Each write and the
+// revision recording it happen in one transaction, so the history never
+// drifts from the catalog.
+type EditRepository interface {
+	// Create adds a food
+	Create(ctx context.Context, f *Food, rev *Revision) error
+	// Update replaces a food's fields, leaving it retired if it was
+	Update(ctx context.Context, f *Food, rev *Revision) error
+	// Retire retires a food, keeping what refers to it. It returns
+	// sql.ErrNoRows when the food is already retired.
+	Retire(ctx context.Context, id string, rev *Revision) error
+	// Restore replaces a retired food's fields and brings it back
+	Restore(ctx context.Context, f *Food, rev *Revision) error
+}
+
+// DiffFoods returns the editable fields that differ between two versions of a
+// food. Either version may be nil, as for a create or delete.
+func DiffFoods(before, after *Food) map[string]FieldChange {
+	old, _ := editableJSON(before)
+	updated, _ := editableJSON(after)
+
+	diff := map[string]FieldChange{}
+	for _, field := range EditableFields {
+		o, n := jsonOrNull(old[field]), jsonOrNull(updated[field])
+		if !bytes.Equal(o, n) {
+			diff[field] = FieldChange{Old: o, New: n}
+		}
+	}
+	return diff
+}
+
+// ApplyPatch applies a JSON merge patch (RFC 7386) to a copy of a food.
+// Objects such as nutrition_100g are merged key by key and null removes a
+// value, so {"nutrition_100g": {"protein": 12.5}} corrects a single nutrient.
+// Only EditableFields may be patched.
+func ApplyPatch(f *Food, patch json.RawMessage) (*Food, error) {
+	var changes map[string]interface{}
+	if err := json.Unmarshal(patch, &changes); err != nil || changes == nil {
+		return nil, fmt.Errorf("%w: expected a JSON object", ErrInvalidPatch)
+	}
+
+	doc := map[string]interface{}{}
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	for field, value := range changes {
+		if !isEditable(field) {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotEditable, field)
+		}
+		if value == nil {
+			delete(doc, field)
+			continue
+		}
+		doc[field] = mergePatch(doc[field], value)
+	}
+
+	raw, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var patched Food
+	if err := json.Unmarshal(raw, &patched); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	patched.ID = f.ID
+	patched.CreatedAt = f.CreatedAt
+	return &patched, nil
+}
+
+// RevertFields returns a copy of a food with each field in diff set back to
+// its old value, leaving every other field as it is now
+func RevertFields(f *Food, diff map[string]FieldChange) (*Food, error) {
+	doc := map[string]json.RawMessage{}
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	for field, change := range diff {
+		if !isEditable(field) {
+			continue
+		}
+		if bytes.Equal(jsonOrNull(change.Old), []byte("null")) {
+			delete(doc, field)
+			continue
+		}
+		doc[field] = change.Old
+	}
+
+	raw, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var reverted Food
+	if err := json.Unmarshal(raw, &reverted); err != nil {
+		return nil, err
+	}
+	reverted.ID = f.ID
+	reverted.CreatedAt = f.CreatedAt
+	return &reverted, nil
+}
+
+// mergePatch merges patch into target following RFC 7386
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+func isEditable(field string) bool {
+	for _, f := range EditableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// editableJSON returns the JSON encoding of each editable field of a food
+func editableJSON(f *Food) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if f == nil {
+		return fields, nil
+	}
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(raw, &fields)
+	return fields, err
+}
+
+func jsonOrNull(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
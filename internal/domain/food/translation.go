@@ -0,0 +1,114 @@
+package food
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is the locale of the names and descriptions stored on foods
+// themselves; other locales are stored as translations
+const DefaultLocale = "en"
+
+// SupportedLocales are the locales foods can be translated into
+var SupportedLocales = []string{"en", "fr", "de", "ak", "ee", "gaa"}
+
+// Translation errors
+var (
+	ErrUnsupportedLocale   = errors.New("locale must be one of en, fr, de, ak, ee, gaa")
+	ErrDefaultLocale       = errors.New("English names and descriptions are edited on the food itself")
+	ErrTranslationRequired = errors.New("translated name is required")
+)
+
+// Translation is a food's name, alternate names and description in a locale
+type Translation struct {
+	FoodID         string    `json:"food_id"`
+	Locale         string    `json:"locale"`
+	Name           string    `json:"name" validate:"required,max=255"`
+	AlternateNames []string  `json:"alternate_names,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// NormalizeLocale returns the supported locale for a language tag such as
+// fr-CA, matching on the primary language subtag, or false when the
+// language is not supported
+func NormalizeLocale(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	for _, locale := range SupportedLocales {
+		if tag == locale {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage returns the supported locale an Accept-Language header
+// prefers most, by quality value, or false when it names none of them
+func ParseAcceptLanguage(header string) (string, bool) {
+	type choice struct {
+		locale string
+		q      float64
+	}
+	var choices []choice
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		locale, ok := NormalizeLocale(tag)
+		if !ok || q <= 0 {
+			continue
+		}
+		choices = append(choices, choice{locale: locale, q: q})
+	}
+	if len(choices) == 0 {
+		return "", false
+	}
+	// Stable, so that the header order breaks ties
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	return choices[0].locale, true
+}
+
+type localeKey struct{}
+
+// ContextWithLocale returns a context carrying the locale of a request
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale of a request, DefaultLocale when unset
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Localize replaces the food's name, alternate names and description with a
+// translation, keeping the English description when the translation has none
+func (f *Food) Localize(t *Translation) {
+	if t == nil {
+		return
+	}
+	f.Locale = t.Locale
+	f.Name = t.Name
+	if len(t.AlternateNames) > 0 {
+		f.AlternateNames = t.AlternateNames
+	}
+	if t.Description != "" {
+		f.Description = t.Description
+	}
+}
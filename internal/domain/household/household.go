@@ -0,0 +1,83 @@
+package household
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation statuses
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+)
+
+// Common errors
+var (
+	ErrNotOwner             = errors.New("only the household owner can do this")
+	ErrNotMember            = errors.New("user is not a member of this household")
+	ErrInvitationNotPending = errors.New("invitation is no longer pending")
+	ErrInvitationNotForUser = errors.New("invitation was sent to a different email address")
+	ErrNoMembers            = errors.New("household has no member profiles")
+)
+
+// Household groups profiles, possibly owned by different users, that share meal plans
+type Household struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Members   []Member  `json:"members"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Member is a profile that belongs to a household
+type Member struct {
+	ProfileID     uuid.UUID `json:"profile_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	ProfileName   string    `json:"profile_name"`
+	CalorieTarget int       `json:"calorie_target,omitempty"`
+	JoinedAt      time.Time `json:"joined_at"`
+}
+
+// Invitation asks another user to add one of their profiles to a household
+type Invitation struct {
+	ID           uuid.UUID  `json:"id"`
+	HouseholdID  uuid.UUID  `json:"household_id"`
+	InvitedBy    uuid.UUID  `json:"invited_by"`
+	InviteeEmail string     `json:"invitee_email"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RespondedAt  *time.Time `json:"responded_at,omitempty"`
+}
+
+// Repository defines the interface for household data access
+type Repository interface {
+	Create(ctx context.Context, h *Household) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Household, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]Household, error)
+	Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error
+	AddMember(ctx context.Context, householdID uuid.UUID, profileID uuid.UUID) error
+	RemoveMember(ctx context.Context, householdID uuid.UUID, profileID uuid.UUID) error
+	ListMembers(ctx context.Context, householdID uuid.UUID) ([]Member, error)
+	CreateInvitation(ctx context.Context, inv *Invitation) error
+	GetInvitation(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	ListPendingInvitations(ctx context.Context, email string) ([]Invitation, error)
+	UpdateInvitationStatus(ctx context.Context, id uuid.UUID, status string) error
+}
+
+// IsMember reports whether any of the household's member profiles belongs to the user
+func (h *Household) IsMember(userID uuid.UUID) bool {
+	if h.OwnerID == userID {
+		return true
+	}
+	for _, m := range h.Members {
+		if m.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
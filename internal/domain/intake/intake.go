@@ -0,0 +1,26 @@
+package intake
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry represents a single logged portion of food eaten by a profile
+type Entry struct {
+	ID          uuid.UUID `json:"id"`
+	ProfileID   uuid.UUID `json:"profile_id"`
+	FoodID      string    `json:"food_id"`
+	AmountGrams float64   `json:"amount_g" validate:"required,gt=0"`
+	MealType    string    `json:"meal_type,omitempty" validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	ConsumedAt  time.Time `json:"consumed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Repository defines the interface for intake log data access
+type Repository interface {
+	Create(ctx context.Context, entry *Entry) error
+	ListByProfile(ctx context.Context, profileID uuid.UUID, from, to time.Time) ([]Entry, error)
+	Delete(ctx context.Context, id uuid.UUID, profileID uuid.UUID) error
+}
@@ -0,0 +1,79 @@
+package measurement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Measurement types
+const (
+	TypeWeight  = "weight"
+	TypeBodyFat = "body_fat"
+	TypeWaist   = "waist"
+	TypeHip     = "hip"
+	TypeChest   = "chest"
+	TypeNeck    = "neck"
+)
+
+// Common errors
+var (
+	ErrUnknownType = errors.New("unknown measurement type")
+	ErrFutureTime  = errors.New("measurement time is in the future")
+)
+
+// Units maps each measurement type to the unit its values are stored in
+var Units = map[string]string{
+	TypeWeight:  "kg",
+	TypeBodyFat: "%",
+	TypeWaist:   "cm",
+	TypeHip:     "cm",
+	TypeChest:   "cm",
+	TypeNeck:    "cm",
+}
+
+// Measurement represents a single body measurement taken by a user
+type Measurement struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Type       string    `json:"type" validate:"required,oneof=weight body_fat waist hip chest neck"`
+	Value      float64   `json:"value" validate:"required,gt=0"`
+	Unit       string    `json:"unit"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Notes      string    `json:"notes,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CalorieAdjustment records an automatic change to a profile's calorie target
+// made because the observed weight trend drifted from the profile's goal
+type CalorieAdjustment struct {
+	ID                 uuid.UUID `json:"id"`
+	ProfileID          uuid.UUID `json:"profile_id"`
+	PreviousTarget     int       `json:"previous_target"`
+	NewTarget          int       `json:"new_target"`
+	ObservedRateKgWeek float64   `json:"observed_rate_kg_week"`
+	GoalRateKgWeek     float64   `json:"goal_rate_kg_week"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// RecordResult is returned after recording a measurement
+type RecordResult struct {
+	Measurement *Measurement       `json:"measurement"`
+	Trend       *Series            `json:"trend,omitempty"`
+	Adjustment  *CalorieAdjustment `json:"calorie_adjustment,omitempty"`
+}
+
+// Repository defines the interface for measurement data access
+type Repository interface {
+	Create(ctx context.Context, m *Measurement) error
+	List(ctx context.Context, userID uuid.UUID, measurementType string, from, to time.Time) ([]Measurement, error)
+	Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	// ApplyAdjustment sets the profile's calorie target and records the
+	// adjustment in one transaction. It returns sql.ErrNoRows when the
+	// target is no longer the adjustment's previous target.
+	ApplyAdjustment(ctx context.Context, adjustment *CalorieAdjustment) error
+	GetLatestAdjustment(ctx context.Context, profileID uuid.UUID) (*CalorieAdjustment, error)
+	ListAdjustments(ctx context.Context, profileID uuid.UUID) ([]CalorieAdjustment, error)
+}
@@ -0,0 +1,94 @@
+package measurement
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultSmoothing is the per-day smoothing factor of the exponential moving
+// average. 0.1 is the value popularised by "The Hacker's Diet" for weight.
+const DefaultSmoothing = 0.1
+
+// TrendPoint is a raw measurement together with its smoothed trend value
+type TrendPoint struct {
+	MeasuredAt time.Time `json:"measured_at"`
+	Value      float64   `json:"value"`
+	Trend      float64   `json:"trend"`
+}
+
+// Series is a measurement history with its smoothed trend
+type Series struct {
+	Type        string       `json:"type"`
+	Unit        string       `json:"unit"`
+	Points      []TrendPoint `json:"points"`
+	Latest      *float64     `json:"latest_trend,omitempty"`
+	WeeklyRate  *float64     `json:"weekly_rate,omitempty"` // Change of the trend per week over the rate window
+	RateWindow  int          `json:"rate_window_days"`
+	SampleCount int          `json:"sample_count"`
+}
+
+// Smooth computes an exponential moving average over measurements ordered by
+// time. Gaps between readings are accounted for by compounding the per-day
+// smoothing factor, so a reading after a week away moves the trend further
+// than one taken the next day.
+func Smooth(measurements []Measurement, alpha float64) []TrendPoint {
+	points := make([]TrendPoint, 0, len(measurements))
+	var trend float64
+	var last time.Time
+	for i, m := range measurements {
+		if i == 0 {
+			trend = m.Value
+		} else {
+			days := m.MeasuredAt.Sub(last).Hours() / 24
+			if days < 1 {
+				days = 1
+			}
+			weight := 1 - math.Pow(1-alpha, days)
+			trend += weight * (m.Value - trend)
+		}
+		last = m.MeasuredAt
+		points = append(points, TrendPoint{MeasuredAt: m.MeasuredAt, Value: m.Value, Trend: round2(trend)})
+	}
+	return points
+}
+
+// WeeklyRate returns the least-squares slope of the trend, in units per week,
+// over the points within window of the latest point. It returns false when
+// fewer than minPoints readings or less than minSpan of history are available.
+func WeeklyRate(points []TrendPoint, window, minSpan time.Duration, minPoints int) (float64, bool) {
+	if len(points) == 0 {
+		return 0, false
+	}
+
+	end := points[len(points)-1].MeasuredAt
+	var recent []TrendPoint
+	for _, p := range points {
+		if end.Sub(p.MeasuredAt) <= window {
+			recent = append(recent, p)
+		}
+	}
+	if len(recent) < minPoints || end.Sub(recent[0].MeasuredAt) < minSpan {
+		return 0, false
+	}
+
+	// Simple linear regression of trend against days since the first point
+	var sumX, sumY, sumXY, sumXX float64
+	n := float64(len(recent))
+	for _, p := range recent {
+		x := p.MeasuredAt.Sub(recent[0].MeasuredAt).Hours() / 24
+		sumX += x
+		sumY += p.Trend
+		sumXY += x * p.Trend
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+	slopePerDay := (n*sumXY - sumX*sumY) / denominator
+	return round2(slopePerDay * 7), true
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
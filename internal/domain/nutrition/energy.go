@@ -0,0 +1,84 @@
+package nutrition
+
+import "math"
+
+// KcalPerKg is the approximate energy content of a kilogram of body weight change
+const KcalPerKg = 7700
+
+// MinCalorieTarget is the lowest calorie target that will be set automatically
+const MinCalorieTarget = 1200
+
+// goalRates is the intended weekly weight change in kg for each profile goal type
+var goalRates = map[string]float64{
+	"weight_loss": -0.5,
+	"maintenance": 0,
+	"weight_gain": 0.25,
+	"muscle_gain": 0.25,
+}
+
+// activityFactors are the physical activity multipliers applied to basal metabolic rate
+var activityFactors = map[string]float64{
+	"sedentary":   1.2,
+	"light":       1.375,
+	"moderate":    1.55,
+	"very_active": 1.725,
+}
+
+// GoalWeeklyRate returns the intended weekly weight change for a goal type
+func GoalWeeklyRate(goalType string) (float64, bool) {
+	rate, ok := goalRates[goalType]
+	return rate, ok
+}
+
+// EstimateCalorieTarget estimates a daily calorie target from the Mifflin-St Jeor
+// equation, the activity level and the energy surplus or deficit implied by the goal.
+// It returns 0 when weight or height is unknown.
+func EstimateCalorieTarget(weightKg, heightCm float64, age int, sex, activityLevel, goalType string) int {
+	if weightKg <= 0 || heightCm <= 0 {
+		return 0
+	}
+	if age <= 0 {
+		age = DefaultAge
+	}
+
+	bmr := 10*weightKg + 6.25*heightCm - 5*float64(age)
+	switch sex {
+	case "male":
+		bmr += 5
+	case "female":
+		bmr -= 161
+	default:
+		bmr -= 78
+	}
+
+	factor, ok := activityFactors[activityLevel]
+	if !ok {
+		factor = activityFactors["sedentary"]
+	}
+
+	rate, _ := GoalWeeklyRate(goalType)
+	target := bmr*factor + rate*KcalPerKg/7
+	return ClampCalorieTarget(int(math.Round(target)))
+}
+
+// AdjustCalorieTarget returns the calorie target that corrects the difference
+// between the observed and the intended weekly weight change. The change is
+// limited to maxStep kcal per adjustment.
+func AdjustCalorieTarget(current int, observedRate, goalRate float64, maxStep int) int {
+	delta := (goalRate - observedRate) * KcalPerKg / 7
+	if delta > float64(maxStep) {
+		delta = float64(maxStep)
+	}
+	if delta < -float64(maxStep) {
+		delta = -float64(maxStep)
+	}
+	return ClampCalorieTarget(current + int(math.Round(delta/10))*10)
+}
+
+// ClampCalorieTarget keeps an automatically derived target above the safe minimum
+func ClampCalorieTarget(kcal int) int {
+	if kcal < MinCalorieTarget {
+		return MinCalorieTarget
+	}
+	return kcal
+}
@@ -0,0 +1,139 @@
+package nutrition
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// Target represents a daily intake goal for a single nutrient
+type Target struct {
+	Nutrient string   `json:"nutrient"`
+	Unit     string   `json:"unit"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Sources  []string `json:"sources"` // e.g. "reference_intake", "hypertension"
+}
+
+// Targets is a set of daily targets keyed by canonical nutrient key
+type Targets map[string]Target
+
+// Add merges a target into the set, keeping the stricter bound on each side
+func (t Targets) Add(target Target) {
+	target.Nutrient = food.CanonicalNutrientKey(target.Nutrient)
+	existing, ok := t[target.Nutrient]
+	if !ok {
+		t[target.Nutrient] = target
+		return
+	}
+
+	if target.Min != nil && (existing.Min == nil || *target.Min > *existing.Min) {
+		existing.Min = target.Min
+	}
+	if target.Max != nil && (existing.Max == nil || *target.Max < *existing.Max) {
+		existing.Max = target.Max
+	}
+	existing.Sources = append(existing.Sources, target.Sources...)
+	t[target.Nutrient] = existing
+}
+
+// Keys returns the nutrient keys in a stable order
+func (t Targets) Keys() []string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TargetsFromCondition converts a health condition's nutrient_restrictions and
+// nutrient_recommendations into daily targets, e.g. {"sodium": {"max": 2000, "unit": "mg"}}
+func TargetsFromCondition(condition string, restrictions, recommendations map[string]interface{}) []Target {
+	var targets []Target
+	for _, spec := range []map[string]interface{}{restrictions, recommendations} {
+		for nutrient, raw := range spec {
+			bounds, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			key := food.CanonicalNutrientKey(nutrient)
+			unit, _ := bounds["unit"].(string)
+			targetUnit := unit
+			if info, ok := food.LookupNutrient(key); ok {
+				targetUnit = info.Unit
+			}
+
+			target := Target{Nutrient: key, Unit: targetUnit, Sources: []string{condition}}
+			if v, ok := bounds["min"].(float64); ok {
+				if converted, ok := ConvertUnit(v, unit, targetUnit); ok {
+					target.Min = &converted
+				}
+			}
+			if v, ok := bounds["max"].(float64); ok {
+				if converted, ok := ConvertUnit(v, unit, targetUnit); ok {
+					target.Max = &converted
+				}
+			}
+			if target.Min != nil || target.Max != nil {
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets
+}
+
+// CalorieTarget returns an energy target with a 10% tolerance either side
+func CalorieTarget(kcal int) Target {
+	min := float64(kcal) * 0.9
+	max := float64(kcal) * 1.1
+	return Target{Nutrient: "calories", Unit: "kcal", Min: &min, Max: &max, Sources: []string{"calorie_target"}}
+}
+
+// ConvertUnit converts a nutrient amount between mass units (g, mg, mcg) or energy units (kcal, kj)
+func ConvertUnit(value float64, from, to string) (float64, bool) {
+	from, to = normalizeUnit(from), normalizeUnit(to)
+	if from == to || from == "" || to == "" {
+		return value, true
+	}
+
+	mass := map[string]float64{"g": 1, "mg": 1e-3, "mcg": 1e-6}
+	if f, ok := mass[from]; ok {
+		if t, ok := mass[to]; ok {
+			return value * f / t, true
+		}
+	}
+
+	switch {
+	case from == "kj" && to == "kcal":
+		return value / 4.184, true
+	case from == "kcal" && to == "kj":
+		return value * 4.184, true
+	}
+	return 0, false
+}
+
+func normalizeUnit(unit string) string {
+	u := strings.ToLower(strings.TrimSpace(unit))
+	switch u {
+	case "µg", "ug", "μg":
+		return "mcg"
+	case "cal":
+		return "kcal"
+	}
+	return u
+}
+
+// AgeFromDateOfBirth returns the age in whole years at the given time. The
+// birthday is compared by month and day, since the day of the year shifts by
+// one after February in leap years.
+func AgeFromDateOfBirth(dob time.Time, at time.Time) int {
+	age := at.Year() - dob.Year()
+	if at.Month() < dob.Month() || at.Month() == dob.Month() && at.Day() < dob.Day() {
+		age--
+	}
+	return age
+}
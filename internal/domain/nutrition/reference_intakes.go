@@ -0,0 +1,111 @@
+package nutrition
+
+// Age bands used by the dietary reference intake tables
+var ageBands = []struct {
+	min, max int
+}{
+	{1, 3}, {4, 8}, {9, 13}, {14, 18}, {19, 30}, {31, 50}, {51, 70}, {71, 200},
+}
+
+// referenceIntake holds per-band daily values for male and female reference
+// individuals. Values follow the US/Canadian Dietary Reference Intakes.
+type referenceIntake struct {
+	nutrient string
+	unit     string
+	upper    bool // true when the value is an upper limit rather than a minimum
+	male     [8]float64
+	female   [8]float64
+}
+
+var referenceIntakes = []referenceIntake{
+	{nutrient: "protein", unit: "g",
+		male:   [8]float64{13, 19, 34, 52, 56, 56, 56, 56},
+		female: [8]float64{13, 19, 34, 46, 46, 46, 46, 46}},
+	{nutrient: "dietary_fiber", unit: "g",
+		male:   [8]float64{19, 25, 31, 38, 38, 38, 30, 30},
+		female: [8]float64{19, 25, 26, 26, 25, 25, 21, 21}},
+	{nutrient: "calcium", unit: "mg",
+		male:   [8]float64{700, 1000, 1300, 1300, 1000, 1000, 1000, 1200},
+		female: [8]float64{700, 1000, 1300, 1300, 1000, 1000, 1200, 1200}},
+	{nutrient: "iron", unit: "mg",
+		male:   [8]float64{7, 10, 8, 11, 8, 8, 8, 8},
+		female: [8]float64{7, 10, 8, 15, 18, 18, 8, 8}},
+	{nutrient: "magnesium", unit: "mg",
+		male:   [8]float64{80, 130, 240, 410, 400, 420, 420, 420},
+		female: [8]float64{80, 130, 240, 360, 310, 320, 320, 320}},
+	{nutrient: "potassium", unit: "mg",
+		male:   [8]float64{2000, 2300, 2500, 3000, 3400, 3400, 3400, 3400},
+		female: [8]float64{2000, 2300, 2300, 2300, 2600, 2600, 2600, 2600}},
+	{nutrient: "zinc", unit: "mg",
+		male:   [8]float64{3, 5, 8, 11, 11, 11, 11, 11},
+		female: [8]float64{3, 5, 8, 9, 8, 8, 8, 8}},
+	{nutrient: "vitamin_a", unit: "mcg",
+		male:   [8]float64{300, 400, 600, 900, 900, 900, 900, 900},
+		female: [8]float64{300, 400, 600, 700, 700, 700, 700, 700}},
+	{nutrient: "vitamin_c", unit: "mg",
+		male:   [8]float64{15, 25, 45, 75, 90, 90, 90, 90},
+		female: [8]float64{15, 25, 45, 65, 75, 75, 75, 75}},
+	{nutrient: "vitamin_d", unit: "mcg",
+		male:   [8]float64{15, 15, 15, 15, 15, 15, 15, 20},
+		female: [8]float64{15, 15, 15, 15, 15, 15, 15, 20}},
+	{nutrient: "vitamin_b12", unit: "mcg",
+		male:   [8]float64{0.9, 1.2, 1.8, 2.4, 2.4, 2.4, 2.4, 2.4},
+		female: [8]float64{0.9, 1.2, 1.8, 2.4, 2.4, 2.4, 2.4, 2.4}},
+	{nutrient: "folate", unit: "mcg",
+		male:   [8]float64{150, 200, 300, 400, 400, 400, 400, 400},
+		female: [8]float64{150, 200, 300, 400, 400, 400, 400, 400}},
+	{nutrient: "sodium", unit: "mg", upper: true,
+		male:   [8]float64{1200, 1500, 1800, 2300, 2300, 2300, 2300, 2300},
+		female: [8]float64{1200, 1500, 1800, 2300, 2300, 2300, 2300, 2300}},
+}
+
+// DefaultAge is used when a user has not provided a date of birth
+const DefaultAge = 35
+
+// ReferenceIntakes returns the daily reference targets for the given age and sex.
+// Sex values other than "male" or "female" use the mean of both tables.
+// calories is used to derive the saturated fat and added sugar limits
+// (each at most 10% of energy); pass 0 to use a 2000 kcal reference diet.
+func ReferenceIntakes(age int, sex string, calories int) Targets {
+	if age <= 0 {
+		age = DefaultAge
+	}
+	band := len(ageBands) - 1
+	for i, b := range ageBands {
+		if age >= b.min && age <= b.max {
+			band = i
+			break
+		}
+	}
+
+	targets := Targets{}
+	for _, ri := range referenceIntakes {
+		var value float64
+		switch sex {
+		case "male":
+			value = ri.male[band]
+		case "female":
+			value = ri.female[band]
+		default:
+			value = (ri.male[band] + ri.female[band]) / 2
+		}
+
+		target := Target{Nutrient: ri.nutrient, Unit: ri.unit, Sources: []string{"reference_intake"}}
+		if ri.upper {
+			target.Max = &value
+		} else {
+			target.Min = &value
+		}
+		targets.Add(target)
+	}
+
+	if calories <= 0 {
+		calories = 2000
+	}
+	satFat := float64(calories) * 0.10 / 9
+	addedSugars := float64(calories) * 0.10 / 4
+	targets.Add(Target{Nutrient: "saturated_fats", Unit: "g", Max: &satFat, Sources: []string{"reference_intake"}})
+	targets.Add(Target{Nutrient: "added_sugars", Unit: "g", Max: &addedSugars, Sources: []string{"reference_intake"}})
+
+	return targets
+}
@@ -1,8 +1,11 @@
 package recommendation
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/nutrition"
 	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
 )
 
@@ -17,8 +20,18 @@ type Rule struct {
 
 // Meal represents a single meal with recommended foods
 type Meal struct {
-	Type  string      `json:"type"` // breakfast, lunch, dinner, snack
-	Foods []food.Food `json:"foods"`
+	Type     string          `json:"type"` // breakfast, lunch, dinner, snack
+	Foods    []food.Food     `json:"foods"`
+	Portions []MemberPortion `json:"portions,omitempty"` // Household plans only
+}
+
+// MemberPortion is one household member's share of a food in a shared meal
+type MemberPortion struct {
+	ProfileID   uuid.UUID `json:"profile_id"`
+	ProfileName string    `json:"profile_name"`
+	FoodID      string    `json:"food_id"`
+	Grams       float64   `json:"grams"`
+	Calories    float64   `json:"calories,omitempty"`
 }
 
 // DailyPlan represents a full day of meal recommendations
@@ -29,9 +42,11 @@ type DailyPlan struct {
 
 // MealPlan represents a complete meal plan for multiple days
 type MealPlan struct {
-	ProfileID string      `json:"profile_id"`
-	Days      []DailyPlan `json:"days"`
-	TotalDays int         `json:"total_days"`
+	ProfileID    string      `json:"profile_id,omitempty"`
+	HouseholdID  string      `json:"household_id,omitempty"`
+	Days         []DailyPlan `json:"days"`
+	TotalDays    int         `json:"total_days"`
+	AppliedRules []Rule      `json:"applied_rules,omitempty"` // Household plans only
 }
 
 // RecommendationRequest represents a request for food recommendations
@@ -40,6 +55,7 @@ type RecommendationRequest struct {
 	CustomRules []Rule     `json:"custom_rules,omitempty"` // Optional: additional rules
 	Limit       int        `json:"limit,omitempty"`        // Optional: limit results
 	Offset      int        `json:"offset,omitempty"`       // Optional: pagination offset
+	Cursor      string     `json:"cursor,omitempty"`       // Optional: next_cursor of the previous page, replaces offset
 }
 
 // RecommendationResponse represents a response with food recommendations
@@ -47,6 +63,93 @@ type RecommendationResponse struct {
 	Foods        []food.Food `json:"foods"`
 	TotalCount   int         `json:"total_count"`
 	AppliedRules []Rule      `json:"applied_rules"`
+	NextCursor   string      `json:"next_cursor,omitempty"` // Continues after the last catalog food scanned
+}
+
+// Gap analysis sources and statuses
+const (
+	GapSourceIntake   = "intake"
+	GapSourceMealPlan = "meal_plan"
+
+	GapStatusDeficit = "deficit"
+	GapStatusExcess  = "excess"
+)
+
+// GapRequest represents a request for a nutrient gap analysis
+type GapRequest struct {
+	ProfileID       uuid.UUID `json:"profile_id"`
+	Source          string    `json:"source"`           // "intake" or "meal_plan"
+	From            time.Time `json:"from"`             // Intake window start (inclusive)
+	To              time.Time `json:"to"`               // Intake window end (exclusive)
+	Days            int       `json:"days"`             // Meal plan length
+	SuggestionLimit int       `json:"suggestion_limit"` // Foods suggested per gap
+}
+
+// FoodContribution represents how much of a nutrient a single food supplied
+type FoodContribution struct {
+	FoodID string  `json:"food_id"`
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// NutrientGap represents a nutrient whose daily average falls outside its target
+type NutrientGap struct {
+	Nutrient        string             `json:"nutrient"`
+	Unit            string             `json:"unit"`
+	DailyAverage    float64            `json:"daily_average"`
+	Target          nutrition.Target   `json:"target"`
+	Status          string             `json:"status"` // "deficit" or "excess"
+	Gap             float64            `json:"gap"`    // Distance from the violated bound
+	PercentOfTarget float64            `json:"percent_of_target"`
+	SuggestedFoods  []food.Food        `json:"suggested_foods,omitempty"`  // Foods that would close a deficit
+	TopContributors []FoodContribution `json:"top_contributors,omitempty"` // Foods driving an excess
+}
+
+// GapReport represents the result of a nutrient gap analysis
+type GapReport struct {
+	ProfileID  uuid.UUID     `json:"profile_id"`
+	Source     string        `json:"source"`
+	From       *time.Time    `json:"from,omitempty"`
+	To         *time.Time    `json:"to,omitempty"`
+	Days       int           `json:"days"`
+	Gaps       []NutrientGap `json:"gaps"`
+	MetTargets []string      `json:"met_targets"`
+	Unassessed []string      `json:"unassessed"` // Targets no food in the window reports data for
+}
+
+// Compatibility verdicts and violation severities
+const (
+	VerdictSafe    = "safe"
+	VerdictCaution = "caution"
+	VerdictAvoid   = "avoid"
+
+	SeverityHigh   = "high"   // Allergens and dietary restrictions
+	SeverityMedium = "medium" // Nutrient limits
+	SeverityLow    = "low"    // Dislikes
+)
+
+// CheckRequest represents a request to check a single food against a profile
+type CheckRequest struct {
+	ProfileID *uuid.UUID `json:"profile_id,omitempty"` // Optional: defaults to the user's default profile
+	FoodID    string     `json:"food_id,omitempty"`
+	EAN13     string     `json:"ean_13,omitempty"`
+}
+
+// Violation represents a profile rule a food breaks
+type Violation struct {
+	Rule     Rule     `json:"rule"`
+	Severity string   `json:"severity"`
+	Reason   string   `json:"reason"`
+	Matched  string   `json:"matched,omitempty"` // Offending ingredient, label or name
+	Value    *float64 `json:"value,omitempty"`   // Offending nutrient value per 100g
+}
+
+// CheckResult represents the verdict for a single food
+type CheckResult struct {
+	Food       food.Food   `json:"food"`
+	ProfileID  uuid.UUID   `json:"profile_id"`
+	Verdict    string      `json:"verdict"` // "safe", "caution" or "avoid"
+	Violations []Violation `json:"violations"`
 }
 
 // Service defines the interface for recommendation business logic
@@ -32,9 +32,95 @@ type DietaryPattern struct {
 	Recommendations []string `json:"recommendations"`
 }
 
+// FoodCategory is a node in the food category taxonomy. FoodTypes lists the
+// food_type values that belong directly to the category.
+type FoodCategory struct {
+	ID        int            `json:"id"`
+	Slug      string         `json:"slug"`
+	Name      string         `json:"name"`
+	ParentID  *int           `json:"parent_id,omitempty"`
+	FoodTypes []string       `json:"food_types"`
+	FoodCount int64          `json:"food_count"` // Foods in the category and its subcategories
+	Children  []FoodCategory `json:"children,omitempty"`
+}
+
+// FoodTypeCount is the number of foods with a food_type value
+type FoodTypeCount struct {
+	FoodType string `json:"food_type"`
+	Count    int64  `json:"count"`
+}
+
+// CategoryListing lists the food types in the catalog and the taxonomy with counts
+type CategoryListing struct {
+	FoodTypes []FoodTypeCount `json:"food_types"`
+	Taxonomy  []FoodCategory  `json:"taxonomy"`
+}
+
+// BuildCategoryTree nests a flat category list under their parents
+func BuildCategoryTree(categories []FoodCategory) []FoodCategory {
+	children := map[int][]FoodCategory{}
+	var roots []FoodCategory
+	for _, c := range categories {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var attach func(nodes []FoodCategory) []FoodCategory
+	attach = func(nodes []FoodCategory) []FoodCategory {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots)
+}
+
+// SubtreeFoodTypes returns the food types of a category and all its subcategories
+func (c FoodCategory) SubtreeFoodTypes() []string {
+	types := append([]string{}, c.FoodTypes...)
+	for _, child := range c.Children {
+		types = append(types, child.SubtreeFoodTypes()...)
+	}
+	return types
+}
+
+// CountCategoryFoods sets FoodCount on every category in a tree from the
+// number of foods per food type, counting each food type once per subtree
+func CountCategoryFoods(tree []FoodCategory, counts map[string]int64) {
+	for i := range tree {
+		CountCategoryFoods(tree[i].Children, counts)
+
+		seen := map[string]bool{}
+		tree[i].FoodCount = 0
+		for _, t := range tree[i].SubtreeFoodTypes() {
+			if !seen[t] {
+				seen[t] = true
+				tree[i].FoodCount += counts[t]
+			}
+		}
+	}
+}
+
+// FindCategory returns the category with the given slug anywhere in a tree
+func FindCategory(tree []FoodCategory, slug string) (FoodCategory, bool) {
+	for _, c := range tree {
+		if c.Slug == slug {
+			return c, true
+		}
+		if found, ok := FindCategory(c.Children, slug); ok {
+			return found, true
+		}
+	}
+	return FoodCategory{}, false
+}
+
 // Repository defines the interface for reference data access
 type Repository interface {
 	GetAllergens(ctx context.Context) ([]Allergen, error)
 	GetHealthConditions(ctx context.Context) ([]HealthCondition, error)
 	GetDietaryPatterns(ctx context.Context) ([]DietaryPattern, error)
+	GetFoodCategories(ctx context.Context) ([]FoodCategory, error)
 }
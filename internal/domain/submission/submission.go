@@ -0,0 +1,88 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// Submission statuses
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// Common errors
+var (
+	ErrNotPending       = errors.New("submission has already been reviewed")
+	ErrNotSubmitter     = errors.New("submission belongs to another user")
+	ErrBarcodeInCatalog = errors.New("a food with this barcode is already in the catalog")
+	ErrUnknownStatus    = errors.New("unknown submission status")
+)
+
+// Input is a food submitted by a user for inclusion in the catalog
+type Input struct {
+	Name          string             `json:"name" validate:"required,max=255"`
+	EAN13         string             `json:"ean_13,omitempty" validate:"omitempty,max=14"`
+	FoodType      string             `json:"food_type,omitempty" validate:"omitempty,max=50"`
+	Description   string             `json:"description,omitempty"`
+	Serving       food.Serving       `json:"serving,omitzero"`
+	Nutrition100g map[string]float64 `json:"nutrition_100g" validate:"required,min=1,dive,gte=0"`
+	Ingredients   string             `json:"ingredients,omitempty"`
+	Labels        []string           `json:"labels,omitempty"`
+}
+
+// Food converts the input into an uncatalogued food
+func (in Input) Food() *food.Food {
+	return &food.Food{
+		Name:          in.Name,
+		EAN13:         in.EAN13,
+		FoodType:      in.FoodType,
+		Description:   in.Description,
+		Serving:       in.Serving,
+		Nutrition100g: food.NutrientsFromMap(in.Nutrition100g),
+		Ingredients:   in.Ingredients,
+		Labels:        in.Labels,
+	}
+}
+
+// Submission is a user-submitted food and its review state. Food holds the
+// submitted data, as edited by moderators, and FoodID the catalog food created
+// on approval.
+type Submission struct {
+	ID              uuid.UUID  `json:"id"`
+	SubmitterID     uuid.UUID  `json:"submitter_id"`
+	Status          string     `json:"status"`
+	Food            *food.Food `json:"food"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	ReviewerID      *uuid.UUID `json:"reviewer_id,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	FoodID          string     `json:"food_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ValidStatus reports whether status is a known submission status
+func ValidStatus(status string) bool {
+	return status == StatusPending || status == StatusApproved || status == StatusRejected
+}
+
+// Repository defines the interface for submission data access
+type Repository interface {
+	Create(ctx context.Context, s *Submission) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Submission, error)
+	ListBySubmitter(ctx context.Context, submitterID uuid.UUID, status string, limit, offset int) ([]Submission, error)
+	CountBySubmitter(ctx context.Context, submitterID uuid.UUID, status string) (int64, error)
+	ListByStatus(ctx context.Context, status string, limit, offset int) ([]Submission, error)
+	CountByStatus(ctx context.Context, status string) (int64, error)
+	UpdateFood(ctx context.Context, s *Submission) error
+	Review(ctx context.Context, s *Submission) error
+	// Approve claims a pending submission and adds its food to the catalog
+	// with a create revision, all in one transaction. It returns
+	// sql.ErrNoRows when the submission has already been reviewed.
+	Approve(ctx context.Context, s *Submission, f *food.Food, rev *food.Revision) error
+}
@@ -8,6 +8,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// User roles
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator" // Can review user-submitted foods
+	RoleAdmin     = "admin"     // Can edit the food catalog and review submissions
+)
+
 // Common errors
 var (
 	ErrEmailUpdateNotAllowed = errors.New("email updates are not allowed for security reasons")
@@ -32,6 +39,8 @@ type User struct {
 	EmailVerified bool       `json:"email_verified"`
 	MFAEnabled    bool       `json:"mfa_enabled"`
 	MFASecret     string     `json:"-"`
+	Role          string     `json:"role"`
+	Locale        string     `json:"locale,omitempty"` // Preferred language for food names, overriding Accept-Language
 }
 
 // RegisterInput represents the input for user registration
@@ -51,6 +60,7 @@ type UpdateUserInput struct {
 	HeightCm      *float64   `json:"height_cm,omitempty" validate:"omitempty,gt=0"`
 	WeightKg      *float64   `json:"weight_kg,omitempty" validate:"omitempty,gt=0"`
 	ActivityLevel *string    `json:"activity_level,omitempty" validate:"omitempty,oneof=sedentary light moderate very_active"`
+	Locale        *string    `json:"locale,omitempty" validate:"omitempty,oneof=en fr de ak ee gaa"`
 }
 
 // Repository defines the interface for user data access
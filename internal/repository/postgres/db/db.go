@@ -24,18 +24,75 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addHouseholdMemberStmt, err = db.PrepareContext(ctx, addHouseholdMember); err != nil {
+		return nil, fmt.Errorf("error preparing query AddHouseholdMember: %w", err)
+	}
 	if q.checkProfileExistsStmt, err = db.PrepareContext(ctx, checkProfileExists); err != nil {
 		return nil, fmt.Errorf("error preparing query CheckProfileExists: %w", err)
 	}
+	if q.clearFoodImportStagingStmt, err = db.PrepareContext(ctx, clearFoodImportStaging); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFoodImportStaging: %w", err)
+	}
+	if q.countFilteredFoodsStmt, err = db.PrepareContext(ctx, countFilteredFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFilteredFoods: %w", err)
+	}
+	if q.countFoodRevisionsStmt, err = db.PrepareContext(ctx, countFoodRevisions); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFoodRevisions: %w", err)
+	}
+	if q.countFoodSubmissionsByStatusStmt, err = db.PrepareContext(ctx, countFoodSubmissionsByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFoodSubmissionsByStatus: %w", err)
+	}
+	if q.countFoodSubmissionsBySubmitterStmt, err = db.PrepareContext(ctx, countFoodSubmissionsBySubmitter); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFoodSubmissionsBySubmitter: %w", err)
+	}
 	if q.countFoodsStmt, err = db.PrepareContext(ctx, countFoods); err != nil {
 		return nil, fmt.Errorf("error preparing query CountFoods: %w", err)
 	}
+	if q.countFoodsByTypeStmt, err = db.PrepareContext(ctx, countFoodsByType); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFoodsByType: %w", err)
+	}
+	if q.countFoodsByTypesStmt, err = db.PrepareContext(ctx, countFoodsByTypes); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFoodsByTypes: %w", err)
+	}
+	if q.countFuzzySearchFoodsStmt, err = db.PrepareContext(ctx, countFuzzySearchFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFuzzySearchFoods: %w", err)
+	}
+	if q.countSearchFoodsStmt, err = db.PrepareContext(ctx, countSearchFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query CountSearchFoods: %w", err)
+	}
+	if q.countStagedFoodsStmt, err = db.PrepareContext(ctx, countStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query CountStagedFoods: %w", err)
+	}
+	if q.createBodyMeasurementStmt, err = db.PrepareContext(ctx, createBodyMeasurement); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBodyMeasurement: %w", err)
+	}
+	if q.createCalorieTargetAdjustmentStmt, err = db.PrepareContext(ctx, createCalorieTargetAdjustment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCalorieTargetAdjustment: %w", err)
+	}
 	if q.createFoodStmt, err = db.PrepareContext(ctx, createFood); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFood: %w", err)
 	}
 	if q.createFoodRatingStmt, err = db.PrepareContext(ctx, createFoodRating); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateFoodRating: %w", err)
 	}
+	if q.createFoodRedirectStmt, err = db.PrepareContext(ctx, createFoodRedirect); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFoodRedirect: %w", err)
+	}
+	if q.createFoodRevisionStmt, err = db.PrepareContext(ctx, createFoodRevision); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFoodRevision: %w", err)
+	}
+	if q.createFoodSubmissionStmt, err = db.PrepareContext(ctx, createFoodSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFoodSubmission: %w", err)
+	}
+	if q.createHouseholdStmt, err = db.PrepareContext(ctx, createHousehold); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateHousehold: %w", err)
+	}
+	if q.createHouseholdInvitationStmt, err = db.PrepareContext(ctx, createHouseholdInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateHouseholdInvitation: %w", err)
+	}
+	if q.createIntakeLogStmt, err = db.PrepareContext(ctx, createIntakeLog); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIntakeLog: %w", err)
+	}
 	if q.createRefreshTokenStmt, err = db.PrepareContext(ctx, createRefreshToken); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateRefreshToken: %w", err)
 	}
@@ -45,6 +102,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createUserProfileStmt, err = db.PrepareContext(ctx, createUserProfile); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUserProfile: %w", err)
 	}
+	if q.declareFoodExportCursorStmt, err = db.PrepareContext(ctx, declareFoodExportCursor); err != nil {
+		return nil, fmt.Errorf("error preparing query DeclareFoodExportCursor: %w", err)
+	}
+	if q.deleteBodyMeasurementStmt, err = db.PrepareContext(ctx, deleteBodyMeasurement); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBodyMeasurement: %w", err)
+	}
 	if q.deleteExpiredRefreshTokensStmt, err = db.PrepareContext(ctx, deleteExpiredRefreshTokens); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteExpiredRefreshTokens: %w", err)
 	}
@@ -54,15 +117,42 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteFoodRatingStmt, err = db.PrepareContext(ctx, deleteFoodRating); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteFoodRating: %w", err)
 	}
+	if q.deleteFoodTranslationStmt, err = db.PrepareContext(ctx, deleteFoodTranslation); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFoodTranslation: %w", err)
+	}
+	if q.deleteHouseholdStmt, err = db.PrepareContext(ctx, deleteHousehold); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteHousehold: %w", err)
+	}
+	if q.deleteIntakeLogStmt, err = db.PrepareContext(ctx, deleteIntakeLog); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIntakeLog: %w", err)
+	}
 	if q.deleteSavedFoodStmt, err = db.PrepareContext(ctx, deleteSavedFood); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteSavedFood: %w", err)
 	}
+	if q.deleteStagedFoodsAfterLineStmt, err = db.PrepareContext(ctx, deleteStagedFoodsAfterLine); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStagedFoodsAfterLine: %w", err)
+	}
 	if q.deleteUserStmt, err = db.PrepareContext(ctx, deleteUser); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteUser: %w", err)
 	}
 	if q.deleteUserProfileStmt, err = db.PrepareContext(ctx, deleteUserProfile); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteUserProfile: %w", err)
 	}
+	if q.facetFoodLabelsStmt, err = db.PrepareContext(ctx, facetFoodLabels); err != nil {
+		return nil, fmt.Errorf("error preparing query FacetFoodLabels: %w", err)
+	}
+	if q.facetFoodTypesStmt, err = db.PrepareContext(ctx, facetFoodTypes); err != nil {
+		return nil, fmt.Errorf("error preparing query FacetFoodTypes: %w", err)
+	}
+	if q.facetNutrientHistogramsStmt, err = db.PrepareContext(ctx, facetNutrientHistograms); err != nil {
+		return nil, fmt.Errorf("error preparing query FacetNutrientHistograms: %w", err)
+	}
+	if q.filterFoodsStmt, err = db.PrepareContext(ctx, filterFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query FilterFoods: %w", err)
+	}
+	if q.fuzzySearchFoodsStmt, err = db.PrepareContext(ctx, fuzzySearchFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query FuzzySearchFoods: %w", err)
+	}
 	if q.getDefaultUserProfileStmt, err = db.PrepareContext(ctx, getDefaultUserProfile); err != nil {
 		return nil, fmt.Errorf("error preparing query GetDefaultUserProfile: %w", err)
 	}
@@ -72,9 +162,33 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getFoodByIDStmt, err = db.PrepareContext(ctx, getFoodByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFoodByID: %w", err)
 	}
+	if q.getFoodNameSimilarityStmt, err = db.PrepareContext(ctx, getFoodNameSimilarity); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFoodNameSimilarity: %w", err)
+	}
 	if q.getFoodRatingStmt, err = db.PrepareContext(ctx, getFoodRating); err != nil {
 		return nil, fmt.Errorf("error preparing query GetFoodRating: %w", err)
 	}
+	if q.getFoodRedirectStmt, err = db.PrepareContext(ctx, getFoodRedirect); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFoodRedirect: %w", err)
+	}
+	if q.getFoodRevisionStmt, err = db.PrepareContext(ctx, getFoodRevision); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFoodRevision: %w", err)
+	}
+	if q.getFoodSearchRankStmt, err = db.PrepareContext(ctx, getFoodSearchRank); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFoodSearchRank: %w", err)
+	}
+	if q.getFoodSubmissionStmt, err = db.PrepareContext(ctx, getFoodSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFoodSubmission: %w", err)
+	}
+	if q.getHouseholdByIDStmt, err = db.PrepareContext(ctx, getHouseholdByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHouseholdByID: %w", err)
+	}
+	if q.getHouseholdInvitationStmt, err = db.PrepareContext(ctx, getHouseholdInvitation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetHouseholdInvitation: %w", err)
+	}
+	if q.getLatestCalorieTargetAdjustmentStmt, err = db.PrepareContext(ctx, getLatestCalorieTargetAdjustment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestCalorieTargetAdjustment: %w", err)
+	}
 	if q.getProfileByIDDirectStmt, err = db.PrepareContext(ctx, getProfileByIDDirect); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProfileByIDDirect: %w", err)
 	}
@@ -96,24 +210,135 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserProfilesStmt, err = db.PrepareContext(ctx, getUserProfiles); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserProfiles: %w", err)
 	}
+	if q.insertStagedFoodsStmt, err = db.PrepareContext(ctx, insertStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertStagedFoods: %w", err)
+	}
+	if q.keepAdminEditedStagedFoodsStmt, err = db.PrepareContext(ctx, keepAdminEditedStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query KeepAdminEditedStagedFoods: %w", err)
+	}
 	if q.listAllergensStmt, err = db.PrepareContext(ctx, listAllergens); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAllergens: %w", err)
 	}
+	if q.listBodyMeasurementsStmt, err = db.PrepareContext(ctx, listBodyMeasurements); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBodyMeasurements: %w", err)
+	}
+	if q.listCalorieTargetAdjustmentsStmt, err = db.PrepareContext(ctx, listCalorieTargetAdjustments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCalorieTargetAdjustments: %w", err)
+	}
+	if q.listDuplicateCandidatesStmt, err = db.PrepareContext(ctx, listDuplicateCandidates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDuplicateCandidates: %w", err)
+	}
+	if q.listFoodCategoriesStmt, err = db.PrepareContext(ctx, listFoodCategories); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFoodCategories: %w", err)
+	}
+	if q.listFoodNamesStmt, err = db.PrepareContext(ctx, listFoodNames); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFoodNames: %w", err)
+	}
+	if q.listFoodRevisionsStmt, err = db.PrepareContext(ctx, listFoodRevisions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFoodRevisions: %w", err)
+	}
+	if q.listFoodSubmissionsByStatusStmt, err = db.PrepareContext(ctx, listFoodSubmissionsByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFoodSubmissionsByStatus: %w", err)
+	}
+	if q.listFoodSubmissionsBySubmitterStmt, err = db.PrepareContext(ctx, listFoodSubmissionsBySubmitter); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFoodSubmissionsBySubmitter: %w", err)
+	}
+	if q.listFoodTranslationsStmt, err = db.PrepareContext(ctx, listFoodTranslations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFoodTranslations: %w", err)
+	}
 	if q.listFoodsStmt, err = db.PrepareContext(ctx, listFoods); err != nil {
 		return nil, fmt.Errorf("error preparing query ListFoods: %w", err)
 	}
 	if q.listFoodsByTypeStmt, err = db.PrepareContext(ctx, listFoodsByType); err != nil {
 		return nil, fmt.Errorf("error preparing query ListFoodsByType: %w", err)
 	}
+	if q.listFoodsByTypesStmt, err = db.PrepareContext(ctx, listFoodsByTypes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFoodsByTypes: %w", err)
+	}
 	if q.listHealthConditionsStmt, err = db.PrepareContext(ctx, listHealthConditions); err != nil {
 		return nil, fmt.Errorf("error preparing query ListHealthConditions: %w", err)
 	}
+	if q.listHouseholdMembersStmt, err = db.PrepareContext(ctx, listHouseholdMembers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseholdMembers: %w", err)
+	}
+	if q.listHouseholdsByUserStmt, err = db.PrepareContext(ctx, listHouseholdsByUser); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseholdsByUser: %w", err)
+	}
+	if q.listIntakeLogsByProfileStmt, err = db.PrepareContext(ctx, listIntakeLogsByProfile); err != nil {
+		return nil, fmt.Errorf("error preparing query ListIntakeLogsByProfile: %w", err)
+	}
+	if q.listPendingHouseholdInvitationsByEmailStmt, err = db.PrepareContext(ctx, listPendingHouseholdInvitationsByEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingHouseholdInvitationsByEmail: %w", err)
+	}
 	if q.listSavedFoodsStmt, err = db.PrepareContext(ctx, listSavedFoods); err != nil {
 		return nil, fmt.Errorf("error preparing query ListSavedFoods: %w", err)
 	}
+	if q.listTopFoodsByNutrientStmt, err = db.PrepareContext(ctx, listTopFoodsByNutrient); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTopFoodsByNutrient: %w", err)
+	}
+	if q.listTranslationsForFoodStmt, err = db.PrepareContext(ctx, listTranslationsForFood); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTranslationsForFood: %w", err)
+	}
+	if q.listUnclassifiedFoodsStmt, err = db.PrepareContext(ctx, listUnclassifiedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnclassifiedFoods: %w", err)
+	}
+	if q.listUngradedFoodsStmt, err = db.PrepareContext(ctx, listUngradedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUngradedFoods: %w", err)
+	}
+	if q.listUnparsedFoodsStmt, err = db.PrepareContext(ctx, listUnparsedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnparsedFoods: %w", err)
+	}
 	if q.listUserRatingsStmt, err = db.PrepareContext(ctx, listUserRatings); err != nil {
 		return nil, fmt.Errorf("error preparing query ListUserRatings: %w", err)
 	}
+	if q.markStagedFoodsImportedStmt, err = db.PrepareContext(ctx, markStagedFoodsImported); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkStagedFoodsImported: %w", err)
+	}
+	if q.moveFoodIntakeLogsStmt, err = db.PrepareContext(ctx, moveFoodIntakeLogs); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveFoodIntakeLogs: %w", err)
+	}
+	if q.moveFoodRatingsStmt, err = db.PrepareContext(ctx, moveFoodRatings); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveFoodRatings: %w", err)
+	}
+	if q.moveFoodRedirectsStmt, err = db.PrepareContext(ctx, moveFoodRedirects); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveFoodRedirects: %w", err)
+	}
+	if q.moveFoodSubmissionsStmt, err = db.PrepareContext(ctx, moveFoodSubmissions); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveFoodSubmissions: %w", err)
+	}
+	if q.moveFoodTranslationsStmt, err = db.PrepareContext(ctx, moveFoodTranslations); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveFoodTranslations: %w", err)
+	}
+	if q.moveSavedFoodsStmt, err = db.PrepareContext(ctx, moveSavedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveSavedFoods: %w", err)
+	}
+	if q.rejectDuplicateStagedFoodsStmt, err = db.PrepareContext(ctx, rejectDuplicateStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query RejectDuplicateStagedFoods: %w", err)
+	}
+	if q.rejectExistingStagedFoodsStmt, err = db.PrepareContext(ctx, rejectExistingStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query RejectExistingStagedFoods: %w", err)
+	}
+	if q.rejectKnownBarcodeStagedFoodsStmt, err = db.PrepareContext(ctx, rejectKnownBarcodeStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query RejectKnownBarcodeStagedFoods: %w", err)
+	}
+	if q.rejectMergedStagedFoodsStmt, err = db.PrepareContext(ctx, rejectMergedStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query RejectMergedStagedFoods: %w", err)
+	}
+	if q.removeHouseholdMemberStmt, err = db.PrepareContext(ctx, removeHouseholdMember); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveHouseholdMember: %w", err)
+	}
+	if q.restoreFoodStmt, err = db.PrepareContext(ctx, restoreFood); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreFood: %w", err)
+	}
+	if q.retireFoodStmt, err = db.PrepareContext(ctx, retireFood); err != nil {
+		return nil, fmt.Errorf("error preparing query RetireFood: %w", err)
+	}
+	if q.retireUnimportedFoodsStmt, err = db.PrepareContext(ctx, retireUnimportedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query RetireUnimportedFoods: %w", err)
+	}
+	if q.reviewFoodSubmissionStmt, err = db.PrepareContext(ctx, reviewFoodSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query ReviewFoodSubmission: %w", err)
+	}
 	if q.revokeAllUserRefreshTokensStmt, err = db.PrepareContext(ctx, revokeAllUserRefreshTokens); err != nil {
 		return nil, fmt.Errorf("error preparing query RevokeAllUserRefreshTokens: %w", err)
 	}
@@ -123,15 +348,48 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.saveFoodStmt, err = db.PrepareContext(ctx, saveFood); err != nil {
 		return nil, fmt.Errorf("error preparing query SaveFood: %w", err)
 	}
-	if q.searchFoodsByNameStmt, err = db.PrepareContext(ctx, searchFoodsByName); err != nil {
-		return nil, fmt.Errorf("error preparing query SearchFoodsByName: %w", err)
+	if q.searchFoodsStmt, err = db.PrepareContext(ctx, searchFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchFoods: %w", err)
 	}
 	if q.setProfileAsDefaultStmt, err = db.PrepareContext(ctx, setProfileAsDefault); err != nil {
 		return nil, fmt.Errorf("error preparing query SetProfileAsDefault: %w", err)
 	}
+	if q.setProfileCalorieTargetStmt, err = db.PrepareContext(ctx, setProfileCalorieTarget); err != nil {
+		return nil, fmt.Errorf("error preparing query SetProfileCalorieTarget: %w", err)
+	}
+	if q.summarizeStagedFoodsStmt, err = db.PrepareContext(ctx, summarizeStagedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query SummarizeStagedFoods: %w", err)
+	}
+	if q.tryLockFoodImportStmt, err = db.PrepareContext(ctx, tryLockFoodImport); err != nil {
+		return nil, fmt.Errorf("error preparing query TryLockFoodImport: %w", err)
+	}
+	if q.unlockFoodImportStmt, err = db.PrepareContext(ctx, unlockFoodImport); err != nil {
+		return nil, fmt.Errorf("error preparing query UnlockFoodImport: %w", err)
+	}
+	if q.updateChangedFoodsStmt, err = db.PrepareContext(ctx, updateChangedFoods); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateChangedFoods: %w", err)
+	}
+	if q.updateFoodStmt, err = db.PrepareContext(ctx, updateFood); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFood: %w", err)
+	}
+	if q.updateFoodIngredientTreeStmt, err = db.PrepareContext(ctx, updateFoodIngredientTree); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFoodIngredientTree: %w", err)
+	}
+	if q.updateFoodNovaStmt, err = db.PrepareContext(ctx, updateFoodNova); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFoodNova: %w", err)
+	}
+	if q.updateFoodNutriScoreStmt, err = db.PrepareContext(ctx, updateFoodNutriScore); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFoodNutriScore: %w", err)
+	}
 	if q.updateFoodRatingStmt, err = db.PrepareContext(ctx, updateFoodRating); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateFoodRating: %w", err)
 	}
+	if q.updateFoodSubmissionFoodStmt, err = db.PrepareContext(ctx, updateFoodSubmissionFood); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateFoodSubmissionFood: %w", err)
+	}
+	if q.updateHouseholdInvitationStatusStmt, err = db.PrepareContext(ctx, updateHouseholdInvitationStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateHouseholdInvitationStatus: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
@@ -147,21 +405,92 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateUserProfileStmt, err = db.PrepareContext(ctx, updateUserProfile); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUserProfile: %w", err)
 	}
+	if q.upsertFoodTranslationStmt, err = db.PrepareContext(ctx, upsertFoodTranslation); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertFoodTranslation: %w", err)
+	}
+	if q.upsertStagedTranslationsStmt, err = db.PrepareContext(ctx, upsertStagedTranslations); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertStagedTranslations: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addHouseholdMemberStmt != nil {
+		if cerr := q.addHouseholdMemberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addHouseholdMemberStmt: %w", cerr)
+		}
+	}
 	if q.checkProfileExistsStmt != nil {
 		if cerr := q.checkProfileExistsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing checkProfileExistsStmt: %w", cerr)
 		}
 	}
+	if q.clearFoodImportStagingStmt != nil {
+		if cerr := q.clearFoodImportStagingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFoodImportStagingStmt: %w", cerr)
+		}
+	}
+	if q.countFilteredFoodsStmt != nil {
+		if cerr := q.countFilteredFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFilteredFoodsStmt: %w", cerr)
+		}
+	}
+	if q.countFoodRevisionsStmt != nil {
+		if cerr := q.countFoodRevisionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFoodRevisionsStmt: %w", cerr)
+		}
+	}
+	if q.countFoodSubmissionsByStatusStmt != nil {
+		if cerr := q.countFoodSubmissionsByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFoodSubmissionsByStatusStmt: %w", cerr)
+		}
+	}
+	if q.countFoodSubmissionsBySubmitterStmt != nil {
+		if cerr := q.countFoodSubmissionsBySubmitterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFoodSubmissionsBySubmitterStmt: %w", cerr)
+		}
+	}
 	if q.countFoodsStmt != nil {
 		if cerr := q.countFoodsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countFoodsStmt: %w", cerr)
 		}
 	}
+	if q.countFoodsByTypeStmt != nil {
+		if cerr := q.countFoodsByTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFoodsByTypeStmt: %w", cerr)
+		}
+	}
+	if q.countFoodsByTypesStmt != nil {
+		if cerr := q.countFoodsByTypesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFoodsByTypesStmt: %w", cerr)
+		}
+	}
+	if q.countFuzzySearchFoodsStmt != nil {
+		if cerr := q.countFuzzySearchFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFuzzySearchFoodsStmt: %w", cerr)
+		}
+	}
+	if q.countSearchFoodsStmt != nil {
+		if cerr := q.countSearchFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countSearchFoodsStmt: %w", cerr)
+		}
+	}
+	if q.countStagedFoodsStmt != nil {
+		if cerr := q.countStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countStagedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.createBodyMeasurementStmt != nil {
+		if cerr := q.createBodyMeasurementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBodyMeasurementStmt: %w", cerr)
+		}
+	}
+	if q.createCalorieTargetAdjustmentStmt != nil {
+		if cerr := q.createCalorieTargetAdjustmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCalorieTargetAdjustmentStmt: %w", cerr)
+		}
+	}
 	if q.createFoodStmt != nil {
 		if cerr := q.createFoodStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createFoodStmt: %w", cerr)
@@ -172,6 +501,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createFoodRatingStmt: %w", cerr)
 		}
 	}
+	if q.createFoodRedirectStmt != nil {
+		if cerr := q.createFoodRedirectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFoodRedirectStmt: %w", cerr)
+		}
+	}
+	if q.createFoodRevisionStmt != nil {
+		if cerr := q.createFoodRevisionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFoodRevisionStmt: %w", cerr)
+		}
+	}
+	if q.createFoodSubmissionStmt != nil {
+		if cerr := q.createFoodSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFoodSubmissionStmt: %w", cerr)
+		}
+	}
+	if q.createHouseholdStmt != nil {
+		if cerr := q.createHouseholdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createHouseholdStmt: %w", cerr)
+		}
+	}
+	if q.createHouseholdInvitationStmt != nil {
+		if cerr := q.createHouseholdInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createHouseholdInvitationStmt: %w", cerr)
+		}
+	}
+	if q.createIntakeLogStmt != nil {
+		if cerr := q.createIntakeLogStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIntakeLogStmt: %w", cerr)
+		}
+	}
 	if q.createRefreshTokenStmt != nil {
 		if cerr := q.createRefreshTokenStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createRefreshTokenStmt: %w", cerr)
@@ -187,6 +546,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createUserProfileStmt: %w", cerr)
 		}
 	}
+	if q.declareFoodExportCursorStmt != nil {
+		if cerr := q.declareFoodExportCursorStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing declareFoodExportCursorStmt: %w", cerr)
+		}
+	}
+	if q.deleteBodyMeasurementStmt != nil {
+		if cerr := q.deleteBodyMeasurementStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBodyMeasurementStmt: %w", cerr)
+		}
+	}
 	if q.deleteExpiredRefreshTokensStmt != nil {
 		if cerr := q.deleteExpiredRefreshTokensStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteExpiredRefreshTokensStmt: %w", cerr)
@@ -202,11 +571,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteFoodRatingStmt: %w", cerr)
 		}
 	}
+	if q.deleteFoodTranslationStmt != nil {
+		if cerr := q.deleteFoodTranslationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFoodTranslationStmt: %w", cerr)
+		}
+	}
+	if q.deleteHouseholdStmt != nil {
+		if cerr := q.deleteHouseholdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteHouseholdStmt: %w", cerr)
+		}
+	}
+	if q.deleteIntakeLogStmt != nil {
+		if cerr := q.deleteIntakeLogStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIntakeLogStmt: %w", cerr)
+		}
+	}
 	if q.deleteSavedFoodStmt != nil {
 		if cerr := q.deleteSavedFoodStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteSavedFoodStmt: %w", cerr)
 		}
 	}
+	if q.deleteStagedFoodsAfterLineStmt != nil {
+		if cerr := q.deleteStagedFoodsAfterLineStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStagedFoodsAfterLineStmt: %w", cerr)
+		}
+	}
 	if q.deleteUserStmt != nil {
 		if cerr := q.deleteUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteUserStmt: %w", cerr)
@@ -217,6 +606,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteUserProfileStmt: %w", cerr)
 		}
 	}
+	if q.facetFoodLabelsStmt != nil {
+		if cerr := q.facetFoodLabelsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing facetFoodLabelsStmt: %w", cerr)
+		}
+	}
+	if q.facetFoodTypesStmt != nil {
+		if cerr := q.facetFoodTypesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing facetFoodTypesStmt: %w", cerr)
+		}
+	}
+	if q.facetNutrientHistogramsStmt != nil {
+		if cerr := q.facetNutrientHistogramsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing facetNutrientHistogramsStmt: %w", cerr)
+		}
+	}
+	if q.filterFoodsStmt != nil {
+		if cerr := q.filterFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing filterFoodsStmt: %w", cerr)
+		}
+	}
+	if q.fuzzySearchFoodsStmt != nil {
+		if cerr := q.fuzzySearchFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing fuzzySearchFoodsStmt: %w", cerr)
+		}
+	}
 	if q.getDefaultUserProfileStmt != nil {
 		if cerr := q.getDefaultUserProfileStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getDefaultUserProfileStmt: %w", cerr)
@@ -232,11 +646,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getFoodByIDStmt: %w", cerr)
 		}
 	}
+	if q.getFoodNameSimilarityStmt != nil {
+		if cerr := q.getFoodNameSimilarityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFoodNameSimilarityStmt: %w", cerr)
+		}
+	}
 	if q.getFoodRatingStmt != nil {
 		if cerr := q.getFoodRatingStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getFoodRatingStmt: %w", cerr)
 		}
 	}
+	if q.getFoodRedirectStmt != nil {
+		if cerr := q.getFoodRedirectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFoodRedirectStmt: %w", cerr)
+		}
+	}
+	if q.getFoodRevisionStmt != nil {
+		if cerr := q.getFoodRevisionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFoodRevisionStmt: %w", cerr)
+		}
+	}
+	if q.getFoodSearchRankStmt != nil {
+		if cerr := q.getFoodSearchRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFoodSearchRankStmt: %w", cerr)
+		}
+	}
+	if q.getFoodSubmissionStmt != nil {
+		if cerr := q.getFoodSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFoodSubmissionStmt: %w", cerr)
+		}
+	}
+	if q.getHouseholdByIDStmt != nil {
+		if cerr := q.getHouseholdByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHouseholdByIDStmt: %w", cerr)
+		}
+	}
+	if q.getHouseholdInvitationStmt != nil {
+		if cerr := q.getHouseholdInvitationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getHouseholdInvitationStmt: %w", cerr)
+		}
+	}
+	if q.getLatestCalorieTargetAdjustmentStmt != nil {
+		if cerr := q.getLatestCalorieTargetAdjustmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestCalorieTargetAdjustmentStmt: %w", cerr)
+		}
+	}
 	if q.getProfileByIDDirectStmt != nil {
 		if cerr := q.getProfileByIDDirectStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getProfileByIDDirectStmt: %w", cerr)
@@ -272,11 +726,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserProfilesStmt: %w", cerr)
 		}
 	}
+	if q.insertStagedFoodsStmt != nil {
+		if cerr := q.insertStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertStagedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.keepAdminEditedStagedFoodsStmt != nil {
+		if cerr := q.keepAdminEditedStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing keepAdminEditedStagedFoodsStmt: %w", cerr)
+		}
+	}
 	if q.listAllergensStmt != nil {
 		if cerr := q.listAllergensStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listAllergensStmt: %w", cerr)
 		}
 	}
+	if q.listBodyMeasurementsStmt != nil {
+		if cerr := q.listBodyMeasurementsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBodyMeasurementsStmt: %w", cerr)
+		}
+	}
+	if q.listCalorieTargetAdjustmentsStmt != nil {
+		if cerr := q.listCalorieTargetAdjustmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCalorieTargetAdjustmentsStmt: %w", cerr)
+		}
+	}
+	if q.listDuplicateCandidatesStmt != nil {
+		if cerr := q.listDuplicateCandidatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDuplicateCandidatesStmt: %w", cerr)
+		}
+	}
+	if q.listFoodCategoriesStmt != nil {
+		if cerr := q.listFoodCategoriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFoodCategoriesStmt: %w", cerr)
+		}
+	}
+	if q.listFoodNamesStmt != nil {
+		if cerr := q.listFoodNamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFoodNamesStmt: %w", cerr)
+		}
+	}
+	if q.listFoodRevisionsStmt != nil {
+		if cerr := q.listFoodRevisionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFoodRevisionsStmt: %w", cerr)
+		}
+	}
+	if q.listFoodSubmissionsByStatusStmt != nil {
+		if cerr := q.listFoodSubmissionsByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFoodSubmissionsByStatusStmt: %w", cerr)
+		}
+	}
+	if q.listFoodSubmissionsBySubmitterStmt != nil {
+		if cerr := q.listFoodSubmissionsBySubmitterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFoodSubmissionsBySubmitterStmt: %w", cerr)
+		}
+	}
+	if q.listFoodTranslationsStmt != nil {
+		if cerr := q.listFoodTranslationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFoodTranslationsStmt: %w", cerr)
+		}
+	}
 	if q.listFoodsStmt != nil {
 		if cerr := q.listFoodsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listFoodsStmt: %w", cerr)
@@ -287,21 +796,151 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listFoodsByTypeStmt: %w", cerr)
 		}
 	}
+	if q.listFoodsByTypesStmt != nil {
+		if cerr := q.listFoodsByTypesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFoodsByTypesStmt: %w", cerr)
+		}
+	}
 	if q.listHealthConditionsStmt != nil {
 		if cerr := q.listHealthConditionsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listHealthConditionsStmt: %w", cerr)
 		}
 	}
+	if q.listHouseholdMembersStmt != nil {
+		if cerr := q.listHouseholdMembersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseholdMembersStmt: %w", cerr)
+		}
+	}
+	if q.listHouseholdsByUserStmt != nil {
+		if cerr := q.listHouseholdsByUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseholdsByUserStmt: %w", cerr)
+		}
+	}
+	if q.listIntakeLogsByProfileStmt != nil {
+		if cerr := q.listIntakeLogsByProfileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listIntakeLogsByProfileStmt: %w", cerr)
+		}
+	}
+	if q.listPendingHouseholdInvitationsByEmailStmt != nil {
+		if cerr := q.listPendingHouseholdInvitationsByEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingHouseholdInvitationsByEmailStmt: %w", cerr)
+		}
+	}
 	if q.listSavedFoodsStmt != nil {
 		if cerr := q.listSavedFoodsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listSavedFoodsStmt: %w", cerr)
 		}
 	}
+	if q.listTopFoodsByNutrientStmt != nil {
+		if cerr := q.listTopFoodsByNutrientStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTopFoodsByNutrientStmt: %w", cerr)
+		}
+	}
+	if q.listTranslationsForFoodStmt != nil {
+		if cerr := q.listTranslationsForFoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTranslationsForFoodStmt: %w", cerr)
+		}
+	}
+	if q.listUnclassifiedFoodsStmt != nil {
+		if cerr := q.listUnclassifiedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnclassifiedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.listUngradedFoodsStmt != nil {
+		if cerr := q.listUngradedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUngradedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.listUnparsedFoodsStmt != nil {
+		if cerr := q.listUnparsedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnparsedFoodsStmt: %w", cerr)
+		}
+	}
 	if q.listUserRatingsStmt != nil {
 		if cerr := q.listUserRatingsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listUserRatingsStmt: %w", cerr)
 		}
 	}
+	if q.markStagedFoodsImportedStmt != nil {
+		if cerr := q.markStagedFoodsImportedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markStagedFoodsImportedStmt: %w", cerr)
+		}
+	}
+	if q.moveFoodIntakeLogsStmt != nil {
+		if cerr := q.moveFoodIntakeLogsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveFoodIntakeLogsStmt: %w", cerr)
+		}
+	}
+	if q.moveFoodRatingsStmt != nil {
+		if cerr := q.moveFoodRatingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveFoodRatingsStmt: %w", cerr)
+		}
+	}
+	if q.moveFoodRedirectsStmt != nil {
+		if cerr := q.moveFoodRedirectsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveFoodRedirectsStmt: %w", cerr)
+		}
+	}
+	if q.moveFoodSubmissionsStmt != nil {
+		if cerr := q.moveFoodSubmissionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveFoodSubmissionsStmt: %w", cerr)
+		}
+	}
+	if q.moveFoodTranslationsStmt != nil {
+		if cerr := q.moveFoodTranslationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveFoodTranslationsStmt: %w", cerr)
+		}
+	}
+	if q.moveSavedFoodsStmt != nil {
+		if cerr := q.moveSavedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveSavedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.rejectDuplicateStagedFoodsStmt != nil {
+		if cerr := q.rejectDuplicateStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rejectDuplicateStagedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.rejectExistingStagedFoodsStmt != nil {
+		if cerr := q.rejectExistingStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rejectExistingStagedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.rejectKnownBarcodeStagedFoodsStmt != nil {
+		if cerr := q.rejectKnownBarcodeStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rejectKnownBarcodeStagedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.rejectMergedStagedFoodsStmt != nil {
+		if cerr := q.rejectMergedStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rejectMergedStagedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.removeHouseholdMemberStmt != nil {
+		if cerr := q.removeHouseholdMemberStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeHouseholdMemberStmt: %w", cerr)
+		}
+	}
+	if q.restoreFoodStmt != nil {
+		if cerr := q.restoreFoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreFoodStmt: %w", cerr)
+		}
+	}
+	if q.retireFoodStmt != nil {
+		if cerr := q.retireFoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retireFoodStmt: %w", cerr)
+		}
+	}
+	if q.retireUnimportedFoodsStmt != nil {
+		if cerr := q.retireUnimportedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing retireUnimportedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.reviewFoodSubmissionStmt != nil {
+		if cerr := q.reviewFoodSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reviewFoodSubmissionStmt: %w", cerr)
+		}
+	}
 	if q.revokeAllUserRefreshTokensStmt != nil {
 		if cerr := q.revokeAllUserRefreshTokensStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing revokeAllUserRefreshTokensStmt: %w", cerr)
@@ -317,9 +956,9 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing saveFoodStmt: %w", cerr)
 		}
 	}
-	if q.searchFoodsByNameStmt != nil {
-		if cerr := q.searchFoodsByNameStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing searchFoodsByNameStmt: %w", cerr)
+	if q.searchFoodsStmt != nil {
+		if cerr := q.searchFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchFoodsStmt: %w", cerr)
 		}
 	}
 	if q.setProfileAsDefaultStmt != nil {
@@ -327,11 +966,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing setProfileAsDefaultStmt: %w", cerr)
 		}
 	}
+	if q.setProfileCalorieTargetStmt != nil {
+		if cerr := q.setProfileCalorieTargetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setProfileCalorieTargetStmt: %w", cerr)
+		}
+	}
+	if q.summarizeStagedFoodsStmt != nil {
+		if cerr := q.summarizeStagedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing summarizeStagedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.tryLockFoodImportStmt != nil {
+		if cerr := q.tryLockFoodImportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing tryLockFoodImportStmt: %w", cerr)
+		}
+	}
+	if q.unlockFoodImportStmt != nil {
+		if cerr := q.unlockFoodImportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unlockFoodImportStmt: %w", cerr)
+		}
+	}
+	if q.updateChangedFoodsStmt != nil {
+		if cerr := q.updateChangedFoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateChangedFoodsStmt: %w", cerr)
+		}
+	}
+	if q.updateFoodStmt != nil {
+		if cerr := q.updateFoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFoodStmt: %w", cerr)
+		}
+	}
+	if q.updateFoodIngredientTreeStmt != nil {
+		if cerr := q.updateFoodIngredientTreeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFoodIngredientTreeStmt: %w", cerr)
+		}
+	}
+	if q.updateFoodNovaStmt != nil {
+		if cerr := q.updateFoodNovaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFoodNovaStmt: %w", cerr)
+		}
+	}
+	if q.updateFoodNutriScoreStmt != nil {
+		if cerr := q.updateFoodNutriScoreStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFoodNutriScoreStmt: %w", cerr)
+		}
+	}
 	if q.updateFoodRatingStmt != nil {
 		if cerr := q.updateFoodRatingStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateFoodRatingStmt: %w", cerr)
 		}
 	}
+	if q.updateFoodSubmissionFoodStmt != nil {
+		if cerr := q.updateFoodSubmissionFoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateFoodSubmissionFoodStmt: %w", cerr)
+		}
+	}
+	if q.updateHouseholdInvitationStatusStmt != nil {
+		if cerr := q.updateHouseholdInvitationStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateHouseholdInvitationStatusStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
@@ -357,6 +1051,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateUserProfileStmt: %w", cerr)
 		}
 	}
+	if q.upsertFoodTranslationStmt != nil {
+		if cerr := q.upsertFoodTranslationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertFoodTranslationStmt: %w", cerr)
+		}
+	}
+	if q.upsertStagedTranslationsStmt != nil {
+		if cerr := q.upsertStagedTranslationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertStagedTranslationsStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -394,95 +1098,271 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                              DBTX
-	tx                              *sql.Tx
-	checkProfileExistsStmt          *sql.Stmt
-	countFoodsStmt                  *sql.Stmt
-	createFoodStmt                  *sql.Stmt
-	createFoodRatingStmt            *sql.Stmt
-	createRefreshTokenStmt          *sql.Stmt
-	createUserStmt                  *sql.Stmt
-	createUserProfileStmt           *sql.Stmt
-	deleteExpiredRefreshTokensStmt  *sql.Stmt
-	deleteFoodStmt                  *sql.Stmt
-	deleteFoodRatingStmt            *sql.Stmt
-	deleteSavedFoodStmt             *sql.Stmt
-	deleteUserStmt                  *sql.Stmt
-	deleteUserProfileStmt           *sql.Stmt
-	getDefaultUserProfileStmt       *sql.Stmt
-	getFoodByEAN13Stmt              *sql.Stmt
-	getFoodByIDStmt                 *sql.Stmt
-	getFoodRatingStmt               *sql.Stmt
-	getProfileByIDDirectStmt        *sql.Stmt
-	getRefreshTokenStmt             *sql.Stmt
-	getSavedFoodStmt                *sql.Stmt
-	getUserByEmailStmt              *sql.Stmt
-	getUserByIDStmt                 *sql.Stmt
-	getUserProfileByIDStmt          *sql.Stmt
-	getUserProfilesStmt             *sql.Stmt
-	listAllergensStmt               *sql.Stmt
-	listFoodsStmt                   *sql.Stmt
-	listFoodsByTypeStmt             *sql.Stmt
-	listHealthConditionsStmt        *sql.Stmt
-	listSavedFoodsStmt              *sql.Stmt
-	listUserRatingsStmt             *sql.Stmt
-	revokeAllUserRefreshTokensStmt  *sql.Stmt
-	revokeRefreshTokenStmt          *sql.Stmt
-	saveFoodStmt                    *sql.Stmt
-	searchFoodsByNameStmt           *sql.Stmt
-	setProfileAsDefaultStmt         *sql.Stmt
-	updateFoodRatingStmt            *sql.Stmt
-	updateUserStmt                  *sql.Stmt
-	updateUserEmailVerificationStmt *sql.Stmt
-	updateUserLastLoginStmt         *sql.Stmt
-	updateUserPasswordStmt          *sql.Stmt
-	updateUserProfileStmt           *sql.Stmt
+	db                                         DBTX
+	tx                                         *sql.Tx
+	addHouseholdMemberStmt                     *sql.Stmt
+	checkProfileExistsStmt                     *sql.Stmt
+	clearFoodImportStagingStmt                 *sql.Stmt
+	countFilteredFoodsStmt                     *sql.Stmt
+	countFoodRevisionsStmt                     *sql.Stmt
+	countFoodSubmissionsByStatusStmt           *sql.Stmt
+	countFoodSubmissionsBySubmitterStmt        *sql.Stmt
+	countFoodsStmt                             *sql.Stmt
+	countFoodsByTypeStmt                       *sql.Stmt
+	countFoodsByTypesStmt                      *sql.Stmt
+	countFuzzySearchFoodsStmt                  *sql.Stmt
+	countSearchFoodsStmt                       *sql.Stmt
+	countStagedFoodsStmt                       *sql.Stmt
+	createBodyMeasurementStmt                  *sql.Stmt
+	createCalorieTargetAdjustmentStmt          *sql.Stmt
+	createFoodStmt                             *sql.Stmt
+	createFoodRatingStmt                       *sql.Stmt
+	createFoodRedirectStmt                     *sql.Stmt
+	createFoodRevisionStmt                     *sql.Stmt
+	createFoodSubmissionStmt                   *sql.Stmt
+	createHouseholdStmt                        *sql.Stmt
+	createHouseholdInvitationStmt              *sql.Stmt
+	createIntakeLogStmt                        *sql.Stmt
+	createRefreshTokenStmt                     *sql.Stmt
+	createUserStmt                             *sql.Stmt
+	createUserProfileStmt                      *sql.Stmt
+	declareFoodExportCursorStmt                *sql.Stmt
+	deleteBodyMeasurementStmt                  *sql.Stmt
+	deleteExpiredRefreshTokensStmt             *sql.Stmt
+	deleteFoodStmt                             *sql.Stmt
+	deleteFoodRatingStmt                       *sql.Stmt
+	deleteFoodTranslationStmt                  *sql.Stmt
+	deleteHouseholdStmt                        *sql.Stmt
+	deleteIntakeLogStmt                        *sql.Stmt
+	deleteSavedFoodStmt                        *sql.Stmt
+	deleteStagedFoodsAfterLineStmt             *sql.Stmt
+	deleteUserStmt                             *sql.Stmt
+	deleteUserProfileStmt                      *sql.Stmt
+	facetFoodLabelsStmt                        *sql.Stmt
+	facetFoodTypesStmt                         *sql.Stmt
+	facetNutrientHistogramsStmt                *sql.Stmt
+	filterFoodsStmt                            *sql.Stmt
+	fuzzySearchFoodsStmt                       *sql.Stmt
+	getDefaultUserProfileStmt                  *sql.Stmt
+	getFoodByEAN13Stmt                         *sql.Stmt
+	getFoodByIDStmt                            *sql.Stmt
+	getFoodNameSimilarityStmt                  *sql.Stmt
+	getFoodRatingStmt                          *sql.Stmt
+	getFoodRedirectStmt                        *sql.Stmt
+	getFoodRevisionStmt                        *sql.Stmt
+	getFoodSearchRankStmt                      *sql.Stmt
+	getFoodSubmissionStmt                      *sql.Stmt
+	getHouseholdByIDStmt                       *sql.Stmt
+	getHouseholdInvitationStmt                 *sql.Stmt
+	getLatestCalorieTargetAdjustmentStmt       *sql.Stmt
+	getProfileByIDDirectStmt                   *sql.Stmt
+	getRefreshTokenStmt                        *sql.Stmt
+	getSavedFoodStmt                           *sql.Stmt
+	getUserByEmailStmt                         *sql.Stmt
+	getUserByIDStmt                            *sql.Stmt
+	getUserProfileByIDStmt                     *sql.Stmt
+	getUserProfilesStmt                        *sql.Stmt
+	insertStagedFoodsStmt                      *sql.Stmt
+	keepAdminEditedStagedFoodsStmt             *sql.Stmt
+	listAllergensStmt                          *sql.Stmt
+	listBodyMeasurementsStmt                   *sql.Stmt
+	listCalorieTargetAdjustmentsStmt           *sql.Stmt
+	listDuplicateCandidatesStmt                *sql.Stmt
+	listFoodCategoriesStmt                     *sql.Stmt
+	listFoodNamesStmt                          *sql.Stmt
+	listFoodRevisionsStmt                      *sql.Stmt
+	listFoodSubmissionsByStatusStmt            *sql.Stmt
+	listFoodSubmissionsBySubmitterStmt         *sql.Stmt
+	listFoodTranslationsStmt                   *sql.Stmt
+	listFoodsStmt                              *sql.Stmt
+	listFoodsByTypeStmt                        *sql.Stmt
+	listFoodsByTypesStmt                       *sql.Stmt
+	listHealthConditionsStmt                   *sql.Stmt
+	listHouseholdMembersStmt                   *sql.Stmt
+	listHouseholdsByUserStmt                   *sql.Stmt
+	listIntakeLogsByProfileStmt                *sql.Stmt
+	listPendingHouseholdInvitationsByEmailStmt *sql.Stmt
+	listSavedFoodsStmt                         *sql.Stmt
+	listTopFoodsByNutrientStmt                 *sql.Stmt
+	listTranslationsForFoodStmt                *sql.Stmt
+	listUnclassifiedFoodsStmt                  *sql.Stmt
+	listUngradedFoodsStmt                      *sql.Stmt
+	listUnparsedFoodsStmt                      *sql.Stmt
+	listUserRatingsStmt                        *sql.Stmt
+	markStagedFoodsImportedStmt                *sql.Stmt
+	moveFoodIntakeLogsStmt                     *sql.Stmt
+	moveFoodRatingsStmt                        *sql.Stmt
+	moveFoodRedirectsStmt                      *sql.Stmt
+	moveFoodSubmissionsStmt                    *sql.Stmt
+	moveFoodTranslationsStmt                   *sql.Stmt
+	moveSavedFoodsStmt                         *sql.Stmt
+	rejectDuplicateStagedFoodsStmt             *sql.Stmt
+	rejectExistingStagedFoodsStmt              *sql.Stmt
+	rejectKnownBarcodeStagedFoodsStmt          *sql.Stmt
+	rejectMergedStagedFoodsStmt                *sql.Stmt
+	removeHouseholdMemberStmt                  *sql.Stmt
+	restoreFoodStmt                            *sql.Stmt
+	retireFoodStmt                             *sql.Stmt
+	retireUnimportedFoodsStmt                  *sql.Stmt
+	reviewFoodSubmissionStmt                   *sql.Stmt
+	revokeAllUserRefreshTokensStmt             *sql.Stmt
+	revokeRefreshTokenStmt                     *sql.Stmt
+	saveFoodStmt                               *sql.Stmt
+	searchFoodsStmt                            *sql.Stmt
+	setProfileAsDefaultStmt                    *sql.Stmt
+	setProfileCalorieTargetStmt                *sql.Stmt
+	summarizeStagedFoodsStmt                   *sql.Stmt
+	tryLockFoodImportStmt                      *sql.Stmt
+	unlockFoodImportStmt                       *sql.Stmt
+	updateChangedFoodsStmt                     *sql.Stmt
+	updateFoodStmt                             *sql.Stmt
+	updateFoodIngredientTreeStmt               *sql.Stmt
+	updateFoodNovaStmt                         *sql.Stmt
+	updateFoodNutriScoreStmt                   *sql.Stmt
+	updateFoodRatingStmt                       *sql.Stmt
+	updateFoodSubmissionFoodStmt               *sql.Stmt
+	updateHouseholdInvitationStatusStmt        *sql.Stmt
+	updateUserStmt                             *sql.Stmt
+	updateUserEmailVerificationStmt            *sql.Stmt
+	updateUserLastLoginStmt                    *sql.Stmt
+	updateUserPasswordStmt                     *sql.Stmt
+	updateUserProfileStmt                      *sql.Stmt
+	upsertFoodTranslationStmt                  *sql.Stmt
+	upsertStagedTranslationsStmt               *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                              tx,
-		tx:                              tx,
-		checkProfileExistsStmt:          q.checkProfileExistsStmt,
-		countFoodsStmt:                  q.countFoodsStmt,
-		createFoodStmt:                  q.createFoodStmt,
-		createFoodRatingStmt:            q.createFoodRatingStmt,
-		createRefreshTokenStmt:          q.createRefreshTokenStmt,
-		createUserStmt:                  q.createUserStmt,
-		createUserProfileStmt:           q.createUserProfileStmt,
-		deleteExpiredRefreshTokensStmt:  q.deleteExpiredRefreshTokensStmt,
-		deleteFoodStmt:                  q.deleteFoodStmt,
-		deleteFoodRatingStmt:            q.deleteFoodRatingStmt,
-		deleteSavedFoodStmt:             q.deleteSavedFoodStmt,
-		deleteUserStmt:                  q.deleteUserStmt,
-		deleteUserProfileStmt:           q.deleteUserProfileStmt,
-		getDefaultUserProfileStmt:       q.getDefaultUserProfileStmt,
-		getFoodByEAN13Stmt:              q.getFoodByEAN13Stmt,
-		getFoodByIDStmt:                 q.getFoodByIDStmt,
-		getFoodRatingStmt:               q.getFoodRatingStmt,
-		getProfileByIDDirectStmt:        q.getProfileByIDDirectStmt,
-		getRefreshTokenStmt:             q.getRefreshTokenStmt,
-		getSavedFoodStmt:                q.getSavedFoodStmt,
-		getUserByEmailStmt:              q.getUserByEmailStmt,
-		getUserByIDStmt:                 q.getUserByIDStmt,
-		getUserProfileByIDStmt:          q.getUserProfileByIDStmt,
-		getUserProfilesStmt:             q.getUserProfilesStmt,
-		listAllergensStmt:               q.listAllergensStmt,
-		listFoodsStmt:                   q.listFoodsStmt,
-		listFoodsByTypeStmt:             q.listFoodsByTypeStmt,
-		listHealthConditionsStmt:        q.listHealthConditionsStmt,
-		listSavedFoodsStmt:              q.listSavedFoodsStmt,
-		listUserRatingsStmt:             q.listUserRatingsStmt,
-		revokeAllUserRefreshTokensStmt:  q.revokeAllUserRefreshTokensStmt,
-		revokeRefreshTokenStmt:          q.revokeRefreshTokenStmt,
-		saveFoodStmt:                    q.saveFoodStmt,
-		searchFoodsByNameStmt:           q.searchFoodsByNameStmt,
-		setProfileAsDefaultStmt:         q.setProfileAsDefaultStmt,
-		updateFoodRatingStmt:            q.updateFoodRatingStmt,
-		updateUserStmt:                  q.updateUserStmt,
-		updateUserEmailVerificationStmt: q.updateUserEmailVerificationStmt,
-		updateUserLastLoginStmt:         q.updateUserLastLoginStmt,
-		updateUserPasswordStmt:          q.updateUserPasswordStmt,
-		updateUserProfileStmt:           q.updateUserProfileStmt,
+		db:                                         tx,
+		tx:                                         tx,
+		addHouseholdMemberStmt:                     q.addHouseholdMemberStmt,
+		checkProfileExistsStmt:                     q.checkProfileExistsStmt,
+		clearFoodImportStagingStmt:                 q.clearFoodImportStagingStmt,
+		countFilteredFoodsStmt:                     q.countFilteredFoodsStmt,
+		countFoodRevisionsStmt:                     q.countFoodRevisionsStmt,
+		countFoodSubmissionsByStatusStmt:           q.countFoodSubmissionsByStatusStmt,
+		countFoodSubmissionsBySubmitterStmt:        q.countFoodSubmissionsBySubmitterStmt,
+		countFoodsStmt:                             q.countFoodsStmt,
+		countFoodsByTypeStmt:                       q.countFoodsByTypeStmt,
+		countFoodsByTypesStmt:                      q.countFoodsByTypesStmt,
+		countFuzzySearchFoodsStmt:                  q.countFuzzySearchFoodsStmt,
+		countSearchFoodsStmt:                       q.countSearchFoodsStmt,
+		countStagedFoodsStmt:                       q.countStagedFoodsStmt,
+		createBodyMeasurementStmt:                  q.createBodyMeasurementStmt,
+		createCalorieTargetAdjustmentStmt:          q.createCalorieTargetAdjustmentStmt,
+		createFoodStmt:                             q.createFoodStmt,
+		createFoodRatingStmt:                       q.createFoodRatingStmt,
+		createFoodRedirectStmt:                     q.createFoodRedirectStmt,
+		createFoodRevisionStmt:                     q.createFoodRevisionStmt,
+		createFoodSubmissionStmt:                   q.createFoodSubmissionStmt,
+		createHouseholdStmt:                        q.createHouseholdStmt,
+		createHouseholdInvitationStmt:              q.createHouseholdInvitationStmt,
+		createIntakeLogStmt:                        q.createIntakeLogStmt,
+		createRefreshTokenStmt:                     q.createRefreshTokenStmt,
+		createUserStmt:                             q.createUserStmt,
+		createUserProfileStmt:                      q.createUserProfileStmt,
+		declareFoodExportCursorStmt:                q.declareFoodExportCursorStmt,
+		deleteBodyMeasurementStmt:                  q.deleteBodyMeasurementStmt,
+		deleteExpiredRefreshTokensStmt:             q.deleteExpiredRefreshTokensStmt,
+		deleteFoodStmt:                             q.deleteFoodStmt,
+		deleteFoodRatingStmt:                       q.deleteFoodRatingStmt,
+		deleteFoodTranslationStmt:                  q.deleteFoodTranslationStmt,
+		deleteHouseholdStmt:                        q.deleteHouseholdStmt,
+		deleteIntakeLogStmt:                        q.deleteIntakeLogStmt,
+		deleteSavedFoodStmt:                        q.deleteSavedFoodStmt,
+		deleteStagedFoodsAfterLineStmt:             q.deleteStagedFoodsAfterLineStmt,
+		deleteUserStmt:                             q.deleteUserStmt,
+		deleteUserProfileStmt:                      q.deleteUserProfileStmt,
+		facetFoodLabelsStmt:                        q.facetFoodLabelsStmt,
+		facetFoodTypesStmt:                         q.facetFoodTypesStmt,
+		facetNutrientHistogramsStmt:                q.facetNutrientHistogramsStmt,
+		filterFoodsStmt:                            q.filterFoodsStmt,
+		fuzzySearchFoodsStmt:                       q.fuzzySearchFoodsStmt,
+		getDefaultUserProfileStmt:                  q.getDefaultUserProfileStmt,
+		getFoodByEAN13Stmt:                         q.getFoodByEAN13Stmt,
+		getFoodByIDStmt:                            q.getFoodByIDStmt,
+		getFoodNameSimilarityStmt:                  q.getFoodNameSimilarityStmt,
+		getFoodRatingStmt:                          q.getFoodRatingStmt,
+		getFoodRedirectStmt:                        q.getFoodRedirectStmt,
+		getFoodRevisionStmt:                        q.getFoodRevisionStmt,
+		getFoodSearchRankStmt:                      q.getFoodSearchRankStmt,
+		getFoodSubmissionStmt:                      q.getFoodSubmissionStmt,
+		getHouseholdByIDStmt:                       q.getHouseholdByIDStmt,
+		getHouseholdInvitationStmt:                 q.getHouseholdInvitationStmt,
+		getLatestCalorieTargetAdjustmentStmt:       q.getLatestCalorieTargetAdjustmentStmt,
+		getProfileByIDDirectStmt:                   q.getProfileByIDDirectStmt,
+		getRefreshTokenStmt:                        q.getRefreshTokenStmt,
+		getSavedFoodStmt:                           q.getSavedFoodStmt,
+		getUserByEmailStmt:                         q.getUserByEmailStmt,
+		getUserByIDStmt:                            q.getUserByIDStmt,
+		getUserProfileByIDStmt:                     q.getUserProfileByIDStmt,
+		getUserProfilesStmt:                        q.getUserProfilesStmt,
+		insertStagedFoodsStmt:                      q.insertStagedFoodsStmt,
+		keepAdminEditedStagedFoodsStmt:             q.keepAdminEditedStagedFoodsStmt,
+		listAllergensStmt:                          q.listAllergensStmt,
+		listBodyMeasurementsStmt:                   q.listBodyMeasurementsStmt,
+		listCalorieTargetAdjustmentsStmt:           q.listCalorieTargetAdjustmentsStmt,
+		listDuplicateCandidatesStmt:                q.listDuplicateCandidatesStmt,
+		listFoodCategoriesStmt:                     q.listFoodCategoriesStmt,
+		listFoodNamesStmt:                          q.listFoodNamesStmt,
+		listFoodRevisionsStmt:                      q.listFoodRevisionsStmt,
+		listFoodSubmissionsByStatusStmt:            q.listFoodSubmissionsByStatusStmt,
+		listFoodSubmissionsBySubmitterStmt:         q.listFoodSubmissionsBySubmitterStmt,
+		listFoodTranslationsStmt:                   q.listFoodTranslationsStmt,
+		listFoodsStmt:                              q.listFoodsStmt,
+		listFoodsByTypeStmt:                        q.listFoodsByTypeStmt,
+		listFoodsByTypesStmt:                       q.listFoodsByTypesStmt,
+		listHealthConditionsStmt:                   q.listHealthConditionsStmt,
+		listHouseholdMembersStmt:                   q.listHouseholdMembersStmt,
+		listHouseholdsByUserStmt:                   q.listHouseholdsByUserStmt,
+		listIntakeLogsByProfileStmt:                q.listIntakeLogsByProfileStmt,
+		listPendingHouseholdInvitationsByEmailStmt: q.listPendingHouseholdInvitationsByEmailStmt,
+		listSavedFoodsStmt:                         q.listSavedFoodsStmt,
+		listTopFoodsByNutrientStmt:                 q.listTopFoodsByNutrientStmt,
+		listTranslationsForFoodStmt:                q.listTranslationsForFoodStmt,
+		listUnclassifiedFoodsStmt:                  q.listUnclassifiedFoodsStmt,
+		listUngradedFoodsStmt:                      q.listUngradedFoodsStmt,
+		listUnparsedFoodsStmt:                      q.listUnparsedFoodsStmt,
+		listUserRatingsStmt:                        q.listUserRatingsStmt,
+		markStagedFoodsImportedStmt:                q.markStagedFoodsImportedStmt,
+		moveFoodIntakeLogsStmt:                     q.moveFoodIntakeLogsStmt,
+		moveFoodRatingsStmt:                        q.moveFoodRatingsStmt,
+		moveFoodRedirectsStmt:                      q.moveFoodRedirectsStmt,
+		moveFoodSubmissionsStmt:                    q.moveFoodSubmissionsStmt,
+		moveFoodTranslationsStmt:                   q.moveFoodTranslationsStmt,
+		moveSavedFoodsStmt:                         q.moveSavedFoodsStmt,
+		rejectDuplicateStagedFoodsStmt:             q.rejectDuplicateStagedFoodsStmt,
+		rejectExistingStagedFoodsStmt:              q.rejectExistingStagedFoodsStmt,
+		rejectKnownBarcodeStagedFoodsStmt:          q.rejectKnownBarcodeStagedFoodsStmt,
+		rejectMergedStagedFoodsStmt:                q.rejectMergedStagedFoodsStmt,
+		removeHouseholdMemberStmt:                  q.removeHouseholdMemberStmt,
+		restoreFoodStmt:                            q.restoreFoodStmt,
+		retireFoodStmt:                             q.retireFoodStmt,
+		retireUnimportedFoodsStmt:                  q.retireUnimportedFoodsStmt,
+		reviewFoodSubmissionStmt:                   q.reviewFoodSubmissionStmt,
+		revokeAllUserRefreshTokensStmt:             q.revokeAllUserRefreshTokensStmt,
+		revokeRefreshTokenStmt:                     q.revokeRefreshTokenStmt,
+		saveFoodStmt:                               q.saveFoodStmt,
+		searchFoodsStmt:                            q.searchFoodsStmt,
+		setProfileAsDefaultStmt:                    q.setProfileAsDefaultStmt,
+		setProfileCalorieTargetStmt:                q.setProfileCalorieTargetStmt,
+		summarizeStagedFoodsStmt:                   q.summarizeStagedFoodsStmt,
+		tryLockFoodImportStmt:                      q.tryLockFoodImportStmt,
+		unlockFoodImportStmt:                       q.unlockFoodImportStmt,
+		updateChangedFoodsStmt:                     q.updateChangedFoodsStmt,
+		updateFoodStmt:                             q.updateFoodStmt,
+		updateFoodIngredientTreeStmt:               q.updateFoodIngredientTreeStmt,
+		updateFoodNovaStmt:                         q.updateFoodNovaStmt,
+		updateFoodNutriScoreStmt:                   q.updateFoodNutriScoreStmt,
+		updateFoodRatingStmt:                       q.updateFoodRatingStmt,
+		updateFoodSubmissionFoodStmt:               q.updateFoodSubmissionFoodStmt,
+		updateHouseholdInvitationStatusStmt:        q.updateHouseholdInvitationStatusStmt,
+		updateUserStmt:                             q.updateUserStmt,
+		updateUserEmailVerificationStmt:            q.updateUserEmailVerificationStmt,
+		updateUserLastLoginStmt:                    q.updateUserLastLoginStmt,
+		updateUserPasswordStmt:                     q.updateUserPasswordStmt,
+		updateUserProfileStmt:                      q.updateUserProfileStmt,
+		upsertFoodTranslationStmt:                  q.upsertFoodTranslationStmt,
+		upsertStagedTranslationsStmt:               q.upsertStagedTranslationsStmt,
 	}
 }
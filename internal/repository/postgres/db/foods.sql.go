@@ -8,13 +8,62 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+const countFilteredFoods = `-- name: CountFilteredFoods :one
+SELECT COUNT(*) FROM filter_foods(
+    $1::text, $2::text, $3::text,
+    $4::jsonb, $5::jsonb, $6::boolean,
+    $7::jsonb, $8::jsonb
+) AS foods
+`
+
+type CountFilteredFoodsParams struct {
+	Query            sql.NullString        `json:"query"`
+	Locale           string                `json:"locale"`
+	FoodType         sql.NullString        `json:"food_type"`
+	LabelsAll        pqtype.NullRawMessage `json:"labels_all"`
+	LabelsAny        pqtype.NullRawMessage `json:"labels_any"`
+	HasBarcode       sql.NullBool          `json:"has_barcode"`
+	NutriScoreGrades pqtype.NullRawMessage `json:"nutri_score_grades"`
+	NutrientRanges   json.RawMessage       `json:"nutrient_ranges"`
+}
+
+func (q *Queries) CountFilteredFoods(ctx context.Context, arg CountFilteredFoodsParams) (int64, error) {
+	row := q.queryRow(ctx, q.countFilteredFoodsStmt, countFilteredFoods,
+		arg.Query,
+		arg.Locale,
+		arg.FoodType,
+		arg.LabelsAll,
+		arg.LabelsAny,
+		arg.HasBarcode,
+		arg.NutriScoreGrades,
+		arg.NutrientRanges,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFoodRevisions = `-- name: CountFoodRevisions :one
+SELECT COUNT(*) FROM food_revisions
+WHERE food_id = $1
+`
+
+func (q *Queries) CountFoodRevisions(ctx context.Context, foodID string) (int64, error) {
+	row := q.queryRow(ctx, q.countFoodRevisionsStmt, countFoodRevisions, foodID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countFoods = `-- name: CountFoods :one
 SELECT COUNT(*) FROM foods
+WHERE retired_at IS NULL
 `
 
 func (q *Queries) CountFoods(ctx context.Context) (int64, error) {
@@ -24,6 +73,103 @@ func (q *Queries) CountFoods(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countFoodsByType = `-- name: CountFoodsByType :many
+SELECT food_type, COUNT(*) AS food_count FROM foods
+WHERE food_type IS NOT NULL AND food_type <> '' AND retired_at IS NULL
+GROUP BY food_type
+ORDER BY food_count DESC, food_type
+`
+
+type CountFoodsByTypeRow struct {
+	FoodType  sql.NullString `json:"food_type"`
+	FoodCount int64          `json:"food_count"`
+}
+
+func (q *Queries) CountFoodsByType(ctx context.Context) ([]CountFoodsByTypeRow, error) {
+	rows, err := q.query(ctx, q.countFoodsByTypeStmt, countFoodsByType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountFoodsByTypeRow{}
+	for rows.Next() {
+		var i CountFoodsByTypeRow
+		if err := rows.Scan(
+			&i.FoodType,
+			&i.FoodCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countFoodsByTypes = `-- name: CountFoodsByTypes :one
+SELECT COUNT(*) FROM foods
+WHERE food_type IN (SELECT jsonb_array_elements_text($1::jsonb))
+  AND retired_at IS NULL
+`
+
+func (q *Queries) CountFoodsByTypes(ctx context.Context, foodTypes json.RawMessage) (int64, error) {
+	row := q.queryRow(ctx, q.countFoodsByTypesStmt, countFoodsByTypes, foodTypes)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFuzzySearchFoods = `-- name: CountFuzzySearchFoods :one
+SELECT COUNT(*) FROM foods
+WHERE id IN (
+      SELECT id FROM foods WHERE $1::text <% name
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = $2::text AND $1::text <% name
+  )
+  AND retired_at IS NULL
+`
+
+type CountFuzzySearchFoodsParams struct {
+	Query  string `json:"query"`
+	Locale string `json:"locale"`
+}
+
+func (q *Queries) CountFuzzySearchFoods(ctx context.Context, arg CountFuzzySearchFoodsParams) (int64, error) {
+	row := q.queryRow(ctx, q.countFuzzySearchFoodsStmt, countFuzzySearchFoods, arg.Query, arg.Locale)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSearchFoods = `-- name: CountSearchFoods :one
+SELECT COUNT(*) FROM foods
+WHERE id IN (
+      SELECT id FROM foods WHERE search_vector @@ food_search_query($1::text, $2::text)
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = $2::text AND search_vector @@ food_search_query($1::text, $2::text)
+  )
+  AND retired_at IS NULL
+`
+
+type CountSearchFoodsParams struct {
+	Query  string `json:"query"`
+	Locale string `json:"locale"`
+}
+
+func (q *Queries) CountSearchFoods(ctx context.Context, arg CountSearchFoodsParams) (int64, error) {
+	row := q.queryRow(ctx, q.countSearchFoodsStmt, countSearchFoods, arg.Query, arg.Locale)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createFood = `-- name: CreateFood :one
 INSERT INTO foods (
     id,
@@ -38,11 +184,18 @@ INSERT INTO foods (
     labels,
     package_size,
     ingredients,
-    ingredient_analysis
+    ingredient_analysis,
+    invalid_barcode,
+    nutri_score_grade,
+    nutri_score_points,
+    nutri_score,
+    nova_group,
+    nova_markers,
+    ingredient_tree
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 )
-RETURNING id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at
+RETURNING id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset
 `
 
 type CreateFoodParams struct {
@@ -59,6 +212,13 @@ type CreateFoodParams struct {
 	PackageSize        pqtype.NullRawMessage `json:"package_size"`
 	Ingredients        sql.NullString        `json:"ingredients"`
 	IngredientAnalysis pqtype.NullRawMessage `json:"ingredient_analysis"`
+	InvalidBarcode     sql.NullString        `json:"invalid_barcode"`
+	NutriScoreGrade    sql.NullString        `json:"nutri_score_grade"`
+	NutriScorePoints   sql.NullInt16         `json:"nutri_score_points"`
+	NutriScore         pqtype.NullRawMessage `json:"nutri_score"`
+	NovaGroup          sql.NullInt16         `json:"nova_group"`
+	NovaMarkers        pqtype.NullRawMessage `json:"nova_markers"`
+	IngredientTree     pqtype.NullRawMessage `json:"ingredient_tree"`
 }
 
 func (q *Queries) CreateFood(ctx context.Context, arg CreateFoodParams) (Food, error) {
@@ -76,6 +236,13 @@ func (q *Queries) CreateFood(ctx context.Context, arg CreateFoodParams) (Food, e
 		arg.PackageSize,
 		arg.Ingredients,
 		arg.IngredientAnalysis,
+		arg.InvalidBarcode,
+		arg.NutriScoreGrade,
+		arg.NutriScorePoints,
+		arg.NutriScore,
+		arg.NovaGroup,
+		arg.NovaMarkers,
+		arg.IngredientTree,
 	)
 	var i Food
 	err := row.Scan(
@@ -94,6 +261,17 @@ func (q *Queries) CreateFood(ctx context.Context, arg CreateFoodParams) (Food, e
 		&i.IngredientAnalysis,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SearchVector,
+		&i.InvalidBarcode,
+		&i.NutriScoreGrade,
+		&i.NutriScorePoints,
+		&i.NutriScore,
+		&i.NovaGroup,
+		&i.NovaMarkers,
+		&i.IngredientTree,
+		&i.RetiredAt,
+		&i.LastImportedAt,
+		&i.Dataset,
 	)
 	return i, err
 }
@@ -137,6 +315,100 @@ func (q *Queries) CreateFoodRating(ctx context.Context, arg CreateFoodRatingPara
 	return i, err
 }
 
+const createFoodRedirect = `-- name: CreateFoodRedirect :exec
+INSERT INTO food_redirects (old_id, food_id, merged_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (old_id) DO UPDATE SET
+    food_id = EXCLUDED.food_id,
+    merged_by = EXCLUDED.merged_by,
+    created_at = NOW()
+`
+
+type CreateFoodRedirectParams struct {
+	OldID    string        `json:"old_id"`
+	FoodID   string        `json:"food_id"`
+	MergedBy uuid.NullUUID `json:"merged_by"`
+}
+
+// Replaces any redirect left from an earlier food with the same ID
+func (q *Queries) CreateFoodRedirect(ctx context.Context, arg CreateFoodRedirectParams) error {
+	_, err := q.exec(ctx, q.createFoodRedirectStmt, createFoodRedirect, arg.OldID, arg.FoodID, arg.MergedBy)
+	return err
+}
+
+const createFoodRevision = `-- name: CreateFoodRevision :one
+INSERT INTO food_revisions (
+    food_id,
+    action,
+    author_id,
+    diff,
+    before,
+    after,
+    reverted_revision_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, food_id, action, author_id, diff, before, after, reverted_revision_id, created_at
+`
+
+type CreateFoodRevisionParams struct {
+	FoodID             string                `json:"food_id"`
+	Action             string                `json:"action"`
+	AuthorID           uuid.NullUUID         `json:"author_id"`
+	Diff               json.RawMessage       `json:"diff"`
+	Before             pqtype.NullRawMessage `json:"before"`
+	After              pqtype.NullRawMessage `json:"after"`
+	RevertedRevisionID uuid.NullUUID         `json:"reverted_revision_id"`
+}
+
+func (q *Queries) CreateFoodRevision(ctx context.Context, arg CreateFoodRevisionParams) (FoodRevision, error) {
+	row := q.queryRow(ctx, q.createFoodRevisionStmt, createFoodRevision,
+		arg.FoodID,
+		arg.Action,
+		arg.AuthorID,
+		arg.Diff,
+		arg.Before,
+		arg.After,
+		arg.RevertedRevisionID,
+	)
+	var i FoodRevision
+	err := row.Scan(
+		&i.ID,
+		&i.FoodID,
+		&i.Action,
+		&i.AuthorID,
+		&i.Diff,
+		&i.Before,
+		&i.After,
+		&i.RevertedRevisionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const declareFoodExportCursor = `-- name: DeclareFoodExportCursor :exec
+DECLARE food_export NO SCROLL CURSOR FOR
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE (retired_at IS NULL OR $1::boolean)
+  AND ($2::text IS NULL OR food_type = $2::text)
+  AND ($3::timestamptz IS NULL OR updated_at >= $3::timestamptz)
+ORDER BY id
+`
+
+type DeclareFoodExportCursorParams struct {
+	IncludeRetired bool           `json:"include_retired"`
+	FoodType       sql.NullString `json:"food_type"`
+	UpdatedSince   sql.NullTime   `json:"updated_since"`
+}
+
+// Opens the food_export cursor over the foods to export, in ID order. Only
+// valid inside a transaction; rows are read with FETCH. Retired foods are
+// left out unless include_retired is set.
+func (q *Queries) DeclareFoodExportCursor(ctx context.Context, arg DeclareFoodExportCursorParams) error {
+	_, err := q.exec(ctx, q.declareFoodExportCursorStmt, declareFoodExportCursor, arg.IncludeRetired, arg.FoodType, arg.UpdatedSince)
+	return err
+}
+
 const deleteFood = `-- name: DeleteFood :exec
 DELETE FROM foods
 WHERE id = $1
@@ -162,6 +434,21 @@ func (q *Queries) DeleteFoodRating(ctx context.Context, arg DeleteFoodRatingPara
 	return err
 }
 
+const deleteFoodTranslation = `-- name: DeleteFoodTranslation :exec
+DELETE FROM food_translations
+WHERE food_id = $1 AND locale = $2
+`
+
+type DeleteFoodTranslationParams struct {
+	FoodID string `json:"food_id"`
+	Locale string `json:"locale"`
+}
+
+func (q *Queries) DeleteFoodTranslation(ctx context.Context, arg DeleteFoodTranslationParams) error {
+	_, err := q.exec(ctx, q.deleteFoodTranslationStmt, deleteFoodTranslation, arg.FoodID, arg.Locale)
+	return err
+}
+
 const deleteSavedFood = `-- name: DeleteSavedFood :exec
 DELETE FROM user_saved_foods
 WHERE user_id = $1 AND food_id = $2 AND list_type = $3
@@ -178,126 +465,1184 @@ func (q *Queries) DeleteSavedFood(ctx context.Context, arg DeleteSavedFoodParams
 	return err
 }
 
-const getFoodByEAN13 = `-- name: GetFoodByEAN13 :one
-SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at FROM foods
-WHERE ean_13 = $1 LIMIT 1
+const facetFoodLabels = `-- name: FacetFoodLabels :many
+SELECT facet.label::text AS label, COUNT(*) AS food_count
+FROM filter_foods(
+    $1::text, $2::text, $3::text,
+    $4::jsonb, $5::jsonb, $6::boolean,
+    $7::jsonb, $8::jsonb
+) AS foods, jsonb_array_elements_text(COALESCE(labels, '[]'::jsonb)) AS facet(label)
+GROUP BY facet.label
+ORDER BY food_count DESC, label
+LIMIT $9
 `
 
-func (q *Queries) GetFoodByEAN13(ctx context.Context, ean13 sql.NullString) (Food, error) {
-	row := q.queryRow(ctx, q.getFoodByEAN13Stmt, getFoodByEAN13, ean13)
-	var i Food
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.AlternateNames,
-		&i.Description,
-		&i.FoodType,
-		&i.Source,
-		&i.Serving,
-		&i.Nutrition100g,
-		&i.Ean13,
-		&i.Labels,
-		&i.PackageSize,
-		&i.Ingredients,
-		&i.IngredientAnalysis,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+type FacetFoodLabelsParams struct {
+	Query            sql.NullString        `json:"query"`
+	Locale           string                `json:"locale"`
+	FoodType         sql.NullString        `json:"food_type"`
+	LabelsAll        pqtype.NullRawMessage `json:"labels_all"`
+	LabelsAny        pqtype.NullRawMessage `json:"labels_any"`
+	HasBarcode       sql.NullBool          `json:"has_barcode"`
+	NutriScoreGrades pqtype.NullRawMessage `json:"nutri_score_grades"`
+	NutrientRanges   json.RawMessage       `json:"nutrient_ranges"`
+	LabelLimit       int32                 `json:"label_limit"`
+}
+
+type FacetFoodLabelsRow struct {
+	Label     string `json:"label"`
+	FoodCount int64  `json:"food_count"`
+}
+
+func (q *Queries) FacetFoodLabels(ctx context.Context, arg FacetFoodLabelsParams) ([]FacetFoodLabelsRow, error) {
+	rows, err := q.query(ctx, q.facetFoodLabelsStmt, facetFoodLabels,
+		arg.Query,
+		arg.Locale,
+		arg.FoodType,
+		arg.LabelsAll,
+		arg.LabelsAny,
+		arg.HasBarcode,
+		arg.NutriScoreGrades,
+		arg.NutrientRanges,
+		arg.LabelLimit,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FacetFoodLabelsRow{}
+	for rows.Next() {
+		var i FacetFoodLabelsRow
+		if err := rows.Scan(
+			&i.Label,
+			&i.FoodCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getFoodByID = `-- name: GetFoodByID :one
-SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at FROM foods
-WHERE id = $1 LIMIT 1
+const facetFoodTypes = `-- name: FacetFoodTypes :many
+SELECT food_type, COUNT(*) AS food_count FROM filter_foods(
+    $1::text, $2::text, $3::text,
+    $4::jsonb, $5::jsonb, $6::boolean,
+    $7::jsonb, $8::jsonb
+) AS foods
+GROUP BY food_type
+ORDER BY food_count DESC, food_type
 `
 
-func (q *Queries) GetFoodByID(ctx context.Context, id string) (Food, error) {
-	row := q.queryRow(ctx, q.getFoodByIDStmt, getFoodByID, id)
-	var i Food
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.AlternateNames,
-		&i.Description,
-		&i.FoodType,
-		&i.Source,
-		&i.Serving,
-		&i.Nutrition100g,
-		&i.Ean13,
-		&i.Labels,
-		&i.PackageSize,
-		&i.Ingredients,
-		&i.IngredientAnalysis,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+type FacetFoodTypesParams struct {
+	Query            sql.NullString        `json:"query"`
+	Locale           string                `json:"locale"`
+	FoodType         sql.NullString        `json:"food_type"`
+	LabelsAll        pqtype.NullRawMessage `json:"labels_all"`
+	LabelsAny        pqtype.NullRawMessage `json:"labels_any"`
+	HasBarcode       sql.NullBool          `json:"has_barcode"`
+	NutriScoreGrades pqtype.NullRawMessage `json:"nutri_score_grades"`
+	NutrientRanges   json.RawMessage       `json:"nutrient_ranges"`
+}
+
+type FacetFoodTypesRow struct {
+	FoodType  sql.NullString `json:"food_type"`
+	FoodCount int64          `json:"food_count"`
+}
+
+func (q *Queries) FacetFoodTypes(ctx context.Context, arg FacetFoodTypesParams) ([]FacetFoodTypesRow, error) {
+	rows, err := q.query(ctx, q.facetFoodTypesStmt, facetFoodTypes,
+		arg.Query,
+		arg.Locale,
+		arg.FoodType,
+		arg.LabelsAll,
+		arg.LabelsAny,
+		arg.HasBarcode,
+		arg.NutriScoreGrades,
+		arg.NutrientRanges,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FacetFoodTypesRow{}
+	for rows.Next() {
+		var i FacetFoodTypesRow
+		if err := rows.Scan(
+			&i.FoodType,
+			&i.FoodCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getFoodRating = `-- name: GetFoodRating :one
-SELECT id, user_id, food_id, rating, comments, created_at, updated_at FROM food_ratings
-WHERE user_id = $1 AND food_id = $2
-LIMIT 1
+const facetNutrientHistograms = `-- name: FacetNutrientHistograms :many
+SELECT
+    h.nutrient::text AS nutrient,
+    width_bucket(
+        (nutrition_100g ->> h.nutrient)::numeric,
+        ARRAY(SELECT jsonb_array_elements_text(h.bounds)::numeric)
+    )::int AS bucket,
+    COUNT(*) AS food_count
+FROM filter_foods(
+    $1::text, $2::text, $3::text,
+    $4::jsonb, $5::jsonb, $6::boolean,
+    $7::jsonb, $8::jsonb
+) AS foods, jsonb_each($9::jsonb) AS h(nutrient, bounds)
+WHERE jsonb_typeof(nutrition_100g -> h.nutrient) = 'number'
+GROUP BY h.nutrient, bucket
+ORDER BY h.nutrient, bucket
 `
 
-type GetFoodRatingParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	FoodID string    `json:"food_id"`
+type FacetNutrientHistogramsParams struct {
+	Query            sql.NullString        `json:"query"`
+	Locale           string                `json:"locale"`
+	FoodType         sql.NullString        `json:"food_type"`
+	LabelsAll        pqtype.NullRawMessage `json:"labels_all"`
+	LabelsAny        pqtype.NullRawMessage `json:"labels_any"`
+	HasBarcode       sql.NullBool          `json:"has_barcode"`
+	NutriScoreGrades pqtype.NullRawMessage `json:"nutri_score_grades"`
+	NutrientRanges   json.RawMessage       `json:"nutrient_ranges"`
+	HistogramBounds  json.RawMessage       `json:"histogram_bounds"`
 }
 
-func (q *Queries) GetFoodRating(ctx context.Context, arg GetFoodRatingParams) (FoodRating, error) {
-	row := q.queryRow(ctx, q.getFoodRatingStmt, getFoodRating, arg.UserID, arg.FoodID)
-	var i FoodRating
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.FoodID,
-		&i.Rating,
-		&i.Comments,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+type FacetNutrientHistogramsRow struct {
+	Nutrient  string `json:"nutrient"`
+	Bucket    int32  `json:"bucket"`
+	FoodCount int64  `json:"food_count"`
+}
+
+// Buckets each nutrient in the histogram_bounds object ({"protein": [5, 10, 20]})
+// with width_bucket: 0 is below the first bound, len(bounds) at or above the last
+func (q *Queries) FacetNutrientHistograms(ctx context.Context, arg FacetNutrientHistogramsParams) ([]FacetNutrientHistogramsRow, error) {
+	rows, err := q.query(ctx, q.facetNutrientHistogramsStmt, facetNutrientHistograms,
+		arg.Query,
+		arg.Locale,
+		arg.FoodType,
+		arg.LabelsAll,
+		arg.LabelsAny,
+		arg.HasBarcode,
+		arg.NutriScoreGrades,
+		arg.NutrientRanges,
+		arg.HistogramBounds,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FacetNutrientHistogramsRow{}
+	for rows.Next() {
+		var i FacetNutrientHistogramsRow
+		if err := rows.Scan(
+			&i.Nutrient,
+			&i.Bucket,
+			&i.FoodCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getSavedFood = `-- name: GetSavedFood :one
-SELECT id, user_id, food_id, list_type, created_at FROM user_saved_foods
-WHERE user_id = $1 AND food_id = $2 AND list_type = $3
-LIMIT 1
+const filterFoods = `-- name: FilterFoods :many
+SELECT foods.id, foods.name, foods.alternate_names, foods.description, foods.food_type, foods.source, foods.serving, foods.nutrition_100g, foods.ean_13, foods.labels, foods.package_size, foods.ingredients, foods.ingredient_analysis, foods.created_at, foods.updated_at, foods.search_vector, foods.invalid_barcode, foods.nutri_score_grade, foods.nutri_score_points, foods.nutri_score, foods.nova_group, foods.nova_markers, foods.ingredient_tree, foods.retired_at, foods.last_imported_at, foods.dataset FROM filter_foods(
+    $1::text, $2::text, $3::text,
+    $4::jsonb, $5::jsonb, $6::boolean,
+    $7::jsonb, $8::jsonb
+) AS foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = $2::text
+WHERE $9::text IS NULL OR (
+    (CASE WHEN $10::text = 'nutri_score' THEN COALESCE(nutri_score_points, 32767) ELSE 0 END)::float8,
+    (CASE WHEN $1::text IS NULL THEN 0
+          ELSE -GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query($1::text, $2::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query($1::text, $2::text)))::float8
+     END)::float8,
+    foods.name, foods.id
+) > ($11::float8, $12::float8, $13::text, $9::text)
+ORDER BY
+    CASE WHEN $10::text = 'nutri_score' THEN COALESCE(nutri_score_points, 32767) ELSE 0 END,
+    CASE WHEN $1::text IS NULL THEN 0
+         ELSE GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query($1::text, $2::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query($1::text, $2::text)))::float8
+    END DESC,
+    foods.name, foods.id
+LIMIT $14 OFFSET $15
 `
 
-type GetSavedFoodParams struct {
-	UserID   uuid.UUID `json:"user_id"`
-	FoodID   string    `json:"food_id"`
-	ListType string    `json:"list_type"`
+type FilterFoodsParams struct {
+	Query            sql.NullString        `json:"query"`
+	Locale           string                `json:"locale"`
+	FoodType         sql.NullString        `json:"food_type"`
+	LabelsAll        pqtype.NullRawMessage `json:"labels_all"`
+	LabelsAny        pqtype.NullRawMessage `json:"labels_any"`
+	HasBarcode       sql.NullBool          `json:"has_barcode"`
+	NutriScoreGrades pqtype.NullRawMessage `json:"nutri_score_grades"`
+	NutrientRanges   json.RawMessage       `json:"nutrient_ranges"`
+	AfterID          sql.NullString        `json:"after_id"`
+	SortBy           string                `json:"sort_by"`
+	AfterPoints      float64               `json:"after_points"`
+	AfterRank        float64               `json:"after_rank"`
+	AfterName        string                `json:"after_name"`
+	RowLimit         int32                 `json:"row_limit"`
+	RowOffset        int32                 `json:"row_offset"`
+}
+
+// Pages through filter_foods, which CountFilteredFoods and the facet queries
+// below select from too. The translation joined in only contributes to the rank.
+// Ungraded foods sort last under the nutri_score ordering through a sentinel
+// above any real score, and the rank is negated to sort ascending.
+func (q *Queries) FilterFoods(ctx context.Context, arg FilterFoodsParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.filterFoodsStmt, filterFoods,
+		arg.Query,
+		arg.Locale,
+		arg.FoodType,
+		arg.LabelsAll,
+		arg.LabelsAny,
+		arg.HasBarcode,
+		arg.NutriScoreGrades,
+		arg.NutrientRanges,
+		arg.AfterID,
+		arg.SortBy,
+		arg.AfterPoints,
+		arg.AfterRank,
+		arg.AfterName,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Food{}
+	for rows.Next() {
+		var i Food
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const fuzzySearchFoods = `-- name: FuzzySearchFoods :many
+SELECT foods.id, foods.name, foods.alternate_names, foods.description, foods.food_type, foods.source, foods.serving, foods.nutrition_100g, foods.ean_13, foods.labels, foods.package_size, foods.ingredients, foods.ingredient_analysis, foods.created_at, foods.updated_at, foods.search_vector, foods.invalid_barcode, foods.nutri_score_grade, foods.nutri_score_points, foods.nutri_score, foods.nova_group, foods.nova_markers, foods.ingredient_tree, foods.retired_at, foods.last_imported_at, foods.dataset FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = $1::text
+WHERE id IN (
+      SELECT id FROM foods WHERE $2::text <% name
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = $1::text AND $2::text <% name
+  )
+  AND retired_at IS NULL
+  AND ($3::text IS NULL OR (
+      -GREATEST(word_similarity($2::text, foods.name), word_similarity($2::text, ft.name))::float8, foods.name, foods.id
+  ) > ($4::float8, $5::text, $3::text))
+ORDER BY GREATEST(word_similarity($2::text, foods.name), word_similarity($2::text, ft.name))::float8 DESC, foods.name, foods.id
+LIMIT $6 OFFSET $7
+`
+
+type FuzzySearchFoodsParams struct {
+	Locale          string         `json:"locale"`
+	Query           string         `json:"query"`
+	AfterID         sql.NullString `json:"after_id"`
+	AfterSimilarity float64        `json:"after_similarity"`
+	AfterName       string         `json:"after_name"`
+	RowLimit        int32          `json:"row_limit"`
+	RowOffset       int32          `json:"row_offset"`
+}
+
+// Like SearchFoods, matches the food name and its translated name in separate
+// branches so that each uses its trigram index
+func (q *Queries) FuzzySearchFoods(ctx context.Context, arg FuzzySearchFoodsParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.fuzzySearchFoodsStmt, fuzzySearchFoods,
+		arg.Locale,
+		arg.Query,
+		arg.AfterID,
+		arg.AfterSimilarity,
+		arg.AfterName,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Food{}
+	for rows.Next() {
+		var i Food
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFoodByEAN13 = `-- name: GetFoodByEAN13 :one
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE ean_13 = $1 LIMIT 1
+`
+
+func (q *Queries) GetFoodByEAN13(ctx context.Context, ean13 sql.NullString) (Food, error) {
+	row := q.queryRow(ctx, q.getFoodByEAN13Stmt, getFoodByEAN13, ean13)
+	var i Food
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AlternateNames,
+		&i.Description,
+		&i.FoodType,
+		&i.Source,
+		&i.Serving,
+		&i.Nutrition100g,
+		&i.Ean13,
+		&i.Labels,
+		&i.PackageSize,
+		&i.Ingredients,
+		&i.IngredientAnalysis,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SearchVector,
+		&i.InvalidBarcode,
+		&i.NutriScoreGrade,
+		&i.NutriScorePoints,
+		&i.NutriScore,
+		&i.NovaGroup,
+		&i.NovaMarkers,
+		&i.IngredientTree,
+		&i.RetiredAt,
+		&i.LastImportedAt,
+		&i.Dataset,
+	)
+	return i, err
+}
+
+const getFoodByID = `-- name: GetFoodByID :one
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFoodByID(ctx context.Context, id string) (Food, error) {
+	row := q.queryRow(ctx, q.getFoodByIDStmt, getFoodByID, id)
+	var i Food
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AlternateNames,
+		&i.Description,
+		&i.FoodType,
+		&i.Source,
+		&i.Serving,
+		&i.Nutrition100g,
+		&i.Ean13,
+		&i.Labels,
+		&i.PackageSize,
+		&i.Ingredients,
+		&i.IngredientAnalysis,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SearchVector,
+		&i.InvalidBarcode,
+		&i.NutriScoreGrade,
+		&i.NutriScorePoints,
+		&i.NutriScore,
+		&i.NovaGroup,
+		&i.NovaMarkers,
+		&i.IngredientTree,
+		&i.RetiredAt,
+		&i.LastImportedAt,
+		&i.Dataset,
+	)
+	return i, err
+}
+
+const getFoodNameSimilarity = `-- name: GetFoodNameSimilarity :one
+SELECT GREATEST(word_similarity($1::text, foods.name), word_similarity($1::text, ft.name))::float8 AS similarity
+FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = $2::text
+WHERE foods.id = $3
+`
+
+type GetFoodNameSimilarityParams struct {
+	Query  string `json:"query"`
+	Locale string `json:"locale"`
+	ID     string `json:"id"`
+}
+
+func (q *Queries) GetFoodNameSimilarity(ctx context.Context, arg GetFoodNameSimilarityParams) (float64, error) {
+	row := q.queryRow(ctx, q.getFoodNameSimilarityStmt, getFoodNameSimilarity, arg.Query, arg.Locale, arg.ID)
+	var similarity float64
+	err := row.Scan(&similarity)
+	return similarity, err
+}
+
+const getFoodRating = `-- name: GetFoodRating :one
+SELECT id, user_id, food_id, rating, comments, created_at, updated_at FROM food_ratings
+WHERE user_id = $1 AND food_id = $2
+LIMIT 1
+`
+
+type GetFoodRatingParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	FoodID string    `json:"food_id"`
+}
+
+func (q *Queries) GetFoodRating(ctx context.Context, arg GetFoodRatingParams) (FoodRating, error) {
+	row := q.queryRow(ctx, q.getFoodRatingStmt, getFoodRating, arg.UserID, arg.FoodID)
+	var i FoodRating
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FoodID,
+		&i.Rating,
+		&i.Comments,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getFoodRedirect = `-- name: GetFoodRedirect :one
+SELECT food_id FROM food_redirects
+WHERE old_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFoodRedirect(ctx context.Context, oldID string) (string, error) {
+	row := q.queryRow(ctx, q.getFoodRedirectStmt, getFoodRedirect, oldID)
+	var foodID string
+	err := row.Scan(&foodID)
+	return foodID, err
+}
+
+const getFoodRevision = `-- name: GetFoodRevision :one
+SELECT id, food_id, action, author_id, diff, before, after, reverted_revision_id, created_at FROM food_revisions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFoodRevision(ctx context.Context, id uuid.UUID) (FoodRevision, error) {
+	row := q.queryRow(ctx, q.getFoodRevisionStmt, getFoodRevision, id)
+	var i FoodRevision
+	err := row.Scan(
+		&i.ID,
+		&i.FoodID,
+		&i.Action,
+		&i.AuthorID,
+		&i.Diff,
+		&i.Before,
+		&i.After,
+		&i.RevertedRevisionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFoodSearchRank = `-- name: GetFoodSearchRank :one
+SELECT GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query($1::text, $2::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query($1::text, $2::text)))::float8 AS rank
+FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = $2::text
+WHERE foods.id = $3
+`
+
+type GetFoodSearchRankParams struct {
+	Query  string `json:"query"`
+	Locale string `json:"locale"`
+	ID     string `json:"id"`
+}
+
+func (q *Queries) GetFoodSearchRank(ctx context.Context, arg GetFoodSearchRankParams) (float64, error) {
+	row := q.queryRow(ctx, q.getFoodSearchRankStmt, getFoodSearchRank, arg.Query, arg.Locale, arg.ID)
+	var rank float64
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getSavedFood = `-- name: GetSavedFood :one
+SELECT id, user_id, food_id, list_type, created_at FROM user_saved_foods
+WHERE user_id = $1 AND food_id = $2 AND list_type = $3
+LIMIT 1
+`
+
+type GetSavedFoodParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	FoodID   string    `json:"food_id"`
+	ListType string    `json:"list_type"`
+}
+
+func (q *Queries) GetSavedFood(ctx context.Context, arg GetSavedFoodParams) (UserSavedFood, error) {
+	row := q.queryRow(ctx, q.getSavedFoodStmt, getSavedFood, arg.UserID, arg.FoodID, arg.ListType)
+	var i UserSavedFood
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.FoodID,
+		&i.ListType,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listDuplicateCandidates = `-- name: ListDuplicateCandidates :many
+SELECT
+    a.id::text AS food_id,
+    b.id::text AS other_id,
+    (COALESCE(a.ean_13, '') <> '' AND a.ean_13 = b.ean_13)::boolean AS same_barcode,
+    similarity(a.name, b.name)::float8 AS name_similarity
+FROM foods a
+JOIN foods b ON a.id < b.id AND b.retired_at IS NULL AND (
+    (COALESCE(a.ean_13, '') <> '' AND a.ean_13 = b.ean_13)
+    OR (a.name % b.name AND similarity(a.name, b.name) >= $1::float8)
+)
+WHERE a.retired_at IS NULL
+  AND ($2::text IS NULL OR (a.food_type = $2::text AND b.food_type = $2::text))
+  AND ($3::text IS NULL OR a.id = $3::text OR b.id = $3::text)
+  AND ($2::text IS NOT NULL OR $3::text IS NOT NULL)
+ORDER BY same_barcode DESC, name_similarity DESC, a.id, b.id
+LIMIT $4
+`
+
+type ListDuplicateCandidatesParams struct {
+	MinNameSimilarity float64        `json:"min_name_similarity"`
+	FoodType          sql.NullString `json:"food_type"`
+	FoodID            sql.NullString `json:"food_id"`
+	RowLimit          int32          `json:"row_limit"`
+}
+
+type ListDuplicateCandidatesRow struct {
+	FoodID         string  `json:"food_id"`
+	OtherID        string  `json:"other_id"`
+	SameBarcode    bool    `json:"same_barcode"`
+	NameSimilarity float64 `json:"name_similarity"`
+}
+
+// Pairs of foods sharing a barcode or with similar names, each pair once.
+// Names are compared with the trigram % operator so the name index is used.
+// The search is scoped to a food type or a food; a scan of the whole catalog
+// would compare every food with every other.
+func (q *Queries) ListDuplicateCandidates(ctx context.Context, arg ListDuplicateCandidatesParams) ([]ListDuplicateCandidatesRow, error) {
+	rows, err := q.query(ctx, q.listDuplicateCandidatesStmt, listDuplicateCandidates,
+		arg.MinNameSimilarity,
+		arg.FoodType,
+		arg.FoodID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDuplicateCandidatesRow{}
+	for rows.Next() {
+		var i ListDuplicateCandidatesRow
+		if err := rows.Scan(
+			&i.FoodID,
+			&i.OtherID,
+			&i.SameBarcode,
+			&i.NameSimilarity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFoodNames = `-- name: ListFoodNames :many
+SELECT id, name, alternate_names FROM foods
+WHERE retired_at IS NULL
+`
+
+type ListFoodNamesRow struct {
+	ID             string                `json:"id"`
+	Name           string                `json:"name"`
+	AlternateNames pqtype.NullRawMessage `json:"alternate_names"`
+}
+
+func (q *Queries) ListFoodNames(ctx context.Context) ([]ListFoodNamesRow, error) {
+	rows, err := q.query(ctx, q.listFoodNamesStmt, listFoodNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFoodNamesRow{}
+	for rows.Next() {
+		var i ListFoodNamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFoodRevisions = `-- name: ListFoodRevisions :many
+SELECT id, food_id, action, author_id, diff, before, after, reverted_revision_id, created_at FROM food_revisions
+WHERE food_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListFoodRevisionsParams struct {
+	FoodID string `json:"food_id"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+func (q *Queries) ListFoodRevisions(ctx context.Context, arg ListFoodRevisionsParams) ([]FoodRevision, error) {
+	rows, err := q.query(ctx, q.listFoodRevisionsStmt, listFoodRevisions, arg.FoodID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FoodRevision{}
+	for rows.Next() {
+		var i FoodRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.FoodID,
+			&i.Action,
+			&i.AuthorID,
+			&i.Diff,
+			&i.Before,
+			&i.After,
+			&i.RevertedRevisionID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFoodTranslations = `-- name: ListFoodTranslations :many
+SELECT food_id, locale, name, alternate_names, description, search_vector, created_at, updated_at FROM food_translations
+WHERE locale = $1::text
+  AND food_id IN (SELECT jsonb_array_elements_text($2::jsonb))
+`
+
+type ListFoodTranslationsParams struct {
+	Locale  string          `json:"locale"`
+	FoodIds json.RawMessage `json:"food_ids"`
+}
+
+func (q *Queries) ListFoodTranslations(ctx context.Context, arg ListFoodTranslationsParams) ([]FoodTranslation, error) {
+	rows, err := q.query(ctx, q.listFoodTranslationsStmt, listFoodTranslations, arg.Locale, arg.FoodIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FoodTranslation{}
+	for rows.Next() {
+		var i FoodTranslation
+		if err := rows.Scan(
+			&i.FoodID,
+			&i.Locale,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.SearchVector,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFoods = `-- name: ListFoods :many
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE retired_at IS NULL
+  AND ($1::text IS NULL OR (name, id) > ($2::text, $1::text))
+ORDER BY name, id
+LIMIT $3 OFFSET $4
+`
+
+type ListFoodsParams struct {
+	AfterID   sql.NullString `json:"after_id"`
+	AfterName string         `json:"after_name"`
+	RowLimit  int32          `json:"row_limit"`
+	RowOffset int32          `json:"row_offset"`
+}
+
+// Pages by offset, or by keyset after the (name, id) of a cursor when after_id is set
+func (q *Queries) ListFoods(ctx context.Context, arg ListFoodsParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.listFoodsStmt, listFoods,
+		arg.AfterID,
+		arg.AfterName,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Food{}
+	for rows.Next() {
+		var i Food
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFoodsByType = `-- name: ListFoodsByType :many
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE food_type = $1 AND retired_at IS NULL
+ORDER BY name
+LIMIT $2 OFFSET $3
+`
+
+type ListFoodsByTypeParams struct {
+	FoodType sql.NullString `json:"food_type"`
+	Limit    int32          `json:"limit"`
+	Offset   int32          `json:"offset"`
+}
+
+func (q *Queries) ListFoodsByType(ctx context.Context, arg ListFoodsByTypeParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.listFoodsByTypeStmt, listFoodsByType, arg.FoodType, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Food{}
+	for rows.Next() {
+		var i Food
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFoodsByTypes = `-- name: ListFoodsByTypes :many
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE food_type IN (SELECT jsonb_array_elements_text($1::jsonb))
+  AND retired_at IS NULL
+  AND ($2::text IS NULL OR (name, id) > ($3::text, $2::text))
+ORDER BY name, id
+LIMIT $4 OFFSET $5
+`
+
+type ListFoodsByTypesParams struct {
+	FoodTypes json.RawMessage `json:"food_types"`
+	AfterID   sql.NullString  `json:"after_id"`
+	AfterName string          `json:"after_name"`
+	RowLimit  int32           `json:"row_limit"`
+	RowOffset int32           `json:"row_offset"`
+}
+
+func (q *Queries) ListFoodsByTypes(ctx context.Context, arg ListFoodsByTypesParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.listFoodsByTypesStmt, listFoodsByTypes,
+		arg.FoodTypes,
+		arg.AfterID,
+		arg.AfterName,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Food{}
+	for rows.Next() {
+		var i Food
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSavedFoods = `-- name: ListSavedFoods :many
+SELECT id, user_id, food_id, list_type, created_at FROM user_saved_foods
+WHERE user_id = $1 AND list_type = $2
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListSavedFoodsParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	ListType string    `json:"list_type"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+}
+
+func (q *Queries) ListSavedFoods(ctx context.Context, arg ListSavedFoodsParams) ([]UserSavedFood, error) {
+	rows, err := q.query(ctx, q.listSavedFoodsStmt, listSavedFoods,
+		arg.UserID,
+		arg.ListType,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSavedFood{}
+	for rows.Next() {
+		var i UserSavedFood
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.FoodID,
+			&i.ListType,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTopFoodsByNutrient = `-- name: ListTopFoodsByNutrient :many
+SELECT f.id, f.name, f.alternate_names, f.description, f.food_type, f.source, f.serving, f.nutrition_100g, f.ean_13, f.labels, f.package_size, f.ingredients, f.ingredient_analysis, f.created_at, f.updated_at, f.search_vector, f.invalid_barcode, f.nutri_score_grade, f.nutri_score_points, f.nutri_score, f.nova_group, f.nova_markers, f.ingredient_tree, f.retired_at, f.last_imported_at, f.dataset FROM (
+    SELECT DISTINCT ON (v.food_id) v.food_id, v.amount
+    FROM food_nutrient_values v
+    JOIN jsonb_array_elements_text($1::jsonb) WITH ORDINALITY AS k(nutrient, priority) ON k.nutrient = v.nutrient
+    ORDER BY v.food_id, k.priority
+) ranked
+JOIN foods f ON f.id = ranked.food_id
+WHERE f.retired_at IS NULL
+ORDER BY ranked.amount DESC, f.id
+LIMIT $2
+`
+
+type ListTopFoodsByNutrientParams struct {
+	Nutrients json.RawMessage `json:"nutrients"`
+	RowLimit  int32           `json:"row_limit"`
+}
+
+// Ranks foods by a nutrient stored under any of the keys in the nutrients
+// array, such as "dietary_fiber", "fiber" and "fibre". A food storing it
+// under several keys is ranked by the first of them.
+func (q *Queries) ListTopFoodsByNutrient(ctx context.Context, arg ListTopFoodsByNutrientParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.listTopFoodsByNutrientStmt, listTopFoodsByNutrient, arg.Nutrients, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Food{}
+	for rows.Next() {
+		var i Food
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) GetSavedFood(ctx context.Context, arg GetSavedFoodParams) (UserSavedFood, error) {
-	row := q.queryRow(ctx, q.getSavedFoodStmt, getSavedFood, arg.UserID, arg.FoodID, arg.ListType)
-	var i UserSavedFood
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.FoodID,
-		&i.ListType,
-		&i.CreatedAt,
-	)
-	return i, err
+const listTranslationsForFood = `-- name: ListTranslationsForFood :many
+SELECT food_id, locale, name, alternate_names, description, search_vector, created_at, updated_at FROM food_translations
+WHERE food_id = $1
+ORDER BY locale
+`
+
+func (q *Queries) ListTranslationsForFood(ctx context.Context, foodID string) ([]FoodTranslation, error) {
+	rows, err := q.query(ctx, q.listTranslationsForFoodStmt, listTranslationsForFood, foodID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FoodTranslation{}
+	for rows.Next() {
+		var i FoodTranslation
+		if err := rows.Scan(
+			&i.FoodID,
+			&i.Locale,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.SearchVector,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const listFoods = `-- name: ListFoods :many
-SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at FROM foods
-ORDER BY name
-LIMIT $1 OFFSET $2
+const listUnclassifiedFoods = `-- name: ListUnclassifiedFoods :many
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE nova_group IS NULL AND id > $1::text
+ORDER BY id
+LIMIT $2
 `
 
-type ListFoodsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+type ListUnclassifiedFoodsParams struct {
+	AfterID  string `json:"after_id"`
+	RowLimit int32  `json:"row_limit"`
 }
 
-func (q *Queries) ListFoods(ctx context.Context, arg ListFoodsParams) ([]Food, error) {
-	rows, err := q.query(ctx, q.listFoodsStmt, listFoods, arg.Limit, arg.Offset)
+// Pages through foods without a NOVA group by ID for backfilling
+func (q *Queries) ListUnclassifiedFoods(ctx context.Context, arg ListUnclassifiedFoodsParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.listUnclassifiedFoodsStmt, listUnclassifiedFoods, arg.AfterID, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -321,6 +1666,17 @@ func (q *Queries) ListFoods(ctx context.Context, arg ListFoodsParams) ([]Food, e
 			&i.IngredientAnalysis,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
 		); err != nil {
 			return nil, err
 		}
@@ -335,21 +1691,21 @@ func (q *Queries) ListFoods(ctx context.Context, arg ListFoodsParams) ([]Food, e
 	return items, nil
 }
 
-const listFoodsByType = `-- name: ListFoodsByType :many
-SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at FROM foods
-WHERE food_type = $1
-ORDER BY name
-LIMIT $2 OFFSET $3
+const listUngradedFoods = `-- name: ListUngradedFoods :many
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE nutri_score_grade IS NULL AND id > $1::text
+ORDER BY id
+LIMIT $2
 `
 
-type ListFoodsByTypeParams struct {
-	FoodType sql.NullString `json:"food_type"`
-	Limit    int32          `json:"limit"`
-	Offset   int32          `json:"offset"`
+type ListUngradedFoodsParams struct {
+	AfterID  string `json:"after_id"`
+	RowLimit int32  `json:"row_limit"`
 }
 
-func (q *Queries) ListFoodsByType(ctx context.Context, arg ListFoodsByTypeParams) ([]Food, error) {
-	rows, err := q.query(ctx, q.listFoodsByTypeStmt, listFoodsByType, arg.FoodType, arg.Limit, arg.Offset)
+// Pages through foods without a Nutri-Score by ID for backfilling
+func (q *Queries) ListUngradedFoods(ctx context.Context, arg ListUngradedFoodsParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.listUngradedFoodsStmt, listUngradedFoods, arg.AfterID, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -373,6 +1729,17 @@ func (q *Queries) ListFoodsByType(ctx context.Context, arg ListFoodsByTypeParams
 			&i.IngredientAnalysis,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
 		); err != nil {
 			return nil, err
 		}
@@ -387,40 +1754,55 @@ func (q *Queries) ListFoodsByType(ctx context.Context, arg ListFoodsByTypeParams
 	return items, nil
 }
 
-const listSavedFoods = `-- name: ListSavedFoods :many
-SELECT id, user_id, food_id, list_type, created_at FROM user_saved_foods
-WHERE user_id = $1 AND list_type = $2
-ORDER BY created_at DESC
-LIMIT $3 OFFSET $4
+const listUnparsedFoods = `-- name: ListUnparsedFoods :many
+SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset FROM foods
+WHERE ingredient_tree IS NULL AND COALESCE(ingredients, '') <> '' AND id > $1::text
+ORDER BY id
+LIMIT $2
 `
 
-type ListSavedFoodsParams struct {
-	UserID   uuid.UUID `json:"user_id"`
-	ListType string    `json:"list_type"`
-	Limit    int32     `json:"limit"`
-	Offset   int32     `json:"offset"`
+type ListUnparsedFoodsParams struct {
+	AfterID  string `json:"after_id"`
+	RowLimit int32  `json:"row_limit"`
 }
 
-func (q *Queries) ListSavedFoods(ctx context.Context, arg ListSavedFoodsParams) ([]UserSavedFood, error) {
-	rows, err := q.query(ctx, q.listSavedFoodsStmt, listSavedFoods,
-		arg.UserID,
-		arg.ListType,
-		arg.Limit,
-		arg.Offset,
-	)
+// Pages through foods with an ingredient statement but no parsed tree by ID for backfilling
+func (q *Queries) ListUnparsedFoods(ctx context.Context, arg ListUnparsedFoodsParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.listUnparsedFoodsStmt, listUnparsedFoods, arg.AfterID, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []UserSavedFood{}
+	items := []Food{}
 	for rows.Next() {
-		var i UserSavedFood
+		var i Food
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.FoodID,
-			&i.ListType,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
 			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
 		); err != nil {
 			return nil, err
 		}
@@ -479,6 +1861,154 @@ func (q *Queries) ListUserRatings(ctx context.Context, arg ListUserRatingsParams
 	return items, nil
 }
 
+const moveFoodIntakeLogs = `-- name: MoveFoodIntakeLogs :execrows
+UPDATE food_intake_logs SET food_id = $1::text
+WHERE food_id = $2::text
+`
+
+type MoveFoodIntakeLogsParams struct {
+	TargetID string `json:"target_id"`
+	SourceID string `json:"source_id"`
+}
+
+func (q *Queries) MoveFoodIntakeLogs(ctx context.Context, arg MoveFoodIntakeLogsParams) (int64, error) {
+	result, err := q.exec(ctx, q.moveFoodIntakeLogsStmt, moveFoodIntakeLogs, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const moveFoodRatings = `-- name: MoveFoodRatings :execrows
+UPDATE food_ratings SET food_id = $1::text, updated_at = NOW()
+WHERE food_id = $2::text
+  AND NOT EXISTS (
+      SELECT 1 FROM food_ratings existing
+      WHERE existing.user_id = food_ratings.user_id AND existing.food_id = $1::text
+  )
+`
+
+type MoveFoodRatingsParams struct {
+	TargetID string `json:"target_id"`
+	SourceID string `json:"source_id"`
+}
+
+// Moves ratings to the surviving food of a merge, except from users who
+// already rated it; their rating of the merged food is dropped with it
+func (q *Queries) MoveFoodRatings(ctx context.Context, arg MoveFoodRatingsParams) (int64, error) {
+	result, err := q.exec(ctx, q.moveFoodRatingsStmt, moveFoodRatings, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const moveFoodRedirects = `-- name: MoveFoodRedirects :exec
+UPDATE food_redirects SET food_id = $1::text
+WHERE food_id = $2::text
+`
+
+type MoveFoodRedirectsParams struct {
+	TargetID string `json:"target_id"`
+	SourceID string `json:"source_id"`
+}
+
+// Repoints earlier redirects to the merged food so redirects never chain
+func (q *Queries) MoveFoodRedirects(ctx context.Context, arg MoveFoodRedirectsParams) error {
+	_, err := q.exec(ctx, q.moveFoodRedirectsStmt, moveFoodRedirects, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveFoodSubmissions = `-- name: MoveFoodSubmissions :exec
+UPDATE food_submissions SET food_id = $1::text
+WHERE food_id = $2::text
+`
+
+type MoveFoodSubmissionsParams struct {
+	TargetID string `json:"target_id"`
+	SourceID string `json:"source_id"`
+}
+
+func (q *Queries) MoveFoodSubmissions(ctx context.Context, arg MoveFoodSubmissionsParams) error {
+	_, err := q.exec(ctx, q.moveFoodSubmissionsStmt, moveFoodSubmissions, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveFoodTranslations = `-- name: MoveFoodTranslations :execrows
+UPDATE food_translations SET food_id = $1::text, updated_at = CURRENT_TIMESTAMP
+WHERE food_id = $2::text
+  AND NOT EXISTS (
+      SELECT 1 FROM food_translations existing
+      WHERE existing.locale = food_translations.locale AND existing.food_id = $1::text
+  )
+`
+
+type MoveFoodTranslationsParams struct {
+	TargetID string `json:"target_id"`
+	SourceID string `json:"source_id"`
+}
+
+// Keeps the merged food's translations into locales the survivor lacks
+func (q *Queries) MoveFoodTranslations(ctx context.Context, arg MoveFoodTranslationsParams) (int64, error) {
+	result, err := q.exec(ctx, q.moveFoodTranslationsStmt, moveFoodTranslations, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const moveSavedFoods = `-- name: MoveSavedFoods :execrows
+UPDATE user_saved_foods SET food_id = $1::text
+WHERE food_id = $2::text
+  AND NOT EXISTS (
+      SELECT 1 FROM user_saved_foods existing
+      WHERE existing.user_id = user_saved_foods.user_id
+        AND existing.list_type = user_saved_foods.list_type
+        AND existing.food_id = $1::text
+  )
+`
+
+type MoveSavedFoodsParams struct {
+	TargetID string `json:"target_id"`
+	SourceID string `json:"source_id"`
+}
+
+func (q *Queries) MoveSavedFoods(ctx context.Context, arg MoveSavedFoodsParams) (int64, error) {
+	result, err := q.exec(ctx, q.moveSavedFoodsStmt, moveSavedFoods, arg.TargetID, arg.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreFood = `-- name: RestoreFood :execrows
+UPDATE foods SET retired_at = NULL, updated_at = NOW()
+WHERE id = $1 AND retired_at IS NOT NULL
+`
+
+func (q *Queries) RestoreFood(ctx context.Context, id string) (int64, error) {
+	result, err := q.exec(ctx, q.restoreFoodStmt, restoreFood, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retireFood = `-- name: RetireFood :execrows
+UPDATE foods SET retired_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND retired_at IS NULL
+`
+
+// Retires a food, keeping the ratings, saved foods and intake logs that
+// refer to it
+func (q *Queries) RetireFood(ctx context.Context, id string) (int64, error) {
+	result, err := q.exec(ctx, q.retireFoodStmt, retireFood, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const saveFood = `-- name: SaveFood :one
 INSERT INTO user_saved_foods (
     user_id,
@@ -509,26 +2039,48 @@ func (q *Queries) SaveFood(ctx context.Context, arg SaveFoodParams) (UserSavedFo
 	return i, err
 }
 
-const searchFoodsByName = `-- name: SearchFoodsByName :many
-SELECT id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at FROM foods
-WHERE name ILIKE '%' || $1 || '%'
-   OR EXISTS (
-       SELECT 1
-       FROM jsonb_array_elements_text(alternate_names) AS alt_name
-       WHERE alt_name ILIKE '%' || $1 || '%'
-   )
-ORDER BY name
-LIMIT $2 OFFSET $3
+const searchFoods = `-- name: SearchFoods :many
+SELECT foods.id, foods.name, foods.alternate_names, foods.description, foods.food_type, foods.source, foods.serving, foods.nutrition_100g, foods.ean_13, foods.labels, foods.package_size, foods.ingredients, foods.ingredient_analysis, foods.created_at, foods.updated_at, foods.search_vector, foods.invalid_barcode, foods.nutri_score_grade, foods.nutri_score_points, foods.nutri_score, foods.nova_group, foods.nova_markers, foods.ingredient_tree, foods.retired_at, foods.last_imported_at, foods.dataset FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = $1::text
+WHERE id IN (
+      SELECT id FROM foods WHERE search_vector @@ food_search_query($2::text, $1::text)
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = $1::text AND search_vector @@ food_search_query($2::text, $1::text)
+  )
+  AND retired_at IS NULL
+  AND ($3::text IS NULL OR (
+      -GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query($2::text, $1::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query($2::text, $1::text)))::float8, foods.name, foods.id
+  ) > ($4::float8, $5::text, $3::text))
+ORDER BY GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query($2::text, $1::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query($2::text, $1::text)))::float8 DESC, foods.name, foods.id
+LIMIT $6 OFFSET $7
 `
 
-type SearchFoodsByNameParams struct {
-	Column1 sql.NullString `json:"column_1"`
-	Limit   int32          `json:"limit"`
-	Offset  int32          `json:"offset"`
+type SearchFoodsParams struct {
+	Locale    string         `json:"locale"`
+	Query     string         `json:"query"`
+	AfterID   sql.NullString `json:"after_id"`
+	AfterRank float64        `json:"after_rank"`
+	AfterName string         `json:"after_name"`
+	RowLimit  int32          `json:"row_limit"`
+	RowOffset int32          `json:"row_offset"`
 }
 
-func (q *Queries) SearchFoodsByName(ctx context.Context, arg SearchFoodsByNameParams) ([]Food, error) {
-	rows, err := q.query(ctx, q.searchFoodsByNameStmt, searchFoodsByName, arg.Column1, arg.Limit, arg.Offset)
+// The food and its translation into the locale are matched in separate
+// branches of a UNION, so that each uses the GIN index on its search vector,
+// and ranked by the better of the two. Weights rank name matches above
+// alternate names, then description, then ingredients. The keyset compares
+// the negated rank so that every key sorts ascending.
+func (q *Queries) SearchFoods(ctx context.Context, arg SearchFoodsParams) ([]Food, error) {
+	rows, err := q.query(ctx, q.searchFoodsStmt, searchFoods,
+		arg.Locale,
+		arg.Query,
+		arg.AfterID,
+		arg.AfterRank,
+		arg.AfterName,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -552,6 +2104,17 @@ func (q *Queries) SearchFoodsByName(ctx context.Context, arg SearchFoodsByNamePa
 			&i.IngredientAnalysis,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
 		); err != nil {
 			return nil, err
 		}
@@ -566,6 +2129,177 @@ func (q *Queries) SearchFoodsByName(ctx context.Context, arg SearchFoodsByNamePa
 	return items, nil
 }
 
+const updateFood = `-- name: UpdateFood :one
+UPDATE foods
+SET
+    name = $1,
+    alternate_names = $2,
+    description = $3,
+    food_type = $4,
+    source = $5,
+    serving = $6,
+    nutrition_100g = $7,
+    ean_13 = $8,
+    labels = $9,
+    package_size = $10,
+    ingredients = $11,
+    ingredient_analysis = $12,
+    invalid_barcode = $13,
+    nutri_score_grade = $14,
+    nutri_score_points = $15,
+    nutri_score = $16,
+    nova_group = $17,
+    nova_markers = $18,
+    ingredient_tree = $19,
+    updated_at = NOW()
+WHERE id = $20
+RETURNING id, name, alternate_names, description, food_type, source, serving, nutrition_100g, ean_13, labels, package_size, ingredients, ingredient_analysis, created_at, updated_at, search_vector, invalid_barcode, nutri_score_grade, nutri_score_points, nutri_score, nova_group, nova_markers, ingredient_tree, retired_at, last_imported_at, dataset
+`
+
+type UpdateFoodParams struct {
+	Name               string                `json:"name"`
+	AlternateNames     pqtype.NullRawMessage `json:"alternate_names"`
+	Description        sql.NullString        `json:"description"`
+	FoodType           sql.NullString        `json:"food_type"`
+	Source             pqtype.NullRawMessage `json:"source"`
+	Serving            pqtype.NullRawMessage `json:"serving"`
+	Nutrition100g      pqtype.NullRawMessage `json:"nutrition_100g"`
+	Ean13              sql.NullString        `json:"ean_13"`
+	Labels             pqtype.NullRawMessage `json:"labels"`
+	PackageSize        pqtype.NullRawMessage `json:"package_size"`
+	Ingredients        sql.NullString        `json:"ingredients"`
+	IngredientAnalysis pqtype.NullRawMessage `json:"ingredient_analysis"`
+	InvalidBarcode     sql.NullString        `json:"invalid_barcode"`
+	NutriScoreGrade    sql.NullString        `json:"nutri_score_grade"`
+	NutriScorePoints   sql.NullInt16         `json:"nutri_score_points"`
+	NutriScore         pqtype.NullRawMessage `json:"nutri_score"`
+	NovaGroup          sql.NullInt16         `json:"nova_group"`
+	NovaMarkers        pqtype.NullRawMessage `json:"nova_markers"`
+	IngredientTree     pqtype.NullRawMessage `json:"ingredient_tree"`
+	ID                 string                `json:"id"`
+}
+
+func (q *Queries) UpdateFood(ctx context.Context, arg UpdateFoodParams) (Food, error) {
+	row := q.queryRow(ctx, q.updateFoodStmt, updateFood,
+		arg.Name,
+		arg.AlternateNames,
+		arg.Description,
+		arg.FoodType,
+		arg.Source,
+		arg.Serving,
+		arg.Nutrition100g,
+		arg.Ean13,
+		arg.Labels,
+		arg.PackageSize,
+		arg.Ingredients,
+		arg.IngredientAnalysis,
+		arg.InvalidBarcode,
+		arg.NutriScoreGrade,
+		arg.NutriScorePoints,
+		arg.NutriScore,
+		arg.NovaGroup,
+		arg.NovaMarkers,
+		arg.IngredientTree,
+		arg.ID,
+	)
+	var i Food
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AlternateNames,
+		&i.Description,
+		&i.FoodType,
+		&i.Source,
+		&i.Serving,
+		&i.Nutrition100g,
+		&i.Ean13,
+		&i.Labels,
+		&i.PackageSize,
+		&i.Ingredients,
+		&i.IngredientAnalysis,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SearchVector,
+		&i.InvalidBarcode,
+		&i.NutriScoreGrade,
+		&i.NutriScorePoints,
+		&i.NutriScore,
+		&i.NovaGroup,
+		&i.NovaMarkers,
+		&i.IngredientTree,
+		&i.RetiredAt,
+		&i.LastImportedAt,
+		&i.Dataset,
+	)
+	return i, err
+}
+
+const updateFoodIngredientTree = `-- name: UpdateFoodIngredientTree :exec
+UPDATE foods
+SET
+    ingredient_tree = $1
+WHERE id = $2
+`
+
+type UpdateFoodIngredientTreeParams struct {
+	IngredientTree pqtype.NullRawMessage `json:"ingredient_tree"`
+	ID             string                `json:"id"`
+}
+
+// Derived from the ingredient statement; does not touch updated_at
+func (q *Queries) UpdateFoodIngredientTree(ctx context.Context, arg UpdateFoodIngredientTreeParams) error {
+	_, err := q.exec(ctx, q.updateFoodIngredientTreeStmt, updateFoodIngredientTree, arg.IngredientTree, arg.ID)
+	return err
+}
+
+const updateFoodNova = `-- name: UpdateFoodNova :exec
+UPDATE foods
+SET
+    nova_group = $1,
+    nova_markers = $2
+WHERE id = $3
+`
+
+type UpdateFoodNovaParams struct {
+	NovaGroup   sql.NullInt16         `json:"nova_group"`
+	NovaMarkers pqtype.NullRawMessage `json:"nova_markers"`
+	ID          string                `json:"id"`
+}
+
+// Derived like the Nutri-Score; does not touch updated_at
+func (q *Queries) UpdateFoodNova(ctx context.Context, arg UpdateFoodNovaParams) error {
+	_, err := q.exec(ctx, q.updateFoodNovaStmt, updateFoodNova, arg.NovaGroup, arg.NovaMarkers, arg.ID)
+	return err
+}
+
+const updateFoodNutriScore = `-- name: UpdateFoodNutriScore :exec
+UPDATE foods
+SET
+    nutri_score_grade = $1,
+    nutri_score_points = $2,
+    nutri_score = $3
+WHERE id = $4
+`
+
+type UpdateFoodNutriScoreParams struct {
+	NutriScoreGrade  sql.NullString        `json:"nutri_score_grade"`
+	NutriScorePoints sql.NullInt16         `json:"nutri_score_points"`
+	NutriScore       pqtype.NullRawMessage `json:"nutri_score"`
+	ID               string                `json:"id"`
+}
+
+// Backfills a derived field, so updated_at is left alone and incremental
+// exports do not resend every food
+func (q *Queries) UpdateFoodNutriScore(ctx context.Context, arg UpdateFoodNutriScoreParams) error {
+	_, err := q.exec(ctx, q.updateFoodNutriScoreStmt, updateFoodNutriScore,
+		arg.NutriScoreGrade,
+		arg.NutriScorePoints,
+		arg.NutriScore,
+		arg.ID,
+	)
+	return err
+}
+
 const updateFoodRating = `-- name: UpdateFoodRating :one
 UPDATE food_ratings
 SET
@@ -602,3 +2336,51 @@ func (q *Queries) UpdateFoodRating(ctx context.Context, arg UpdateFoodRatingPara
 	)
 	return i, err
 }
+
+const upsertFoodTranslation = `-- name: UpsertFoodTranslation :one
+INSERT INTO food_translations (
+    food_id,
+    locale,
+    name,
+    alternate_names,
+    description
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (food_id, locale) DO UPDATE SET
+    name = EXCLUDED.name,
+    alternate_names = EXCLUDED.alternate_names,
+    description = EXCLUDED.description,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING food_id, locale, name, alternate_names, description, search_vector, created_at, updated_at
+`
+
+type UpsertFoodTranslationParams struct {
+	FoodID         string                `json:"food_id"`
+	Locale         string                `json:"locale"`
+	Name           string                `json:"name"`
+	AlternateNames pqtype.NullRawMessage `json:"alternate_names"`
+	Description    sql.NullString        `json:"description"`
+}
+
+func (q *Queries) UpsertFoodTranslation(ctx context.Context, arg UpsertFoodTranslationParams) (FoodTranslation, error) {
+	row := q.queryRow(ctx, q.upsertFoodTranslationStmt, upsertFoodTranslation,
+		arg.FoodID,
+		arg.Locale,
+		arg.Name,
+		arg.AlternateNames,
+		arg.Description,
+	)
+	var i FoodTranslation
+	err := row.Scan(
+		&i.FoodID,
+		&i.Locale,
+		&i.Name,
+		&i.AlternateNames,
+		&i.Description,
+		&i.SearchVector,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -6,6 +6,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -32,6 +33,27 @@ type AuditLog struct {
 	CreatedAt  sql.NullTime          `json:"created_at"`
 }
 
+type BodyMeasurement struct {
+	ID              uuid.UUID      `json:"id"`
+	UserID          uuid.UUID      `json:"user_id"`
+	MeasurementType string         `json:"measurement_type"`
+	Value           string         `json:"value"`
+	Unit            string         `json:"unit"`
+	MeasuredAt      time.Time      `json:"measured_at"`
+	Notes           sql.NullString `json:"notes"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+}
+
+type CalorieTargetAdjustment struct {
+	ID                 uuid.UUID     `json:"id"`
+	ProfileID          uuid.UUID     `json:"profile_id"`
+	PreviousTarget     sql.NullInt32 `json:"previous_target"`
+	NewTarget          int32         `json:"new_target"`
+	ObservedRateKgWeek string        `json:"observed_rate_kg_week"`
+	GoalRateKgWeek     string        `json:"goal_rate_kg_week"`
+	CreatedAt          sql.NullTime  `json:"created_at"`
+}
+
 type Food struct {
 	ID                 string                `json:"id"`
 	Name               string                `json:"name"`
@@ -48,6 +70,68 @@ type Food struct {
 	IngredientAnalysis pqtype.NullRawMessage `json:"ingredient_analysis"`
 	CreatedAt          sql.NullTime          `json:"created_at"`
 	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	SearchVector       interface{}           `json:"search_vector"`
+	InvalidBarcode     sql.NullString        `json:"invalid_barcode"`
+	NutriScoreGrade    sql.NullString        `json:"nutri_score_grade"`
+	NutriScorePoints   sql.NullInt16         `json:"nutri_score_points"`
+	NutriScore         pqtype.NullRawMessage `json:"nutri_score"`
+	NovaGroup          sql.NullInt16         `json:"nova_group"`
+	NovaMarkers        pqtype.NullRawMessage `json:"nova_markers"`
+	IngredientTree     pqtype.NullRawMessage `json:"ingredient_tree"`
+	RetiredAt          sql.NullTime          `json:"retired_at"`
+	LastImportedAt     sql.NullTime          `json:"last_imported_at"`
+	Dataset            sql.NullString        `json:"dataset"`
+}
+
+type FoodCategory struct {
+	ID        int32           `json:"id"`
+	Slug      string          `json:"slug"`
+	Name      string          `json:"name"`
+	ParentID  sql.NullInt32   `json:"parent_id"`
+	FoodTypes json.RawMessage `json:"food_types"`
+	CreatedAt sql.NullTime    `json:"created_at"`
+}
+
+type FoodImportStaging struct {
+	Line               int32                 `json:"line"`
+	Raw                string                `json:"raw"`
+	ID                 string                `json:"id"`
+	Name               string                `json:"name"`
+	AlternateNames     pqtype.NullRawMessage `json:"alternate_names"`
+	Description        sql.NullString        `json:"description"`
+	FoodType           sql.NullString        `json:"food_type"`
+	Source             pqtype.NullRawMessage `json:"source"`
+	Serving            pqtype.NullRawMessage `json:"serving"`
+	Nutrition100g      pqtype.NullRawMessage `json:"nutrition_100g"`
+	Ean13              sql.NullString        `json:"ean_13"`
+	Labels             pqtype.NullRawMessage `json:"labels"`
+	PackageSize        pqtype.NullRawMessage `json:"package_size"`
+	Ingredients        sql.NullString        `json:"ingredients"`
+	IngredientAnalysis pqtype.NullRawMessage `json:"ingredient_analysis"`
+	InvalidBarcode     sql.NullString        `json:"invalid_barcode"`
+	NutriScoreGrade    sql.NullString        `json:"nutri_score_grade"`
+	NutriScorePoints   sql.NullInt16         `json:"nutri_score_points"`
+	NutriScore         pqtype.NullRawMessage `json:"nutri_score"`
+	NovaGroup          sql.NullInt16         `json:"nova_group"`
+	NovaMarkers        pqtype.NullRawMessage `json:"nova_markers"`
+	IngredientTree     pqtype.NullRawMessage `json:"ingredient_tree"`
+	Translations       pqtype.NullRawMessage `json:"translations"`
+}
+
+type FoodIntakeLog struct {
+	ID         uuid.UUID      `json:"id"`
+	ProfileID  uuid.UUID      `json:"profile_id"`
+	FoodID     string         `json:"food_id"`
+	AmountG    string         `json:"amount_g"`
+	MealType   sql.NullString `json:"meal_type"`
+	ConsumedAt time.Time      `json:"consumed_at"`
+	CreatedAt  sql.NullTime   `json:"created_at"`
+}
+
+type FoodNutrientValue struct {
+	FoodID   string `json:"food_id"`
+	Nutrient string `json:"nutrient"`
+	Amount   string `json:"amount"`
 }
 
 type FoodRating struct {
@@ -60,6 +144,51 @@ type FoodRating struct {
 	UpdatedAt sql.NullTime   `json:"updated_at"`
 }
 
+type FoodRedirect struct {
+	OldID     string        `json:"old_id"`
+	FoodID    string        `json:"food_id"`
+	MergedBy  uuid.NullUUID `json:"merged_by"`
+	CreatedAt sql.NullTime  `json:"created_at"`
+}
+
+type FoodRevision struct {
+	ID                 uuid.UUID             `json:"id"`
+	FoodID             string                `json:"food_id"`
+	Action             string                `json:"action"`
+	AuthorID           uuid.NullUUID         `json:"author_id"`
+	Diff               json.RawMessage       `json:"diff"`
+	Before             pqtype.NullRawMessage `json:"before"`
+	After              pqtype.NullRawMessage `json:"after"`
+	RevertedRevisionID uuid.NullUUID         `json:"reverted_revision_id"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+}
+
+type FoodSubmission struct {
+	ID              uuid.UUID       `json:"id"`
+	SubmitterID     uuid.UUID       `json:"submitter_id"`
+	Status          string          `json:"status"`
+	Name            string          `json:"name"`
+	Ean13           sql.NullString  `json:"ean_13"`
+	Food            json.RawMessage `json:"food"`
+	RejectionReason sql.NullString  `json:"rejection_reason"`
+	ReviewerID      uuid.NullUUID   `json:"reviewer_id"`
+	ReviewedAt      sql.NullTime    `json:"reviewed_at"`
+	FoodID          sql.NullString  `json:"food_id"`
+	CreatedAt       sql.NullTime    `json:"created_at"`
+	UpdatedAt       sql.NullTime    `json:"updated_at"`
+}
+
+type FoodTranslation struct {
+	FoodID         string                `json:"food_id"`
+	Locale         string                `json:"locale"`
+	Name           string                `json:"name"`
+	AlternateNames pqtype.NullRawMessage `json:"alternate_names"`
+	Description    sql.NullString        `json:"description"`
+	SearchVector   interface{}           `json:"search_vector"`
+	CreatedAt      sql.NullTime          `json:"created_at"`
+	UpdatedAt      sql.NullTime          `json:"updated_at"`
+}
+
 type HealthCondition struct {
 	ID                      int32                 `json:"id"`
 	Name                    string                `json:"name"`
@@ -69,6 +198,30 @@ type HealthCondition struct {
 	CreatedAt               sql.NullTime          `json:"created_at"`
 }
 
+type Household struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	OwnerUserID uuid.UUID    `json:"owner_user_id"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+}
+
+type HouseholdInvitation struct {
+	ID           uuid.UUID    `json:"id"`
+	HouseholdID  uuid.UUID    `json:"household_id"`
+	InvitedBy    uuid.UUID    `json:"invited_by"`
+	InviteeEmail string       `json:"invitee_email"`
+	Status       string       `json:"status"`
+	CreatedAt    sql.NullTime `json:"created_at"`
+	RespondedAt  sql.NullTime `json:"responded_at"`
+}
+
+type HouseholdMember struct {
+	HouseholdID uuid.UUID    `json:"household_id"`
+	ProfileID   uuid.UUID    `json:"profile_id"`
+	JoinedAt    sql.NullTime `json:"joined_at"`
+}
+
 type RefreshToken struct {
 	ID        uuid.UUID    `json:"id"`
 	UserID    uuid.UUID    `json:"user_id"`
@@ -97,6 +250,8 @@ type User struct {
 	EmailVerified sql.NullBool   `json:"email_verified"`
 	MfaEnabled    sql.NullBool   `json:"mfa_enabled"`
 	MfaSecret     sql.NullString `json:"mfa_secret"`
+	Role          string         `json:"role"`
+	Locale        sql.NullString `json:"locale"`
 }
 
 type UserProfile struct {
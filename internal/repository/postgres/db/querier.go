@@ -7,28 +7,67 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddHouseholdMember(ctx context.Context, arg AddHouseholdMemberParams) error
 	CheckProfileExists(ctx context.Context, id uuid.UUID) (bool, error)
+	ClearFoodImportStaging(ctx context.Context) error
+	CountFilteredFoods(ctx context.Context, arg CountFilteredFoodsParams) (int64, error)
+	CountFoodRevisions(ctx context.Context, foodID string) (int64, error)
+	CountFoodSubmissionsByStatus(ctx context.Context, status string) (int64, error)
+	CountFoodSubmissionsBySubmitter(ctx context.Context, arg CountFoodSubmissionsBySubmitterParams) (int64, error)
 	CountFoods(ctx context.Context) (int64, error)
+	CountFoodsByType(ctx context.Context) ([]CountFoodsByTypeRow, error)
+	CountFoodsByTypes(ctx context.Context, foodTypes json.RawMessage) (int64, error)
+	CountFuzzySearchFoods(ctx context.Context, arg CountFuzzySearchFoodsParams) (int64, error)
+	CountSearchFoods(ctx context.Context, arg CountSearchFoodsParams) (int64, error)
+	CountStagedFoods(ctx context.Context) (int64, error)
+	CreateBodyMeasurement(ctx context.Context, arg CreateBodyMeasurementParams) (BodyMeasurement, error)
+	CreateCalorieTargetAdjustment(ctx context.Context, arg CreateCalorieTargetAdjustmentParams) (CalorieTargetAdjustment, error)
 	CreateFood(ctx context.Context, arg CreateFoodParams) (Food, error)
 	CreateFoodRating(ctx context.Context, arg CreateFoodRatingParams) (FoodRating, error)
+	CreateFoodRedirect(ctx context.Context, arg CreateFoodRedirectParams) error
+	CreateFoodRevision(ctx context.Context, arg CreateFoodRevisionParams) (FoodRevision, error)
+	CreateFoodSubmission(ctx context.Context, arg CreateFoodSubmissionParams) (FoodSubmission, error)
+	CreateHousehold(ctx context.Context, arg CreateHouseholdParams) (Household, error)
+	CreateHouseholdInvitation(ctx context.Context, arg CreateHouseholdInvitationParams) (HouseholdInvitation, error)
+	CreateIntakeLog(ctx context.Context, arg CreateIntakeLogParams) (FoodIntakeLog, error)
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateUserProfile(ctx context.Context, arg CreateUserProfileParams) (UserProfile, error)
+	DeclareFoodExportCursor(ctx context.Context, arg DeclareFoodExportCursorParams) error
+	DeleteBodyMeasurement(ctx context.Context, arg DeleteBodyMeasurementParams) (int64, error)
 	DeleteExpiredRefreshTokens(ctx context.Context) error
 	DeleteFood(ctx context.Context, id string) error
 	DeleteFoodRating(ctx context.Context, arg DeleteFoodRatingParams) error
+	DeleteFoodTranslation(ctx context.Context, arg DeleteFoodTranslationParams) error
+	DeleteHousehold(ctx context.Context, arg DeleteHouseholdParams) error
+	DeleteIntakeLog(ctx context.Context, arg DeleteIntakeLogParams) (int64, error)
 	DeleteSavedFood(ctx context.Context, arg DeleteSavedFoodParams) error
+	DeleteStagedFoodsAfterLine(ctx context.Context, line int32) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	DeleteUserProfile(ctx context.Context, arg DeleteUserProfileParams) error
+	FacetFoodLabels(ctx context.Context, arg FacetFoodLabelsParams) ([]FacetFoodLabelsRow, error)
+	FacetFoodTypes(ctx context.Context, arg FacetFoodTypesParams) ([]FacetFoodTypesRow, error)
+	FacetNutrientHistograms(ctx context.Context, arg FacetNutrientHistogramsParams) ([]FacetNutrientHistogramsRow, error)
+	FilterFoods(ctx context.Context, arg FilterFoodsParams) ([]Food, error)
+	FuzzySearchFoods(ctx context.Context, arg FuzzySearchFoodsParams) ([]Food, error)
 	GetDefaultUserProfile(ctx context.Context, userID uuid.UUID) (UserProfile, error)
 	GetFoodByEAN13(ctx context.Context, ean13 sql.NullString) (Food, error)
 	GetFoodByID(ctx context.Context, id string) (Food, error)
+	GetFoodNameSimilarity(ctx context.Context, arg GetFoodNameSimilarityParams) (float64, error)
 	GetFoodRating(ctx context.Context, arg GetFoodRatingParams) (FoodRating, error)
+	GetFoodRedirect(ctx context.Context, oldID string) (string, error)
+	GetFoodRevision(ctx context.Context, id uuid.UUID) (FoodRevision, error)
+	GetFoodSearchRank(ctx context.Context, arg GetFoodSearchRankParams) (float64, error)
+	GetFoodSubmission(ctx context.Context, id uuid.UUID) (FoodSubmission, error)
+	GetHouseholdByID(ctx context.Context, id uuid.UUID) (Household, error)
+	GetHouseholdInvitation(ctx context.Context, id uuid.UUID) (HouseholdInvitation, error)
+	GetLatestCalorieTargetAdjustment(ctx context.Context, profileID uuid.UUID) (CalorieTargetAdjustment, error)
 	GetProfileByIDDirect(ctx context.Context, id uuid.UUID) (UserProfile, error)
 	GetRefreshToken(ctx context.Context, token string) (RefreshToken, error)
 	GetSavedFood(ctx context.Context, arg GetSavedFoodParams) (UserSavedFood, error)
@@ -36,23 +75,73 @@ type Querier interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserProfileByID(ctx context.Context, id uuid.UUID) (UserProfile, error)
 	GetUserProfiles(ctx context.Context, userID uuid.UUID) ([]UserProfile, error)
+	InsertStagedFoods(ctx context.Context, arg InsertStagedFoodsParams) (int64, error)
+	KeepAdminEditedStagedFoods(ctx context.Context) ([]KeepAdminEditedStagedFoodsRow, error)
 	ListAllergens(ctx context.Context) ([]Allergen, error)
+	ListBodyMeasurements(ctx context.Context, arg ListBodyMeasurementsParams) ([]BodyMeasurement, error)
+	ListCalorieTargetAdjustments(ctx context.Context, profileID uuid.UUID) ([]CalorieTargetAdjustment, error)
+	ListDuplicateCandidates(ctx context.Context, arg ListDuplicateCandidatesParams) ([]ListDuplicateCandidatesRow, error)
+	ListFoodCategories(ctx context.Context) ([]FoodCategory, error)
+	ListFoodNames(ctx context.Context) ([]ListFoodNamesRow, error)
+	ListFoodRevisions(ctx context.Context, arg ListFoodRevisionsParams) ([]FoodRevision, error)
+	ListFoodSubmissionsByStatus(ctx context.Context, arg ListFoodSubmissionsByStatusParams) ([]FoodSubmission, error)
+	ListFoodSubmissionsBySubmitter(ctx context.Context, arg ListFoodSubmissionsBySubmitterParams) ([]FoodSubmission, error)
+	ListFoodTranslations(ctx context.Context, arg ListFoodTranslationsParams) ([]FoodTranslation, error)
 	ListFoods(ctx context.Context, arg ListFoodsParams) ([]Food, error)
 	ListFoodsByType(ctx context.Context, arg ListFoodsByTypeParams) ([]Food, error)
+	ListFoodsByTypes(ctx context.Context, arg ListFoodsByTypesParams) ([]Food, error)
 	ListHealthConditions(ctx context.Context) ([]HealthCondition, error)
+	ListHouseholdMembers(ctx context.Context, householdID uuid.UUID) ([]ListHouseholdMembersRow, error)
+	ListHouseholdsByUser(ctx context.Context, userID uuid.UUID) ([]Household, error)
+	ListIntakeLogsByProfile(ctx context.Context, arg ListIntakeLogsByProfileParams) ([]FoodIntakeLog, error)
+	ListPendingHouseholdInvitationsByEmail(ctx context.Context, inviteeEmail string) ([]HouseholdInvitation, error)
 	ListSavedFoods(ctx context.Context, arg ListSavedFoodsParams) ([]UserSavedFood, error)
+	ListTopFoodsByNutrient(ctx context.Context, arg ListTopFoodsByNutrientParams) ([]Food, error)
+	ListTranslationsForFood(ctx context.Context, foodID string) ([]FoodTranslation, error)
+	ListUnclassifiedFoods(ctx context.Context, arg ListUnclassifiedFoodsParams) ([]Food, error)
+	ListUngradedFoods(ctx context.Context, arg ListUngradedFoodsParams) ([]Food, error)
+	ListUnparsedFoods(ctx context.Context, arg ListUnparsedFoodsParams) ([]Food, error)
 	ListUserRatings(ctx context.Context, arg ListUserRatingsParams) ([]FoodRating, error)
+	MarkStagedFoodsImported(ctx context.Context, arg MarkStagedFoodsImportedParams) error
+	MoveFoodIntakeLogs(ctx context.Context, arg MoveFoodIntakeLogsParams) (int64, error)
+	MoveFoodRatings(ctx context.Context, arg MoveFoodRatingsParams) (int64, error)
+	MoveFoodRedirects(ctx context.Context, arg MoveFoodRedirectsParams) error
+	MoveFoodSubmissions(ctx context.Context, arg MoveFoodSubmissionsParams) error
+	MoveFoodTranslations(ctx context.Context, arg MoveFoodTranslationsParams) (int64, error)
+	MoveSavedFoods(ctx context.Context, arg MoveSavedFoodsParams) (int64, error)
+	RejectDuplicateStagedFoods(ctx context.Context) ([]RejectDuplicateStagedFoodsRow, error)
+	RejectExistingStagedFoods(ctx context.Context) ([]RejectExistingStagedFoodsRow, error)
+	RejectKnownBarcodeStagedFoods(ctx context.Context) ([]RejectKnownBarcodeStagedFoodsRow, error)
+	RejectMergedStagedFoods(ctx context.Context) ([]RejectMergedStagedFoodsRow, error)
+	RemoveHouseholdMember(ctx context.Context, arg RemoveHouseholdMemberParams) error
+	RestoreFood(ctx context.Context, id string) (int64, error)
+	RetireFood(ctx context.Context, id string) (int64, error)
+	RetireUnimportedFoods(ctx context.Context, arg RetireUnimportedFoodsParams) (int64, error)
+	ReviewFoodSubmission(ctx context.Context, arg ReviewFoodSubmissionParams) (FoodSubmission, error)
 	RevokeAllUserRefreshTokens(ctx context.Context, userID uuid.UUID) error
 	RevokeRefreshToken(ctx context.Context, token string) error
 	SaveFood(ctx context.Context, arg SaveFoodParams) (UserSavedFood, error)
-	SearchFoodsByName(ctx context.Context, arg SearchFoodsByNameParams) ([]Food, error)
+	SearchFoods(ctx context.Context, arg SearchFoodsParams) ([]Food, error)
 	SetProfileAsDefault(ctx context.Context, arg SetProfileAsDefaultParams) error
+	SetProfileCalorieTarget(ctx context.Context, arg SetProfileCalorieTargetParams) (int64, error)
+	SummarizeStagedFoods(ctx context.Context) (SummarizeStagedFoodsRow, error)
+	TryLockFoodImport(ctx context.Context) (bool, error)
+	UnlockFoodImport(ctx context.Context) error
+	UpdateChangedFoods(ctx context.Context, arg UpdateChangedFoodsParams) (int64, error)
+	UpdateFood(ctx context.Context, arg UpdateFoodParams) (Food, error)
+	UpdateFoodIngredientTree(ctx context.Context, arg UpdateFoodIngredientTreeParams) error
+	UpdateFoodNova(ctx context.Context, arg UpdateFoodNovaParams) error
+	UpdateFoodNutriScore(ctx context.Context, arg UpdateFoodNutriScoreParams) error
 	UpdateFoodRating(ctx context.Context, arg UpdateFoodRatingParams) (FoodRating, error)
+	UpdateFoodSubmissionFood(ctx context.Context, arg UpdateFoodSubmissionFoodParams) (FoodSubmission, error)
+	UpdateHouseholdInvitationStatus(ctx context.Context, arg UpdateHouseholdInvitationStatusParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserEmailVerification(ctx context.Context, arg UpdateUserEmailVerificationParams) error
 	UpdateUserLastLogin(ctx context.Context, id uuid.UUID) error
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UserProfile, error)
+	UpsertFoodTranslation(ctx context.Context, arg UpsertFoodTranslationParams) (FoodTranslation, error)
+	UpsertStagedTranslations(ctx context.Context) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -44,6 +44,41 @@ func (q *Queries) ListAllergens(ctx context.Context) ([]Allergen, error) {
 	return items, nil
 }
 
+const listFoodCategories = `-- name: ListFoodCategories :many
+SELECT id, slug, name, parent_id, food_types, created_at FROM food_categories
+ORDER BY name
+`
+
+func (q *Queries) ListFoodCategories(ctx context.Context) ([]FoodCategory, error) {
+	rows, err := q.query(ctx, q.listFoodCategoriesStmt, listFoodCategories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FoodCategory{}
+	for rows.Next() {
+		var i FoodCategory
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.Name,
+			&i.ParentID,
+			&i.FoodTypes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listHealthConditions = `-- name: ListHealthConditions :many
 SELECT id, name, description, nutrient_restrictions, nutrient_recommendations, created_at
 FROM health_conditions
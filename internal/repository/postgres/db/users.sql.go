@@ -26,7 +26,7 @@ INSERT INTO users (
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9
 )
-RETURNING id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret
+RETURNING id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret, role, locale
 `
 
 type CreateUserParams struct {
@@ -72,6 +72,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.EmailVerified,
 		&i.MfaEnabled,
 		&i.MfaSecret,
+		&i.Role,
+		&i.Locale,
 	)
 	return i, err
 }
@@ -87,7 +89,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret FROM users
+SELECT id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret, role, locale FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -112,12 +114,14 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.EmailVerified,
 		&i.MfaEnabled,
 		&i.MfaSecret,
+		&i.Role,
+		&i.Locale,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret FROM users
+SELECT id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret, role, locale FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -142,6 +146,8 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.EmailVerified,
 		&i.MfaEnabled,
 		&i.MfaSecret,
+		&i.Role,
+		&i.Locale,
 	)
 	return i, err
 }
@@ -156,9 +162,10 @@ SET
     height_cm = COALESCE($6, height_cm),
     weight_kg = COALESCE($7, weight_kg),
     activity_level = COALESCE($8, activity_level),
+    locale = COALESCE($9, locale),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret
+RETURNING id, email, password_hash, first_name, last_name, date_of_birth, gender, height_cm, weight_kg, activity_level, created_at, updated_at, last_login, account_status, email_verified, mfa_enabled, mfa_secret, role, locale
 `
 
 type UpdateUserParams struct {
@@ -170,6 +177,7 @@ type UpdateUserParams struct {
 	HeightCm      sql.NullString `json:"height_cm"`
 	WeightKg      sql.NullString `json:"weight_kg"`
 	ActivityLevel sql.NullString `json:"activity_level"`
+	Locale        sql.NullString `json:"locale"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -182,6 +190,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.HeightCm,
 		arg.WeightKg,
 		arg.ActivityLevel,
+		arg.Locale,
 	)
 	var i User
 	err := row.Scan(
@@ -202,6 +211,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.EmailVerified,
 		&i.MfaEnabled,
 		&i.MfaSecret,
+		&i.Role,
+		&i.Locale,
 	)
 	return i, err
 }
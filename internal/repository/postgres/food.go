@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
@@ -37,10 +38,13 @@ func (r *foodRepository) GetByEAN13(ean13 string) (*food.Food, error) {
 	return mapDbFoodToDomain(&f), nil
 }
 
-func (r *foodRepository) List(limit, offset int) ([]food.Food, error) {
+func (r *foodRepository) List(after *food.Cursor, limit, offset int) ([]food.Food, error) {
+	afterID, afterName := cursorPosition(after)
 	foods, err := r.queries.ListFoods(context.Background(), db.ListFoodsParams{
-		Limit:  int32(limit),
-		Offset: int32(offset),
+		AfterID:   afterID,
+		AfterName: afterName,
+		RowLimit:  int32(limit),
+		RowOffset: int32(offset),
 	})
 	if err != nil {
 		return nil, err
@@ -70,11 +74,152 @@ func (r *foodRepository) ListByType(foodType string, limit, offset int) ([]food.
 	return result, nil
 }
 
-func (r *foodRepository) Search(query string, limit, offset int) ([]food.Food, error) {
-	foods, err := r.queries.SearchFoodsByName(context.Background(), db.SearchFoodsByNameParams{
-		Column1: sql.NullString{String: query, Valid: true},
-		Limit:   int32(limit),
-		Offset:  int32(offset),
+func (r *foodRepository) ListByTypes(foodTypes []string, after *food.Cursor, limit, offset int) ([]food.Food, error) {
+	types, err := json.Marshal(foodTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, afterName := cursorPosition(after)
+	foods, err := r.queries.ListFoodsByTypes(context.Background(), db.ListFoodsByTypesParams{
+		FoodTypes: types,
+		AfterID:   afterID,
+		AfterName: afterName,
+		RowLimit:  int32(limit),
+		RowOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.Food, len(foods))
+	for i, f := range foods {
+		result[i] = *mapDbFoodToDomain(&f)
+	}
+	return result, nil
+}
+
+func (r *foodRepository) CountByTypes(foodTypes []string) (int64, error) {
+	types, err := json.Marshal(foodTypes)
+	if err != nil {
+		return 0, err
+	}
+	return r.queries.CountFoodsByTypes(context.Background(), types)
+}
+
+func (r *foodRepository) CountByType() ([]food.FacetCount, error) {
+	rows, err := r.queries.CountFoodsByType(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.FacetCount, len(rows))
+	for i, row := range rows {
+		result[i] = food.FacetCount{Value: row.FoodType.String, Count: row.FoodCount}
+	}
+	return result, nil
+}
+
+func (r *foodRepository) Search(query, locale string, after *food.Cursor, limit, offset int) ([]food.Food, error) {
+	afterID, afterName := cursorPosition(after)
+	foods, err := r.queries.SearchFoods(context.Background(), db.SearchFoodsParams{
+		Query:     query,
+		Locale:    locale,
+		AfterID:   afterID,
+		AfterRank: cursorKey(after, 0),
+		AfterName: afterName,
+		RowLimit:  int32(limit),
+		RowOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.Food, len(foods))
+	for i, f := range foods {
+		result[i] = *mapDbFoodToDomain(&f)
+	}
+	return result, nil
+}
+
+func (r *foodRepository) FuzzySearch(query, locale string, after *food.Cursor, limit, offset int) ([]food.Food, error) {
+	afterID, afterName := cursorPosition(after)
+	foods, err := r.queries.FuzzySearchFoods(context.Background(), db.FuzzySearchFoodsParams{
+		Query:           query,
+		Locale:          locale,
+		AfterID:         afterID,
+		AfterSimilarity: cursorKey(after, 0),
+		AfterName:       afterName,
+		RowLimit:        int32(limit),
+		RowOffset:       int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.Food, len(foods))
+	for i, f := range foods {
+		result[i] = *mapDbFoodToDomain(&f)
+	}
+	return result, nil
+}
+
+func (r *foodRepository) SearchRank(query, locale, id string) (float64, error) {
+	return r.queries.GetFoodSearchRank(context.Background(), db.GetFoodSearchRankParams{
+		Query:  query,
+		Locale: locale,
+		ID:     id,
+	})
+}
+
+func (r *foodRepository) NameSimilarity(query, locale, id string) (float64, error) {
+	return r.queries.GetFoodNameSimilarity(context.Background(), db.GetFoodNameSimilarityParams{
+		Query:  query,
+		Locale: locale,
+		ID:     id,
+	})
+}
+
+// cursorPosition returns the keyset parameters for the (name, id) a cursor
+// points at, with a NULL ID for the first page
+func cursorPosition(after *food.Cursor) (sql.NullString, string) {
+	if after == nil {
+		return sql.NullString{}, ""
+	}
+	return sql.NullString{String: after.ID, Valid: true}, after.Name
+}
+
+func cursorKey(after *food.Cursor, i int) float64 {
+	if after == nil {
+		return 0
+	}
+	return after.Key(i)
+}
+
+func (r *foodRepository) ListNames() ([]food.Name, error) {
+	rows, err := r.queries.ListFoodNames(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.Name, len(rows))
+	for i, row := range rows {
+		result[i] = food.Name{FoodID: row.ID, Name: row.Name}
+		json.Unmarshal(row.AlternateNames.RawMessage, &result[i].AlternateNames)
+	}
+	return result, nil
+}
+
+// ListTopByNutrient returns the foods richest in a nutrient, reading it from
+// the same keys as food.NutrientValue
+func (r *foodRepository) ListTopByNutrient(nutrient string, limit int) ([]food.Food, error) {
+	keys, err := json.Marshal(food.NutrientKeys(nutrient))
+	if err != nil {
+		return nil, err
+	}
+	foods, err := r.queries.ListTopFoodsByNutrient(context.Background(), db.ListTopFoodsByNutrientParams{
+		Nutrients: keys,
+		RowLimit:  int32(limit),
 	})
 	if err != nil {
 		return nil, err
@@ -91,7 +236,27 @@ func (r *foodRepository) Count() (int64, error) {
 	return r.queries.CountFoods(context.Background())
 }
 
+func (r *foodRepository) CountSearch(query, locale string) (int64, error) {
+	return r.queries.CountSearchFoods(context.Background(), db.CountSearchFoodsParams{
+		Query:  query,
+		Locale: locale,
+	})
+}
+
+func (r *foodRepository) CountFuzzySearch(query, locale string) (int64, error) {
+	return r.queries.CountFuzzySearchFoods(context.Background(), db.CountFuzzySearchFoodsParams{
+		Query:  query,
+		Locale: locale,
+	})
+}
+
 func (r *foodRepository) Create(food *food.Food) error {
+	_, err := r.queries.CreateFood(context.Background(), createFoodParams(food))
+	return err
+}
+
+// createFoodParams converts a food into the columns CreateFood sets
+func createFoodParams(food *food.Food) db.CreateFoodParams {
 	alternateNames, _ := json.Marshal(food.AlternateNames)
 	source, _ := json.Marshal(food.Source)
 	serving, _ := json.Marshal(food.Serving)
@@ -99,8 +264,11 @@ func (r *foodRepository) Create(food *food.Food) error {
 	labels, _ := json.Marshal(food.Labels)
 	packageSize, _ := json.Marshal(food.PackageSize)
 	ingredientAnalysis, _ := json.Marshal(food.IngredientAnalysis)
+	grade, points, nutriScore := nutriScoreColumns(food.NutriScore)
+	novaGroup, novaMarkers := novaColumns(food.NovaGroup, food.NovaMarkers)
+	ingredientTree := jsonColumn(food.IngredientTree, food.IngredientTree != nil)
 
-	_, err := r.queries.CreateFood(context.Background(), db.CreateFoodParams{
+	return db.CreateFoodParams{
 		ID:                 food.ID,
 		Name:               food.Name,
 		AlternateNames:     pqtype.NullRawMessage{RawMessage: alternateNames, Valid: true},
@@ -114,14 +282,233 @@ func (r *foodRepository) Create(food *food.Food) error {
 		PackageSize:        pqtype.NullRawMessage{RawMessage: packageSize, Valid: true},
 		Ingredients:        sql.NullString{String: food.Ingredients, Valid: food.Ingredients != ""},
 		IngredientAnalysis: pqtype.NullRawMessage{RawMessage: ingredientAnalysis, Valid: true},
-	})
-	return err
+		InvalidBarcode:     sql.NullString{String: food.InvalidBarcode, Valid: food.InvalidBarcode != ""},
+		NutriScoreGrade:    grade,
+		NutriScorePoints:   points,
+		NutriScore:         nutriScore,
+		NovaGroup:          novaGroup,
+		NovaMarkers:        novaMarkers,
+		IngredientTree:     ingredientTree,
+	}
+}
+
+// jsonColumn marshals value into a JSONB column value, NULL unless valid
+func jsonColumn(value interface{}, valid bool) pqtype.NullRawMessage {
+	if !valid {
+		return pqtype.NullRawMessage{}
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return pqtype.NullRawMessage{}
+	}
+	return pqtype.NullRawMessage{RawMessage: raw, Valid: true}
+}
+
+// nutriScoreColumns converts a Nutri-Score into its column values, all NULL
+// when the food is ungraded
+func nutriScoreColumns(score *food.NutriScore) (sql.NullString, sql.NullInt16, pqtype.NullRawMessage) {
+	if score == nil {
+		return sql.NullString{}, sql.NullInt16{}, pqtype.NullRawMessage{}
+	}
+	breakdown, _ := json.Marshal(score)
+	return sql.NullString{String: score.Grade, Valid: true},
+		sql.NullInt16{Int16: int16(score.Score), Valid: true},
+		pqtype.NullRawMessage{RawMessage: breakdown, Valid: true}
+}
+
+// novaColumns converts a NOVA classification into its column values, both
+// NULL when the food is unclassified
+func novaColumns(group int, markers []food.NovaMarker) (sql.NullInt16, pqtype.NullRawMessage) {
+	if group == 0 {
+		return sql.NullInt16{}, pqtype.NullRawMessage{}
+	}
+	raw, _ := json.Marshal(markers)
+	return sql.NullInt16{Int16: int16(group), Valid: true}, pqtype.NullRawMessage{RawMessage: raw, Valid: true}
 }
 
 func (r *foodRepository) Delete(id string) error {
 	return r.queries.DeleteFood(context.Background(), id)
 }
 
+func (r *foodRepository) Update(food *food.Food) error {
+	updated, err := r.queries.UpdateFood(context.Background(), updateFoodParams(food))
+	if err != nil {
+		return err
+	}
+	food.UpdatedAt = updated.UpdatedAt.Time
+	return nil
+}
+
+// updateFoodParams converts a food into the columns UpdateFood sets
+func updateFoodParams(food *food.Food) db.UpdateFoodParams {
+	p := createFoodParams(food)
+	return db.UpdateFoodParams{
+		ID:                 p.ID,
+		Name:               p.Name,
+		AlternateNames:     p.AlternateNames,
+		Description:        p.Description,
+		FoodType:           p.FoodType,
+		Source:             p.Source,
+		Serving:            p.Serving,
+		Nutrition100g:      p.Nutrition100g,
+		Ean13:              p.Ean13,
+		Labels:             p.Labels,
+		PackageSize:        p.PackageSize,
+		Ingredients:        p.Ingredients,
+		IngredientAnalysis: p.IngredientAnalysis,
+		InvalidBarcode:     p.InvalidBarcode,
+		NutriScoreGrade:    p.NutriScoreGrade,
+		NutriScorePoints:   p.NutriScorePoints,
+		NutriScore:         p.NutriScore,
+		NovaGroup:          p.NovaGroup,
+		NovaMarkers:        p.NovaMarkers,
+		IngredientTree:     p.IngredientTree,
+	}
+}
+
+// createRevision stores a revision in the transaction of the change it records
+func createRevision(ctx context.Context, q *db.Queries, rev *food.Revision) error {
+	diff, err := json.Marshal(rev.Diff)
+	if err != nil {
+		return err
+	}
+
+	var authorID, revertedID uuid.NullUUID
+	if rev.AuthorID != nil {
+		authorID = uuid.NullUUID{UUID: *rev.AuthorID, Valid: true}
+	}
+	if rev.RevertedRevisionID != nil {
+		revertedID = uuid.NullUUID{UUID: *rev.RevertedRevisionID, Valid: true}
+	}
+
+	created, err := q.CreateFoodRevision(ctx, db.CreateFoodRevisionParams{
+		FoodID:             rev.FoodID,
+		Action:             rev.Action,
+		AuthorID:           authorID,
+		Diff:               diff,
+		Before:             jsonColumn(rev.Before, rev.Before != nil),
+		After:              jsonColumn(rev.After, rev.After != nil),
+		RevertedRevisionID: revertedID,
+	})
+	if err != nil {
+		return err
+	}
+	rev.ID = created.ID
+	rev.CreatedAt = created.CreatedAt.Time
+	return nil
+}
+
+func (r *foodRepository) GetRevision(id uuid.UUID) (*food.Revision, error) {
+	rev, err := r.queries.GetFoodRevision(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	return mapDbRevisionToDomain(&rev), nil
+}
+
+func (r *foodRepository) ListRevisions(foodID string, limit, offset int) ([]food.Revision, error) {
+	revisions, err := r.queries.ListFoodRevisions(context.Background(), db.ListFoodRevisionsParams{
+		FoodID: foodID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.Revision, len(revisions))
+	for i, rev := range revisions {
+		result[i] = *mapDbRevisionToDomain(&rev)
+	}
+	return result, nil
+}
+
+func (r *foodRepository) CountRevisions(foodID string) (int64, error) {
+	return r.queries.CountFoodRevisions(context.Background(), foodID)
+}
+
+func (r *foodRepository) ListDuplicateCandidates(filter food.DuplicateFilter, limit int) ([]food.DuplicateCandidate, error) {
+	rows, err := r.queries.ListDuplicateCandidates(context.Background(), db.ListDuplicateCandidatesParams{
+		MinNameSimilarity: filter.MinNameSimilarity,
+		FoodType:          sql.NullString{String: filter.FoodType, Valid: filter.FoodType != ""},
+		FoodID:            sql.NullString{String: filter.FoodID, Valid: filter.FoodID != ""},
+		RowLimit:          int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.DuplicateCandidate, len(rows))
+	for i, row := range rows {
+		result[i] = food.DuplicateCandidate{
+			FoodID:         row.FoodID,
+			OtherID:        row.OtherID,
+			SameBarcode:    row.SameBarcode,
+			NameSimilarity: row.NameSimilarity,
+		}
+	}
+	return result, nil
+}
+
+func (r *foodRepository) GetRedirect(oldID string) (string, error) {
+	return r.queries.GetFoodRedirect(context.Background(), oldID)
+}
+
+func (r *foodRepository) ListTranslations(foodIDs []string, locale string) (map[string]*food.Translation, error) {
+	ids, err := json.Marshal(foodIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.queries.ListFoodTranslations(context.Background(), db.ListFoodTranslationsParams{
+		Locale:  locale,
+		FoodIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*food.Translation, len(rows))
+	for i := range rows {
+		result[rows[i].FoodID] = mapDbTranslationToDomain(&rows[i])
+	}
+	return result, nil
+}
+
+func (r *foodRepository) ListTranslationsForFood(foodID string) ([]food.Translation, error) {
+	rows, err := r.queries.ListTranslationsForFood(context.Background(), foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.Translation, len(rows))
+	for i := range rows {
+		result[i] = *mapDbTranslationToDomain(&rows[i])
+	}
+	return result, nil
+}
+
+func (r *foodRepository) UpsertTranslation(t *food.Translation) error {
+	result, err := r.queries.UpsertFoodTranslation(context.Background(), db.UpsertFoodTranslationParams{
+		FoodID:         t.FoodID,
+		Locale:         t.Locale,
+		Name:           t.Name,
+		AlternateNames: jsonColumn(t.AlternateNames, len(t.AlternateNames) > 0),
+		Description:    sql.NullString{String: t.Description, Valid: t.Description != ""},
+	})
+	if err != nil {
+		return err
+	}
+	*t = *mapDbTranslationToDomain(&result)
+	return nil
+}
+
+func (r *foodRepository) DeleteTranslation(foodID, locale string) error {
+	return r.queries.DeleteFoodTranslation(context.Background(), db.DeleteFoodTranslationParams{
+		FoodID: foodID,
+		Locale: locale,
+	})
+}
+
 func (r *foodRepository) CreateRating(rating *food.FoodRating) error {
 	result, err := r.queries.CreateFoodRating(context.Background(), db.CreateFoodRatingParams{
 		UserID:   rating.UserID,
@@ -247,11 +634,14 @@ func (r *foodRepository) DeleteSavedFood(userID uuid.UUID, foodID string, listTy
 func mapDbFoodToDomain(f *db.Food) *food.Food {
 	var alternateNames []string
 	var source []map[string]string
-	var serving map[string]interface{}
-	var nutrition map[string]interface{}
+	var serving food.Serving
+	var nutrition food.Nutrients
 	var labels []string
-	var packageSize map[string]interface{}
-	var ingredientAnalysis map[string]interface{}
+	var packageSize food.Serving
+	var ingredientAnalysis food.IngredientAnalysis
+	var nutriScore *food.NutriScore
+	var novaMarkers []food.NovaMarker
+	var ingredientTree *food.IngredientList
 
 	json.Unmarshal(f.AlternateNames.RawMessage, &alternateNames)
 	json.Unmarshal(f.Source.RawMessage, &source)
@@ -260,6 +650,17 @@ func mapDbFoodToDomain(f *db.Food) *food.Food {
 	json.Unmarshal(f.Labels.RawMessage, &labels)
 	json.Unmarshal(f.PackageSize.RawMessage, &packageSize)
 	json.Unmarshal(f.IngredientAnalysis.RawMessage, &ingredientAnalysis)
+	if f.NutriScore.Valid {
+		json.Unmarshal(f.NutriScore.RawMessage, &nutriScore)
+	}
+	json.Unmarshal(f.NovaMarkers.RawMessage, &novaMarkers)
+	if f.IngredientTree.Valid {
+		json.Unmarshal(f.IngredientTree.RawMessage, &ingredientTree)
+	}
+	var retiredAt *time.Time
+	if f.RetiredAt.Valid {
+		retiredAt = &f.RetiredAt.Time
+	}
 
 	return &food.Food{
 		ID:                 f.ID,
@@ -271,15 +672,61 @@ func mapDbFoodToDomain(f *db.Food) *food.Food {
 		Serving:            serving,
 		Nutrition100g:      nutrition,
 		EAN13:              f.Ean13.String,
+		InvalidBarcode:     f.InvalidBarcode.String,
 		Labels:             labels,
 		PackageSize:        packageSize,
 		Ingredients:        f.Ingredients.String,
 		IngredientAnalysis: ingredientAnalysis,
+		IngredientTree:     ingredientTree,
+		NutriScoreGrade:    f.NutriScoreGrade.String,
+		NutriScore:         nutriScore,
+		NovaGroup:          int(f.NovaGroup.Int16),
+		NovaMarkers:        novaMarkers,
+		RetiredAt:          retiredAt,
 		CreatedAt:          f.CreatedAt.Time,
 		UpdatedAt:          f.UpdatedAt.Time,
 	}
 }
 
+func mapDbRevisionToDomain(r *db.FoodRevision) *food.Revision {
+	rev := &food.Revision{
+		ID:        r.ID,
+		FoodID:    r.FoodID,
+		Action:    r.Action,
+		Diff:      map[string]food.FieldChange{},
+		CreatedAt: r.CreatedAt.Time,
+	}
+	json.Unmarshal(r.Diff, &rev.Diff)
+	if r.Before.Valid {
+		json.Unmarshal(r.Before.RawMessage, &rev.Before)
+	}
+	if r.After.Valid {
+		json.Unmarshal(r.After.RawMessage, &rev.After)
+	}
+	if r.AuthorID.Valid {
+		authorID := r.AuthorID.UUID
+		rev.AuthorID = &authorID
+	}
+	if r.RevertedRevisionID.Valid {
+		revertedID := r.RevertedRevisionID.UUID
+		rev.RevertedRevisionID = &revertedID
+	}
+	return rev
+}
+
+func mapDbTranslationToDomain(t *db.FoodTranslation) *food.Translation {
+	translation := &food.Translation{
+		FoodID:      t.FoodID,
+		Locale:      t.Locale,
+		Name:        t.Name,
+		Description: t.Description.String,
+		CreatedAt:   t.CreatedAt.Time,
+		UpdatedAt:   t.UpdatedAt.Time,
+	}
+	json.Unmarshal(t.AlternateNames.RawMessage, &translation.AlternateNames)
+	return translation
+}
+
 func mapDbRatingToDomain(r *db.FoodRating) *food.FoodRating {
 	return &food.FoodRating{
 		ID:        r.ID,
@@ -301,3 +748,216 @@ func mapDbSavedFoodToDomain(s *db.UserSavedFood) *food.SavedFood {
 		CreatedAt: s.CreatedAt.Time,
 	}
 }
+
+// foodFilterArgs holds the query parameters shared by the filter and facet queries
+type foodFilterArgs struct {
+	query          sql.NullString
+	foodType       sql.NullString
+	labelsAll      pqtype.NullRawMessage
+	labelsAny      pqtype.NullRawMessage
+	hasBarcode     sql.NullBool
+	grades         pqtype.NullRawMessage
+	nutrientRanges json.RawMessage
+	locale         string
+}
+
+func newFoodFilterArgs(filter food.SearchFilter) (foodFilterArgs, error) {
+	args := foodFilterArgs{
+		query:          sql.NullString{String: filter.Query, Valid: filter.Query != ""},
+		foodType:       sql.NullString{String: filter.FoodType, Valid: filter.FoodType != ""},
+		nutrientRanges: json.RawMessage("[]"),
+		locale:         filter.Locale,
+	}
+	if args.locale == "" {
+		args.locale = food.DefaultLocale
+	}
+	if filter.HasBarcode != nil {
+		args.hasBarcode = sql.NullBool{Bool: *filter.HasBarcode, Valid: true}
+	}
+	if len(filter.Labels) > 0 {
+		labels, err := json.Marshal(filter.Labels)
+		if err != nil {
+			return args, err
+		}
+		if filter.MatchAllLabels {
+			args.labelsAll = pqtype.NullRawMessage{RawMessage: labels, Valid: true}
+		} else {
+			args.labelsAny = pqtype.NullRawMessage{RawMessage: labels, Valid: true}
+		}
+	}
+	if len(filter.NutriScoreGrades) > 0 {
+		grades, err := json.Marshal(filter.NutriScoreGrades)
+		if err != nil {
+			return args, err
+		}
+		args.grades = pqtype.NullRawMessage{RawMessage: grades, Valid: true}
+	}
+	if len(filter.NutrientRanges) > 0 {
+		ranges, err := json.Marshal(filter.NutrientRanges)
+		if err != nil {
+			return args, err
+		}
+		args.nutrientRanges = ranges
+	}
+	return args, nil
+}
+
+func (r *foodRepository) Filter(filter food.SearchFilter, after *food.Cursor, limit, offset int) ([]food.Food, error) {
+	args, err := newFoodFilterArgs(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, afterName := cursorPosition(after)
+	foods, err := r.queries.FilterFoods(context.Background(), db.FilterFoodsParams{
+		Query:            args.query,
+		Locale:           args.locale,
+		FoodType:         args.foodType,
+		LabelsAll:        args.labelsAll,
+		LabelsAny:        args.labelsAny,
+		HasBarcode:       args.hasBarcode,
+		NutriScoreGrades: args.grades,
+		NutrientRanges:   args.nutrientRanges,
+		SortBy:           filter.Sort,
+		AfterID:          afterID,
+		AfterPoints:      cursorKey(after, 0),
+		AfterRank:        cursorKey(after, 1),
+		AfterName:        afterName,
+		RowLimit:         int32(limit),
+		RowOffset:        int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]food.Food, len(foods))
+	for i, f := range foods {
+		result[i] = *mapDbFoodToDomain(&f)
+	}
+	return result, nil
+}
+
+func (r *foodRepository) CountFiltered(filter food.SearchFilter) (int64, error) {
+	args, err := newFoodFilterArgs(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.queries.CountFilteredFoods(context.Background(), db.CountFilteredFoodsParams{
+		Query:            args.query,
+		Locale:           args.locale,
+		FoodType:         args.foodType,
+		LabelsAll:        args.labelsAll,
+		LabelsAny:        args.labelsAny,
+		HasBarcode:       args.hasBarcode,
+		NutriScoreGrades: args.grades,
+		NutrientRanges:   args.nutrientRanges,
+	})
+}
+
+func (r *foodRepository) Facets(filter food.SearchFilter, labelLimit int) (*food.Facets, error) {
+	ctx := context.Background()
+	args, err := newFoodFilterArgs(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &food.Facets{
+		FoodTypes: []food.FacetCount{},
+		Labels:    []food.FacetCount{},
+		Nutrients: []food.NutrientHistogram{},
+	}
+
+	types, err := r.queries.FacetFoodTypes(ctx, db.FacetFoodTypesParams{
+		Query:            args.query,
+		Locale:           args.locale,
+		FoodType:         args.foodType,
+		LabelsAll:        args.labelsAll,
+		LabelsAny:        args.labelsAny,
+		HasBarcode:       args.hasBarcode,
+		NutriScoreGrades: args.grades,
+		NutrientRanges:   args.nutrientRanges,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		if !t.FoodType.Valid || t.FoodType.String == "" {
+			continue
+		}
+		facets.FoodTypes = append(facets.FoodTypes, food.FacetCount{Value: t.FoodType.String, Count: t.FoodCount})
+	}
+
+	labels, err := r.queries.FacetFoodLabels(ctx, db.FacetFoodLabelsParams{
+		Query:            args.query,
+		Locale:           args.locale,
+		FoodType:         args.foodType,
+		LabelsAll:        args.labelsAll,
+		LabelsAny:        args.labelsAny,
+		HasBarcode:       args.hasBarcode,
+		NutriScoreGrades: args.grades,
+		NutrientRanges:   args.nutrientRanges,
+		LabelLimit:       int32(labelLimit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range labels {
+		facets.Labels = append(facets.Labels, food.FacetCount{Value: l.Label, Count: l.FoodCount})
+	}
+
+	bounds, err := json.Marshal(food.HistogramBounds)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := r.queries.FacetNutrientHistograms(ctx, db.FacetNutrientHistogramsParams{
+		HistogramBounds:  bounds,
+		Query:            args.query,
+		Locale:           args.locale,
+		FoodType:         args.foodType,
+		LabelsAll:        args.labelsAll,
+		LabelsAny:        args.labelsAny,
+		HasBarcode:       args.hasBarcode,
+		NutriScoreGrades: args.grades,
+		NutrientRanges:   args.nutrientRanges,
+	})
+	if err != nil {
+		return nil, err
+	}
+	facets.Nutrients = mapHistograms(buckets)
+
+	return facets, nil
+}
+
+// mapHistograms expands width_bucket counts into one histogram per nutrient,
+// including empty buckets, in the order of food.CanonicalNutrients
+func mapHistograms(rows []db.FacetNutrientHistogramsRow) []food.NutrientHistogram {
+	counts := map[string]map[int32]int64{}
+	for _, row := range rows {
+		if counts[row.Nutrient] == nil {
+			counts[row.Nutrient] = map[int32]int64{}
+		}
+		counts[row.Nutrient][row.Bucket] = row.FoodCount
+	}
+
+	histograms := []food.NutrientHistogram{}
+	for _, n := range food.CanonicalNutrients {
+		bounds, ok := food.HistogramBounds[n.Key]
+		if !ok {
+			continue
+		}
+		h := food.NutrientHistogram{Nutrient: n.Key, Unit: n.Unit}
+		for i := 0; i <= len(bounds); i++ {
+			bucket := food.HistogramBucket{Count: counts[n.Key][int32(i)]}
+			if i > 0 {
+				bucket.Min = &bounds[i-1]
+			}
+			if i < len(bounds) {
+				bucket.Max = &bounds[i]
+			}
+			h.Buckets = append(h.Buckets, bucket)
+		}
+		histograms = append(histograms, h)
+	}
+	return histograms
+}
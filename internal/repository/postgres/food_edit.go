@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+type foodEditRepository struct {
+	tm      *TransactionManager
+	queries *db.Queries
+}
+
+// NewFoodEditRepository creates a repository that writes admin edits to the
+// catalog. It needs the database itself, since each edit and its revision are
+// written in one transaction.
+func NewFoodEditRepository(sqlDB *sql.DB, queries *db.Queries) food.EditRepository {
+	return &foodEditRepository{
+		tm:      NewTransactionManager(sqlDB),
+		queries: queries,
+	}
+}
+
+func (r *foodEditRepository) Create(ctx context.Context, f *food.Food, rev *food.Revision) error {
+	return r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		q := r.queries.WithTx(tx)
+		created, err := q.CreateFood(ctx, createFoodParams(f))
+		if err != nil {
+			return err
+		}
+		f.CreatedAt = created.CreatedAt.Time
+		f.UpdatedAt = created.UpdatedAt.Time
+		return createRevision(ctx, q, rev)
+	})
+}
+
+func (r *foodEditRepository) Update(ctx context.Context, f *food.Food, rev *food.Revision) error {
+	return r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		q := r.queries.WithTx(tx)
+		updated, err := q.UpdateFood(ctx, updateFoodParams(f))
+		if err != nil {
+			return err
+		}
+		f.UpdatedAt = updated.UpdatedAt.Time
+		return createRevision(ctx, q, rev)
+	})
+}
+
+func (r *foodEditRepository) Retire(ctx context.Context, id string, rev *food.Revision) error {
+	return r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		q := r.queries.WithTx(tx)
+		retired, err := q.RetireFood(ctx, id)
+		if err != nil {
+			return err
+		}
+		if retired == 0 {
+			return sql.ErrNoRows
+		}
+		return createRevision(ctx, q, rev)
+	})
+}
+
+func (r *foodEditRepository) Restore(ctx context.Context, f *food.Food, rev *food.Revision) error {
+	return r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		q := r.queries.WithTx(tx)
+		if _, err := q.RestoreFood(ctx, f.ID); err != nil {
+			return err
+		}
+		updated, err := q.UpdateFood(ctx, updateFoodParams(f))
+		if err != nil {
+			return err
+		}
+		f.RetiredAt = nil
+		f.UpdatedAt = updated.UpdatedAt.Time
+		return createRevision(ctx, q, rev)
+	})
+}
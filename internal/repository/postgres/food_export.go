@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+// exportFetchSize is the number of rows fetched from the export cursor at a time
+const exportFetchSize = 500
+
+// fetchFoodExport reads the next rows of the cursor opened by
+// DeclareFoodExportCursor. sqlc cannot infer the columns of a FETCH, so it is
+// written by hand; the rows have the columns of the foods table.
+const fetchFoodExport = `FETCH FORWARD 500 FROM food_export`
+
+type foodExportRepository struct {
+	tm      *TransactionManager
+	queries *db.Queries
+}
+
+// NewFoodExportRepository creates a repository that streams the food catalog.
+// It needs the database itself, since cursors only live inside a transaction.
+func NewFoodExportRepository(sqlDB *sql.DB, queries *db.Queries) food.ExportRepository {
+	return &foodExportRepository{
+		tm:      NewTransactionManager(sqlDB),
+		queries: queries,
+	}
+}
+
+func (r *foodExportRepository) Export(ctx context.Context, filter food.ExportFilter, fn func(f *food.Food) error) error {
+	params := db.DeclareFoodExportCursorParams{
+		IncludeRetired: filter.IncludeRetired,
+		FoodType:       sql.NullString{String: filter.FoodType, Valid: filter.FoodType != ""},
+	}
+	if filter.UpdatedSince != nil {
+		params.UpdatedSince = sql.NullTime{Time: *filter.UpdatedSince, Valid: true}
+	}
+
+	return r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		if err := r.queries.WithTx(tx).DeclareFoodExportCursor(ctx, params); err != nil {
+			return err
+		}
+		for {
+			n, err := fetchExportBatch(ctx, tx, fn)
+			if err != nil {
+				return err
+			}
+			if n < exportFetchSize {
+				return nil
+			}
+		}
+	})
+}
+
+// fetchExportBatch fetches the next batch of the export cursor and passes each
+// food to fn, returning the number of rows fetched
+func fetchExportBatch(ctx context.Context, tx *sql.Tx, fn func(f *food.Food) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetchFoodExport)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var i db.Food
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AlternateNames,
+			&i.Description,
+			&i.FoodType,
+			&i.Source,
+			&i.Serving,
+			&i.Nutrition100g,
+			&i.Ean13,
+			&i.Labels,
+			&i.PackageSize,
+			&i.Ingredients,
+			&i.IngredientAnalysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.InvalidBarcode,
+			&i.NutriScoreGrade,
+			&i.NutriScorePoints,
+			&i.NutriScore,
+			&i.NovaGroup,
+			&i.NovaMarkers,
+			&i.IngredientTree,
+			&i.RetiredAt,
+			&i.LastImportedAt,
+			&i.Dataset,
+		); err != nil {
+			return n, err
+		}
+		n++
+		if err := fn(mapDbFoodToDomain(&i)); err != nil {
+			return n, err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return n, err
+	}
+	return n, rows.Err()
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+type foodMergeRepository struct {
+	tm      *TransactionManager
+	queries *db.Queries
+}
+
+// NewFoodMergeRepository creates a repository that merges duplicate foods.
+// It needs the database itself, since a merge spans several tables and must
+// not be left half done.
+func NewFoodMergeRepository(sqlDB *sql.DB, queries *db.Queries) food.MergeRepository {
+	return &foodMergeRepository{
+		tm:      NewTransactionManager(sqlDB),
+		queries: queries,
+	}
+}
+
+func (r *foodMergeRepository) Merge(ctx context.Context, sourceID, targetID string, mergedBy uuid.UUID, rev *food.Revision) (*food.MergeResult, error) {
+	result := &food.MergeResult{SourceID: sourceID, TargetID: targetID, Revision: rev}
+	err := r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		q := r.queries.WithTx(tx)
+		var err error
+
+		if result.Ratings, err = q.MoveFoodRatings(ctx, db.MoveFoodRatingsParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if result.SavedFoods, err = q.MoveSavedFoods(ctx, db.MoveSavedFoodsParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if result.IntakeLogs, err = q.MoveFoodIntakeLogs(ctx, db.MoveFoodIntakeLogsParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if result.Translations, err = q.MoveFoodTranslations(ctx, db.MoveFoodTranslationsParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if err := q.MoveFoodSubmissions(ctx, db.MoveFoodSubmissionsParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+		if err := q.MoveFoodRedirects(ctx, db.MoveFoodRedirectsParams{TargetID: targetID, SourceID: sourceID}); err != nil {
+			return err
+		}
+
+		// Whatever was not moved, such as a rating from a user who also rated
+		// the target, is deleted with the source
+		if err := q.DeleteFood(ctx, sourceID); err != nil {
+			return err
+		}
+		if err := q.CreateFoodRedirect(ctx, db.CreateFoodRedirectParams{
+			OldID:    sourceID,
+			FoodID:   targetID,
+			MergedBy: uuid.NullUUID{UUID: mergedBy, Valid: true},
+		}); err != nil {
+			return err
+		}
+		return createRevision(ctx, q, rev)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
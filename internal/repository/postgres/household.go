@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/household"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+type householdRepository struct {
+	queries *db.Queries
+}
+
+func NewHouseholdRepository(queries *db.Queries) household.Repository {
+	return &householdRepository{
+		queries: queries,
+	}
+}
+
+func (r *householdRepository) Create(ctx context.Context, h *household.Household) error {
+	result, err := r.queries.CreateHousehold(ctx, db.CreateHouseholdParams{
+		Name:        h.Name,
+		OwnerUserID: h.OwnerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	h.ID = result.ID
+	h.CreatedAt = result.CreatedAt.Time
+	h.UpdatedAt = result.UpdatedAt.Time
+	return nil
+}
+
+func (r *householdRepository) GetByID(ctx context.Context, id uuid.UUID) (*household.Household, error) {
+	result, err := r.queries.GetHouseholdByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	h := mapDbHouseholdToDomain(&result)
+	h.Members, err = r.ListMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (r *householdRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]household.Household, error) {
+	results, err := r.queries.ListHouseholdsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	households := make([]household.Household, len(results))
+	for i, h := range results {
+		households[i] = *mapDbHouseholdToDomain(&h)
+		households[i].Members, err = r.ListMembers(ctx, h.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return households, nil
+}
+
+func (r *householdRepository) Delete(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) error {
+	return r.queries.DeleteHousehold(ctx, db.DeleteHouseholdParams{
+		ID:          id,
+		OwnerUserID: ownerID,
+	})
+}
+
+func (r *householdRepository) AddMember(ctx context.Context, householdID uuid.UUID, profileID uuid.UUID) error {
+	return r.queries.AddHouseholdMember(ctx, db.AddHouseholdMemberParams{
+		HouseholdID: householdID,
+		ProfileID:   profileID,
+	})
+}
+
+func (r *householdRepository) RemoveMember(ctx context.Context, householdID uuid.UUID, profileID uuid.UUID) error {
+	return r.queries.RemoveHouseholdMember(ctx, db.RemoveHouseholdMemberParams{
+		HouseholdID: householdID,
+		ProfileID:   profileID,
+	})
+}
+
+func (r *householdRepository) ListMembers(ctx context.Context, householdID uuid.UUID) ([]household.Member, error) {
+	results, err := r.queries.ListHouseholdMembers(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]household.Member, len(results))
+	for i, m := range results {
+		members[i] = household.Member{
+			ProfileID:     m.ProfileID,
+			UserID:        m.UserID,
+			ProfileName:   m.ProfileName,
+			CalorieTarget: int(m.CalorieTarget.Int32),
+			JoinedAt:      m.JoinedAt.Time,
+		}
+	}
+	return members, nil
+}
+
+func (r *householdRepository) CreateInvitation(ctx context.Context, inv *household.Invitation) error {
+	result, err := r.queries.CreateHouseholdInvitation(ctx, db.CreateHouseholdInvitationParams{
+		HouseholdID:  inv.HouseholdID,
+		InvitedBy:    inv.InvitedBy,
+		InviteeEmail: strings.ToLower(inv.InviteeEmail),
+	})
+	if err != nil {
+		return err
+	}
+
+	*inv = *mapDbHouseholdInvitationToDomain(&result)
+	return nil
+}
+
+func (r *householdRepository) GetInvitation(ctx context.Context, id uuid.UUID) (*household.Invitation, error) {
+	result, err := r.queries.GetHouseholdInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapDbHouseholdInvitationToDomain(&result), nil
+}
+
+func (r *householdRepository) ListPendingInvitations(ctx context.Context, email string) ([]household.Invitation, error) {
+	results, err := r.queries.ListPendingHouseholdInvitationsByEmail(ctx, strings.ToLower(email))
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]household.Invitation, len(results))
+	for i, inv := range results {
+		invitations[i] = *mapDbHouseholdInvitationToDomain(&inv)
+	}
+	return invitations, nil
+}
+
+func (r *householdRepository) UpdateInvitationStatus(ctx context.Context, id uuid.UUID, status string) error {
+	return r.queries.UpdateHouseholdInvitationStatus(ctx, db.UpdateHouseholdInvitationStatusParams{
+		ID:     id,
+		Status: status,
+	})
+}
+
+func mapDbHouseholdToDomain(h *db.Household) *household.Household {
+	return &household.Household{
+		ID:        h.ID,
+		Name:      h.Name,
+		OwnerID:   h.OwnerUserID,
+		Members:   []household.Member{},
+		CreatedAt: h.CreatedAt.Time,
+		UpdatedAt: h.UpdatedAt.Time,
+	}
+}
+
+func mapDbHouseholdInvitationToDomain(inv *db.HouseholdInvitation) *household.Invitation {
+	result := &household.Invitation{
+		ID:           inv.ID,
+		HouseholdID:  inv.HouseholdID,
+		InvitedBy:    inv.InvitedBy,
+		InviteeEmail: inv.InviteeEmail,
+		Status:       inv.Status,
+		CreatedAt:    inv.CreatedAt.Time,
+	}
+	if inv.RespondedAt.Valid {
+		result.RespondedAt = &inv.RespondedAt.Time
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/intake"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+type intakeRepository struct {
+	queries *db.Queries
+}
+
+func NewIntakeRepository(queries *db.Queries) intake.Repository {
+	return &intakeRepository{
+		queries: queries,
+	}
+}
+
+func (r *intakeRepository) Create(ctx context.Context, entry *intake.Entry) error {
+	result, err := r.queries.CreateIntakeLog(ctx, db.CreateIntakeLogParams{
+		ProfileID:  entry.ProfileID,
+		FoodID:     entry.FoodID,
+		AmountG:    fmt.Sprintf("%.2f", entry.AmountGrams),
+		MealType:   sql.NullString{String: entry.MealType, Valid: entry.MealType != ""},
+		ConsumedAt: entry.ConsumedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	*entry = *mapDbIntakeLogToDomain(&result)
+	return nil
+}
+
+func (r *intakeRepository) ListByProfile(ctx context.Context, profileID uuid.UUID, from, to time.Time) ([]intake.Entry, error) {
+	results, err := r.queries.ListIntakeLogsByProfile(ctx, db.ListIntakeLogsByProfileParams{
+		ProfileID: profileID,
+		FromTime:  from,
+		ToTime:    to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]intake.Entry, len(results))
+	for i, l := range results {
+		entries[i] = *mapDbIntakeLogToDomain(&l)
+	}
+	return entries, nil
+}
+
+// Delete removes an entry of the profile's log. It returns sql.ErrNoRows
+// when the profile has no such entry.
+func (r *intakeRepository) Delete(ctx context.Context, id uuid.UUID, profileID uuid.UUID) error {
+	deleted, err := r.queries.DeleteIntakeLog(ctx, db.DeleteIntakeLogParams{
+		ID:        id,
+		ProfileID: profileID,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func mapDbIntakeLogToDomain(l *db.FoodIntakeLog) *intake.Entry {
+	amount, _ := strconv.ParseFloat(l.AmountG, 64)
+	return &intake.Entry{
+		ID:          l.ID,
+		ProfileID:   l.ProfileID,
+		FoodID:      l.FoodID,
+		AmountGrams: amount,
+		MealType:    l.MealType.String,
+		ConsumedAt:  l.ConsumedAt,
+		CreatedAt:   l.CreatedAt.Time,
+	}
+}
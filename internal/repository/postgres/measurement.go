@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/measurement"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+type measurementRepository struct {
+	tm      *TransactionManager
+	queries *db.Queries
+}
+
+// NewMeasurementRepository creates a measurement repository. It needs the
+// database itself, since a calorie adjustment and the profile target it sets
+// are written in one transaction.
+func NewMeasurementRepository(sqlDB *sql.DB, queries *db.Queries) measurement.Repository {
+	return &measurementRepository{
+		tm:      NewTransactionManager(sqlDB),
+		queries: queries,
+	}
+}
+
+func (r *measurementRepository) Create(ctx context.Context, m *measurement.Measurement) error {
+	result, err := r.queries.CreateBodyMeasurement(ctx, db.CreateBodyMeasurementParams{
+		UserID:          m.UserID,
+		MeasurementType: m.Type,
+		Value:           fmt.Sprintf("%.2f", m.Value),
+		Unit:            m.Unit,
+		MeasuredAt:      m.MeasuredAt,
+		Notes:           sql.NullString{String: m.Notes, Valid: m.Notes != ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	*m = *mapDbMeasurementToDomain(&result)
+	return nil
+}
+
+func (r *measurementRepository) List(ctx context.Context, userID uuid.UUID, measurementType string, from, to time.Time) ([]measurement.Measurement, error) {
+	results, err := r.queries.ListBodyMeasurements(ctx, db.ListBodyMeasurementsParams{
+		UserID:          userID,
+		MeasurementType: measurementType,
+		FromTime:        from,
+		ToTime:          to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	measurements := make([]measurement.Measurement, len(results))
+	for i, m := range results {
+		measurements[i] = *mapDbMeasurementToDomain(&m)
+	}
+	return measurements, nil
+}
+
+// Delete removes one of a user's measurements. It returns sql.ErrNoRows when
+// the user has no measurement with that ID.
+func (r *measurementRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	deleted, err := r.queries.DeleteBodyMeasurement(ctx, db.DeleteBodyMeasurementParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *measurementRepository) ApplyAdjustment(ctx context.Context, adjustment *measurement.CalorieAdjustment) error {
+	return r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		q := r.queries.WithTx(tx)
+		updated, err := q.SetProfileCalorieTarget(ctx, db.SetProfileCalorieTargetParams{
+			ID:             adjustment.ProfileID,
+			NewTarget:      int32(adjustment.NewTarget),
+			PreviousTarget: int32(adjustment.PreviousTarget),
+		})
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return sql.ErrNoRows
+		}
+
+		result, err := q.CreateCalorieTargetAdjustment(ctx, db.CreateCalorieTargetAdjustmentParams{
+			ProfileID:          adjustment.ProfileID,
+			PreviousTarget:     sql.NullInt32{Int32: int32(adjustment.PreviousTarget), Valid: adjustment.PreviousTarget != 0},
+			NewTarget:          int32(adjustment.NewTarget),
+			ObservedRateKgWeek: fmt.Sprintf("%.2f", adjustment.ObservedRateKgWeek),
+			GoalRateKgWeek:     fmt.Sprintf("%.2f", adjustment.GoalRateKgWeek),
+		})
+		if err != nil {
+			return err
+		}
+
+		*adjustment = *mapDbCalorieAdjustmentToDomain(&result)
+		return nil
+	})
+}
+
+func (r *measurementRepository) GetLatestAdjustment(ctx context.Context, profileID uuid.UUID) (*measurement.CalorieAdjustment, error) {
+	result, err := r.queries.GetLatestCalorieTargetAdjustment(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	return mapDbCalorieAdjustmentToDomain(&result), nil
+}
+
+func (r *measurementRepository) ListAdjustments(ctx context.Context, profileID uuid.UUID) ([]measurement.CalorieAdjustment, error) {
+	results, err := r.queries.ListCalorieTargetAdjustments(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments := make([]measurement.CalorieAdjustment, len(results))
+	for i, a := range results {
+		adjustments[i] = *mapDbCalorieAdjustmentToDomain(&a)
+	}
+	return adjustments, nil
+}
+
+func mapDbMeasurementToDomain(m *db.BodyMeasurement) *measurement.Measurement {
+	value, _ := strconv.ParseFloat(m.Value, 64)
+	return &measurement.Measurement{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Type:       m.MeasurementType,
+		Value:      value,
+		Unit:       m.Unit,
+		MeasuredAt: m.MeasuredAt,
+		Notes:      m.Notes.String,
+		CreatedAt:  m.CreatedAt.Time,
+	}
+}
+
+func mapDbCalorieAdjustmentToDomain(a *db.CalorieTargetAdjustment) *measurement.CalorieAdjustment {
+	observed, _ := strconv.ParseFloat(a.ObservedRateKgWeek, 64)
+	goal, _ := strconv.ParseFloat(a.GoalRateKgWeek, 64)
+	return &measurement.CalorieAdjustment{
+		ID:                 a.ID,
+		ProfileID:          a.ProfileID,
+		PreviousTarget:     int(a.PreviousTarget.Int32),
+		NewTarget:          int(a.NewTarget),
+		ObservedRateKgWeek: observed,
+		GoalRateKgWeek:     goal,
+		CreatedAt:          a.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,258 @@
+-- name: TryLockFoodImport :one
+-- Only one import at a time may use the staging table. The lock is held by
+-- the session, so it must be taken and released on the same connection.
+SELECT pg_try_advisory_lock(hashtext('food_import'))::boolean AS locked;
+
+-- name: UnlockFoodImport :exec
+SELECT pg_advisory_unlock(hashtext('food_import'));
+
+-- name: ClearFoodImportStaging :exec
+TRUNCATE food_import_staging;
+
+-- name: DeleteStagedFoodsAfterLine :exec
+-- Drops rows staged after the checkpoint of an interrupted import
+DELETE FROM food_import_staging
+WHERE line > sqlc.arg(line)::int;
+
+-- name: CountStagedFoods :one
+SELECT COUNT(*) FROM food_import_staging;
+
+-- name: RejectDuplicateStagedFoods :many
+-- Removes and returns the rows repeating the ID of an earlier row
+DELETE FROM food_import_staging s
+WHERE EXISTS (
+    SELECT 1 FROM food_import_staging e
+    WHERE e.id = s.id AND e.line < s.line
+)
+RETURNING s.line, s.raw, s.id;
+
+-- name: RejectMergedStagedFoods :many
+-- Removes and returns the rows for foods merged into another food, with the
+-- ID of that food, so that re-importing a dataset does not bring them back
+DELETE FROM food_import_staging s
+WHERE s.id IN (SELECT old_id FROM food_redirects)
+RETURNING s.line, s.raw, s.id,
+    (SELECT r.food_id FROM food_redirects r WHERE r.old_id = s.id)::text AS merged_into;
+
+-- name: RejectExistingStagedFoods :many
+-- Removes and returns the rows for foods already in the catalog, for imports
+-- that only add foods
+DELETE FROM food_import_staging s
+USING foods f
+WHERE f.id = s.id
+RETURNING s.line, s.raw, s.id;
+
+-- name: RejectKnownBarcodeStagedFoods :many
+-- Removes and returns the rows whose barcode belongs to another food, either
+-- in the catalog or on an earlier row, with the ID of that food
+DELETE FROM food_import_staging s
+WHERE s.ean_13 IS NOT NULL AND (
+    EXISTS (SELECT 1 FROM foods f WHERE f.ean_13 = s.ean_13 AND f.id <> s.id)
+    OR EXISTS (SELECT 1 FROM food_import_staging e WHERE e.ean_13 = s.ean_13 AND e.line < s.line)
+)
+RETURNING s.line, s.raw, s.id, s.ean_13,
+    COALESCE(
+        (SELECT f.id FROM foods f WHERE f.ean_13 = s.ean_13 AND f.id <> s.id ORDER BY f.id LIMIT 1),
+        (SELECT e.id FROM food_import_staging e WHERE e.ean_13 = s.ean_13 AND e.line < s.line ORDER BY e.line LIMIT 1)
+    )::text AS existing_id;
+
+-- name: KeepAdminEditedStagedFoods :many
+-- Removes and returns the rows for foods an admin has edited since they were
+-- last imported, so that upserts leave the admin's version alone
+DELETE FROM food_import_staging s
+USING foods f
+WHERE f.id = s.id AND EXISTS (
+    SELECT 1 FROM food_revisions r
+    WHERE r.food_id = f.id AND r.created_at > COALESCE(f.last_imported_at, '-infinity'::timestamptz)
+)
+RETURNING s.line, s.id;
+
+-- name: SummarizeStagedFoods :one
+-- Counts what merging the staged rows will do, before it is done. Columns are
+-- compared as stored, so JSON fields compare by value rather than by text.
+WITH compared AS (
+    SELECT
+        s.invalid_barcode,
+        s.nutri_score_grade,
+        s.nova_group,
+        s.translations,
+        f.id IS NULL AS is_new,
+        f.name IS DISTINCT FROM s.name AS name_changed,
+        f.alternate_names IS DISTINCT FROM s.alternate_names AS alternate_names_changed,
+        f.description IS DISTINCT FROM s.description AS description_changed,
+        f.food_type IS DISTINCT FROM s.food_type AS food_type_changed,
+        f.source IS DISTINCT FROM s.source AS source_changed,
+        f.serving IS DISTINCT FROM s.serving AS serving_changed,
+        f.nutrition_100g IS DISTINCT FROM s.nutrition_100g AS nutrition_100g_changed,
+        f.ean_13 IS DISTINCT FROM s.ean_13 AS ean_13_changed,
+        f.labels IS DISTINCT FROM s.labels AS labels_changed,
+        f.package_size IS DISTINCT FROM s.package_size AS package_size_changed,
+        f.ingredients IS DISTINCT FROM s.ingredients AS ingredients_changed,
+        f.ingredient_analysis IS DISTINCT FROM s.ingredient_analysis AS ingredient_analysis_changed,
+        f.invalid_barcode IS DISTINCT FROM s.invalid_barcode AS invalid_barcode_changed,
+        f.retired_at IS NOT NULL AS restored
+    FROM food_import_staging s
+    LEFT JOIN foods f ON f.id = s.id
+), flagged AS (
+    SELECT *, NOT is_new AND (
+        name_changed OR alternate_names_changed OR description_changed OR
+        food_type_changed OR source_changed OR serving_changed OR nutrition_100g_changed OR
+        ean_13_changed OR labels_changed OR package_size_changed OR ingredients_changed OR
+        ingredient_analysis_changed OR invalid_barcode_changed OR restored
+    ) AS is_changed
+    FROM compared
+)
+SELECT
+    (COUNT(*) FILTER (WHERE is_new))::bigint AS added,
+    (COUNT(*) FILTER (WHERE is_changed))::bigint AS changed,
+    (COUNT(*) FILTER (WHERE NOT is_new AND NOT is_changed))::bigint AS unchanged,
+    (COUNT(*) FILTER (WHERE invalid_barcode IS NOT NULL))::bigint AS invalid_barcodes,
+    (COUNT(*) FILTER (WHERE nutri_score_grade IS NULL))::bigint AS ungraded,
+    (COUNT(*) FILTER (WHERE nova_group IS NULL))::bigint AS unclassified,
+    (COALESCE(SUM(jsonb_array_length(COALESCE(translations, '[]'::jsonb))), 0))::bigint AS translations,
+    jsonb_build_object(
+        'name', COUNT(*) FILTER (WHERE is_changed AND name_changed),
+        'alternate_names', COUNT(*) FILTER (WHERE is_changed AND alternate_names_changed),
+        'description', COUNT(*) FILTER (WHERE is_changed AND description_changed),
+        'food_type', COUNT(*) FILTER (WHERE is_changed AND food_type_changed),
+        'source', COUNT(*) FILTER (WHERE is_changed AND source_changed),
+        'serving', COUNT(*) FILTER (WHERE is_changed AND serving_changed),
+        'nutrition_100g', COUNT(*) FILTER (WHERE is_changed AND nutrition_100g_changed),
+        'ean_13', COUNT(*) FILTER (WHERE is_changed AND ean_13_changed),
+        'labels', COUNT(*) FILTER (WHERE is_changed AND labels_changed),
+        'package_size', COUNT(*) FILTER (WHERE is_changed AND package_size_changed),
+        'ingredients', COUNT(*) FILTER (WHERE is_changed AND ingredients_changed),
+        'ingredient_analysis', COUNT(*) FILTER (WHERE is_changed AND ingredient_analysis_changed),
+        'invalid_barcode', COUNT(*) FILTER (WHERE is_changed AND invalid_barcode_changed),
+        'retired_at', COUNT(*) FILTER (WHERE is_changed AND restored)
+    )::jsonb AS field_changes
+FROM flagged;
+
+-- name: UpdateChangedFoods :execrows
+-- Updates the foods whose staged row differs, restoring retired ones
+UPDATE foods f
+SET
+    name = s.name,
+    alternate_names = s.alternate_names,
+    description = s.description,
+    food_type = s.food_type,
+    source = s.source,
+    serving = s.serving,
+    nutrition_100g = s.nutrition_100g,
+    ean_13 = s.ean_13,
+    labels = s.labels,
+    package_size = s.package_size,
+    ingredients = s.ingredients,
+    ingredient_analysis = s.ingredient_analysis,
+    invalid_barcode = s.invalid_barcode,
+    nutri_score_grade = s.nutri_score_grade,
+    nutri_score_points = s.nutri_score_points,
+    nutri_score = s.nutri_score,
+    nova_group = s.nova_group,
+    nova_markers = s.nova_markers,
+    ingredient_tree = s.ingredient_tree,
+    last_imported_at = sqlc.arg(imported_at)::timestamptz,
+    dataset = sqlc.arg(dataset)::text,
+    retired_at = NULL,
+    updated_at = NOW()
+FROM food_import_staging s
+WHERE f.id = s.id AND (
+    f.name IS DISTINCT FROM s.name OR f.alternate_names IS DISTINCT FROM s.alternate_names OR
+    f.description IS DISTINCT FROM s.description OR
+    f.food_type IS DISTINCT FROM s.food_type OR f.source IS DISTINCT FROM s.source OR
+    f.serving IS DISTINCT FROM s.serving OR
+    f.nutrition_100g IS DISTINCT FROM s.nutrition_100g OR
+    f.ean_13 IS DISTINCT FROM s.ean_13 OR f.labels IS DISTINCT FROM s.labels OR
+    f.package_size IS DISTINCT FROM s.package_size OR
+    f.ingredients IS DISTINCT FROM s.ingredients OR
+    f.ingredient_analysis IS DISTINCT FROM s.ingredient_analysis OR
+    f.invalid_barcode IS DISTINCT FROM s.invalid_barcode OR f.retired_at IS NOT NULL
+);
+
+-- name: MarkStagedFoodsImported :exec
+-- Stamps the foods the import saw, leaving updated_at alone for unchanged ones
+UPDATE foods f
+SET last_imported_at = sqlc.arg(imported_at)::timestamptz,
+    dataset = sqlc.arg(dataset)::text
+FROM food_import_staging s
+WHERE f.id = s.id AND (
+    f.last_imported_at IS DISTINCT FROM sqlc.arg(imported_at)::timestamptz OR
+    f.dataset IS DISTINCT FROM sqlc.arg(dataset)::text
+);
+
+-- name: InsertStagedFoods :execrows
+INSERT INTO foods (
+    id,
+    name,
+    alternate_names,
+    description,
+    food_type,
+    source,
+    serving,
+    nutrition_100g,
+    ean_13,
+    labels,
+    package_size,
+    ingredients,
+    ingredient_analysis,
+    invalid_barcode,
+    nutri_score_grade,
+    nutri_score_points,
+    nutri_score,
+    nova_group,
+    nova_markers,
+    ingredient_tree,
+    last_imported_at,
+    dataset
+)
+SELECT
+    s.id,
+    s.name,
+    s.alternate_names,
+    s.description,
+    s.food_type,
+    s.source,
+    s.serving,
+    s.nutrition_100g,
+    s.ean_13,
+    s.labels,
+    s.package_size,
+    s.ingredients,
+    s.ingredient_analysis,
+    s.invalid_barcode,
+    s.nutri_score_grade,
+    s.nutri_score_points,
+    s.nutri_score,
+    s.nova_group,
+    s.nova_markers,
+    s.ingredient_tree,
+    sqlc.arg(imported_at)::timestamptz,
+    sqlc.arg(dataset)::text
+FROM food_import_staging s
+WHERE NOT EXISTS (SELECT 1 FROM foods f WHERE f.id = s.id)
+ORDER BY s.line;
+
+-- name: UpsertStagedTranslations :execrows
+INSERT INTO food_translations (food_id, locale, name, alternate_names, description)
+SELECT s.id, t.locale, t.name, t.alternate_names, NULLIF(t.description, '')
+FROM food_import_staging s,
+     jsonb_to_recordset(s.translations) AS t(locale text, name text, alternate_names jsonb, description text)
+WHERE s.translations IS NOT NULL
+ON CONFLICT (food_id, locale) DO UPDATE SET
+    name = EXCLUDED.name,
+    alternate_names = EXCLUDED.alternate_names,
+    description = EXCLUDED.description,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: RetireUnimportedFoods :execrows
+-- Retires the foods of a dataset that an import run of it did not see,
+-- other than those an admin has edited since they were last imported
+UPDATE foods f SET retired_at = NOW(), updated_at = NOW()
+WHERE f.retired_at IS NULL
+  AND f.dataset = sqlc.arg(dataset)::text
+  AND f.last_imported_at IS NOT NULL
+  AND f.last_imported_at <> sqlc.arg(imported_at)::timestamptz
+  AND NOT EXISTS (
+      SELECT 1 FROM food_revisions r
+      WHERE r.food_id = f.id AND r.created_at > f.last_imported_at
+  );
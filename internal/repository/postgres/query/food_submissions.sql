@@ -0,0 +1,58 @@
+-- name: CreateFoodSubmission :one
+INSERT INTO food_submissions (
+    submitter_id,
+    name,
+    ean_13,
+    food
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetFoodSubmission :one
+SELECT * FROM food_submissions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListFoodSubmissionsBySubmitter :many
+SELECT * FROM food_submissions
+WHERE submitter_id = sqlc.arg(submitter_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountFoodSubmissionsBySubmitter :one
+SELECT COUNT(*) FROM food_submissions
+WHERE submitter_id = sqlc.arg(submitter_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text);
+
+-- name: ListFoodSubmissionsByStatus :many
+SELECT * FROM food_submissions
+WHERE status = sqlc.arg(status)::text
+ORDER BY created_at
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountFoodSubmissionsByStatus :one
+SELECT COUNT(*) FROM food_submissions
+WHERE status = $1;
+
+-- name: UpdateFoodSubmissionFood :one
+UPDATE food_submissions
+SET
+    name = $2,
+    ean_13 = $3,
+    food = $4,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: ReviewFoodSubmission :one
+UPDATE food_submissions
+SET
+    status = $2,
+    rejection_reason = $3,
+    reviewer_id = $4,
+    food_id = $5,
+    reviewed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
@@ -12,9 +12,16 @@ INSERT INTO foods (
     labels,
     package_size,
     ingredients,
-    ingredient_analysis
+    ingredient_analysis,
+    invalid_barcode,
+    nutri_score_grade,
+    nutri_score_points,
+    nutri_score,
+    nova_group,
+    nova_markers,
+    ingredient_tree
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 )
 RETURNING *;
 
@@ -27,34 +34,256 @@ SELECT * FROM foods
 WHERE ean_13 = $1 LIMIT 1;
 
 -- name: ListFoods :many
+-- Pages by offset, or by keyset after the (name, id) of a cursor when after_id is set
 SELECT * FROM foods
-ORDER BY name
-LIMIT $1 OFFSET $2;
+WHERE retired_at IS NULL
+  AND (sqlc.narg(after_id)::text IS NULL OR (name, id) > (sqlc.arg(after_name)::text, sqlc.narg(after_id)::text))
+ORDER BY name, id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
 
 -- name: ListFoodsByType :many
 SELECT * FROM foods
-WHERE food_type = $1
+WHERE food_type = $1 AND retired_at IS NULL
 ORDER BY name
 LIMIT $2 OFFSET $3;
 
--- name: SearchFoodsByName :many
+-- name: SearchFoods :many
+-- The food and its translation into the locale are matched in separate
+-- branches of a UNION, so that each uses the GIN index on its search vector,
+-- and ranked by the better of the two. Weights rank name matches above
+-- alternate names, then description, then ingredients. The keyset compares
+-- the negated rank so that every key sorts ascending.
+SELECT foods.* FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = sqlc.arg(locale)::text
+WHERE id IN (
+      SELECT id FROM foods WHERE search_vector @@ food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = sqlc.arg(locale)::text AND search_vector @@ food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)
+  )
+  AND retired_at IS NULL
+  AND (sqlc.narg(after_id)::text IS NULL OR (
+      -GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)))::float8, foods.name, foods.id
+  ) > (sqlc.arg(after_rank)::float8, sqlc.arg(after_name)::text, sqlc.narg(after_id)::text))
+ORDER BY GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)))::float8 DESC, foods.name, foods.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: GetFoodSearchRank :one
+SELECT GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)))::float8 AS rank
+FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = sqlc.arg(locale)::text
+WHERE foods.id = sqlc.arg(id);
+
+-- name: CountSearchFoods :one
+SELECT COUNT(*) FROM foods
+WHERE id IN (
+      SELECT id FROM foods WHERE search_vector @@ food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = sqlc.arg(locale)::text AND search_vector @@ food_search_query(sqlc.arg(query)::text, sqlc.arg(locale)::text)
+  )
+  AND retired_at IS NULL;
+
+-- name: FuzzySearchFoods :many
+-- Like SearchFoods, matches the food name and its translated name in separate
+-- branches so that each uses its trigram index
+SELECT foods.* FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = sqlc.arg(locale)::text
+WHERE id IN (
+      SELECT id FROM foods WHERE sqlc.arg(query)::text <% name
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = sqlc.arg(locale)::text AND sqlc.arg(query)::text <% name
+  )
+  AND retired_at IS NULL
+  AND (sqlc.narg(after_id)::text IS NULL OR (
+      -GREATEST(word_similarity(sqlc.arg(query)::text, foods.name), word_similarity(sqlc.arg(query)::text, ft.name))::float8, foods.name, foods.id
+  ) > (sqlc.arg(after_similarity)::float8, sqlc.arg(after_name)::text, sqlc.narg(after_id)::text))
+ORDER BY GREATEST(word_similarity(sqlc.arg(query)::text, foods.name), word_similarity(sqlc.arg(query)::text, ft.name))::float8 DESC, foods.name, foods.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: GetFoodNameSimilarity :one
+SELECT GREATEST(word_similarity(sqlc.arg(query)::text, foods.name), word_similarity(sqlc.arg(query)::text, ft.name))::float8 AS similarity
+FROM foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = sqlc.arg(locale)::text
+WHERE foods.id = sqlc.arg(id);
+
+-- name: CountFuzzySearchFoods :one
+SELECT COUNT(*) FROM foods
+WHERE id IN (
+      SELECT id FROM foods WHERE sqlc.arg(query)::text <% name
+      UNION
+      SELECT food_id FROM food_translations
+      WHERE locale = sqlc.arg(locale)::text AND sqlc.arg(query)::text <% name
+  )
+  AND retired_at IS NULL;
+
+-- name: FilterFoods :many
+-- Pages through filter_foods, which CountFilteredFoods and the facet queries
+-- below select from too. The translation joined in only contributes to the rank.
+-- Ungraded foods sort last under the nutri_score ordering through a sentinel
+-- above any real score, and the rank is negated to sort ascending.
+SELECT foods.* FROM filter_foods(
+    sqlc.narg(query)::text, sqlc.arg(locale)::text, sqlc.narg(food_type)::text,
+    sqlc.narg(labels_all)::jsonb, sqlc.narg(labels_any)::jsonb, sqlc.narg(has_barcode)::boolean,
+    sqlc.narg(nutri_score_grades)::jsonb, sqlc.arg(nutrient_ranges)::jsonb
+) AS foods
+LEFT JOIN food_translations ft ON ft.food_id = foods.id AND ft.locale = sqlc.arg(locale)::text
+WHERE sqlc.narg(after_id)::text IS NULL OR (
+    (CASE WHEN sqlc.arg(sort_by)::text = 'nutri_score' THEN COALESCE(nutri_score_points, 32767) ELSE 0 END)::float8,
+    (CASE WHEN sqlc.narg(query)::text IS NULL THEN 0
+          ELSE -GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query(sqlc.narg(query)::text, sqlc.arg(locale)::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query(sqlc.narg(query)::text, sqlc.arg(locale)::text)))::float8
+     END)::float8,
+    foods.name, foods.id
+) > (sqlc.arg(after_points)::float8, sqlc.arg(after_rank)::float8, sqlc.arg(after_name)::text, sqlc.narg(after_id)::text)
+ORDER BY
+    CASE WHEN sqlc.arg(sort_by)::text = 'nutri_score' THEN COALESCE(nutri_score_points, 32767) ELSE 0 END,
+    CASE WHEN sqlc.narg(query)::text IS NULL THEN 0
+         ELSE GREATEST(ts_rank('{0.1, 0.2, 0.4, 1.0}', foods.search_vector, food_search_query(sqlc.narg(query)::text, sqlc.arg(locale)::text)), ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, food_search_query(sqlc.narg(query)::text, sqlc.arg(locale)::text)))::float8
+    END DESC,
+    foods.name, foods.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountFilteredFoods :one
+SELECT COUNT(*) FROM filter_foods(
+    sqlc.narg(query)::text, sqlc.arg(locale)::text, sqlc.narg(food_type)::text,
+    sqlc.narg(labels_all)::jsonb, sqlc.narg(labels_any)::jsonb, sqlc.narg(has_barcode)::boolean,
+    sqlc.narg(nutri_score_grades)::jsonb, sqlc.arg(nutrient_ranges)::jsonb
+) AS foods;
+
+-- name: FacetFoodTypes :many
+SELECT food_type, COUNT(*) AS food_count FROM filter_foods(
+    sqlc.narg(query)::text, sqlc.arg(locale)::text, sqlc.narg(food_type)::text,
+    sqlc.narg(labels_all)::jsonb, sqlc.narg(labels_any)::jsonb, sqlc.narg(has_barcode)::boolean,
+    sqlc.narg(nutri_score_grades)::jsonb, sqlc.arg(nutrient_ranges)::jsonb
+) AS foods
+GROUP BY food_type
+ORDER BY food_count DESC, food_type;
+
+-- name: FacetFoodLabels :many
+SELECT facet.label::text AS label, COUNT(*) AS food_count
+FROM filter_foods(
+    sqlc.narg(query)::text, sqlc.arg(locale)::text, sqlc.narg(food_type)::text,
+    sqlc.narg(labels_all)::jsonb, sqlc.narg(labels_any)::jsonb, sqlc.narg(has_barcode)::boolean,
+    sqlc.narg(nutri_score_grades)::jsonb, sqlc.arg(nutrient_ranges)::jsonb
+) AS foods, jsonb_array_elements_text(COALESCE(labels, '[]'::jsonb)) AS facet(label)
+GROUP BY facet.label
+ORDER BY food_count DESC, label
+LIMIT sqlc.arg(label_limit);
+
+-- name: FacetNutrientHistograms :many
+-- Buckets each nutrient in the histogram_bounds object ({"protein": [5, 10, 20]})
+-- with width_bucket: 0 is below the first bound, len(bounds) at or above the last
+SELECT
+    h.nutrient::text AS nutrient,
+    width_bucket(
+        (nutrition_100g ->> h.nutrient)::numeric,
+        ARRAY(SELECT jsonb_array_elements_text(h.bounds)::numeric)
+    )::int AS bucket,
+    COUNT(*) AS food_count
+FROM filter_foods(
+    sqlc.narg(query)::text, sqlc.arg(locale)::text, sqlc.narg(food_type)::text,
+    sqlc.narg(labels_all)::jsonb, sqlc.narg(labels_any)::jsonb, sqlc.narg(has_barcode)::boolean,
+    sqlc.narg(nutri_score_grades)::jsonb, sqlc.arg(nutrient_ranges)::jsonb
+) AS foods, jsonb_each(sqlc.arg(histogram_bounds)::jsonb) AS h(nutrient, bounds)
+WHERE jsonb_typeof(nutrition_100g -> h.nutrient) = 'number'
+GROUP BY h.nutrient, bucket
+ORDER BY h.nutrient, bucket;
+
+-- name: ListUngradedFoods :many
+-- Pages through foods without a Nutri-Score by ID for backfilling
 SELECT * FROM foods
-WHERE name ILIKE '%' || $1 || '%'
-   OR EXISTS (
-       SELECT 1
-       FROM jsonb_array_elements_text(alternate_names) AS alt_name
-       WHERE alt_name ILIKE '%' || $1 || '%'
-   )
-ORDER BY name
-LIMIT $2 OFFSET $3;
+WHERE nutri_score_grade IS NULL AND id > sqlc.arg(after_id)::text
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateFoodNutriScore :exec
+-- Backfills a derived field, so updated_at is left alone and incremental
+-- exports do not resend every food
+UPDATE foods
+SET
+    nutri_score_grade = sqlc.arg(nutri_score_grade),
+    nutri_score_points = sqlc.arg(nutri_score_points),
+    nutri_score = sqlc.arg(nutri_score)
+WHERE id = sqlc.arg(id);
+
+-- name: ListUnclassifiedFoods :many
+-- Pages through foods without a NOVA group by ID for backfilling
+SELECT * FROM foods
+WHERE nova_group IS NULL AND id > sqlc.arg(after_id)::text
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateFoodNova :exec
+-- Derived like the Nutri-Score; does not touch updated_at
+UPDATE foods
+SET
+    nova_group = sqlc.arg(nova_group),
+    nova_markers = sqlc.arg(nova_markers)
+WHERE id = sqlc.arg(id);
+
+-- name: ListUnparsedFoods :many
+-- Pages through foods with an ingredient statement but no parsed tree by ID for backfilling
+SELECT * FROM foods
+WHERE ingredient_tree IS NULL AND COALESCE(ingredients, '') <> '' AND id > sqlc.arg(after_id)::text
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateFoodIngredientTree :exec
+-- Derived from the ingredient statement; does not touch updated_at
+UPDATE foods
+SET
+    ingredient_tree = sqlc.arg(ingredient_tree)
+WHERE id = sqlc.arg(id);
+
+-- name: ListFoodNames :many
+SELECT id, name, alternate_names FROM foods
+WHERE retired_at IS NULL;
 
 -- name: CountFoods :one
-SELECT COUNT(*) FROM foods;
+SELECT COUNT(*) FROM foods
+WHERE retired_at IS NULL;
+
+-- name: UpdateFood :one
+UPDATE foods
+SET
+    name = sqlc.arg(name),
+    alternate_names = sqlc.arg(alternate_names),
+    description = sqlc.arg(description),
+    food_type = sqlc.arg(food_type),
+    source = sqlc.arg(source),
+    serving = sqlc.arg(serving),
+    nutrition_100g = sqlc.arg(nutrition_100g),
+    ean_13 = sqlc.arg(ean_13),
+    labels = sqlc.arg(labels),
+    package_size = sqlc.arg(package_size),
+    ingredients = sqlc.arg(ingredients),
+    ingredient_analysis = sqlc.arg(ingredient_analysis),
+    invalid_barcode = sqlc.arg(invalid_barcode),
+    nutri_score_grade = sqlc.arg(nutri_score_grade),
+    nutri_score_points = sqlc.arg(nutri_score_points),
+    nutri_score = sqlc.arg(nutri_score),
+    nova_group = sqlc.arg(nova_group),
+    nova_markers = sqlc.arg(nova_markers),
+    ingredient_tree = sqlc.arg(ingredient_tree),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
 
 -- name: DeleteFood :exec
 DELETE FROM foods
 WHERE id = $1;
 
+-- name: RetireFood :execrows
+-- Retires a food, keeping the ratings, saved foods and intake logs that
+-- refer to it
+UPDATE foods SET retired_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND retired_at IS NULL;
+
+-- name: RestoreFood :execrows
+UPDATE foods SET retired_at = NULL, updated_at = NOW()
+WHERE id = $1 AND retired_at IS NOT NULL;
+
 -- name: CreateFoodRating :one
 INSERT INTO food_ratings (
     user_id,
@@ -114,3 +343,184 @@ LIMIT $3 OFFSET $4;
 -- name: DeleteSavedFood :exec
 DELETE FROM user_saved_foods
 WHERE user_id = $1 AND food_id = $2 AND list_type = $3;
+
+-- name: ListTopFoodsByNutrient :many
+-- Ranks foods by a nutrient stored under any of the keys in the nutrients
+-- array, such as "dietary_fiber", "fiber" and "fibre". A food storing it
+-- under several keys is ranked by the first of them.
+SELECT f.* FROM (
+    SELECT DISTINCT ON (v.food_id) v.food_id, v.amount
+    FROM food_nutrient_values v
+    JOIN jsonb_array_elements_text(sqlc.arg(nutrients)::jsonb) WITH ORDINALITY AS k(nutrient, priority) ON k.nutrient = v.nutrient
+    ORDER BY v.food_id, k.priority
+) ranked
+JOIN foods f ON f.id = ranked.food_id
+WHERE f.retired_at IS NULL
+ORDER BY ranked.amount DESC, f.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountFoodsByType :many
+SELECT food_type, COUNT(*) AS food_count FROM foods
+WHERE food_type IS NOT NULL AND food_type <> '' AND retired_at IS NULL
+GROUP BY food_type
+ORDER BY food_count DESC, food_type;
+
+-- name: ListFoodsByTypes :many
+SELECT * FROM foods
+WHERE food_type IN (SELECT jsonb_array_elements_text(sqlc.arg(food_types)::jsonb))
+  AND retired_at IS NULL
+  AND (sqlc.narg(after_id)::text IS NULL OR (name, id) > (sqlc.arg(after_name)::text, sqlc.narg(after_id)::text))
+ORDER BY name, id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountFoodsByTypes :one
+SELECT COUNT(*) FROM foods
+WHERE food_type IN (SELECT jsonb_array_elements_text(sqlc.arg(food_types)::jsonb))
+  AND retired_at IS NULL;
+
+-- name: CreateFoodRevision :one
+INSERT INTO food_revisions (
+    food_id,
+    action,
+    author_id,
+    diff,
+    before,
+    after,
+    reverted_revision_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetFoodRevision :one
+SELECT * FROM food_revisions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListFoodRevisions :many
+SELECT * FROM food_revisions
+WHERE food_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountFoodRevisions :one
+SELECT COUNT(*) FROM food_revisions
+WHERE food_id = $1;
+
+-- name: DeclareFoodExportCursor :exec
+-- Opens the food_export cursor over the foods to export, in ID order. Only
+-- valid inside a transaction; rows are read with FETCH. Retired foods are
+-- left out unless include_retired is set.
+DECLARE food_export NO SCROLL CURSOR FOR
+SELECT * FROM foods
+WHERE (retired_at IS NULL OR sqlc.arg(include_retired)::boolean)
+  AND (sqlc.narg(food_type)::text IS NULL OR food_type = sqlc.narg(food_type)::text)
+  AND (sqlc.narg(updated_since)::timestamptz IS NULL OR updated_at >= sqlc.narg(updated_since)::timestamptz)
+ORDER BY id;
+
+-- name: ListFoodTranslations :many
+SELECT * FROM food_translations
+WHERE locale = sqlc.arg(locale)::text
+  AND food_id IN (SELECT jsonb_array_elements_text(sqlc.arg(food_ids)::jsonb));
+
+-- name: ListTranslationsForFood :many
+SELECT * FROM food_translations
+WHERE food_id = $1
+ORDER BY locale;
+
+-- name: UpsertFoodTranslation :one
+INSERT INTO food_translations (
+    food_id,
+    locale,
+    name,
+    alternate_names,
+    description
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (food_id, locale) DO UPDATE SET
+    name = EXCLUDED.name,
+    alternate_names = EXCLUDED.alternate_names,
+    description = EXCLUDED.description,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteFoodTranslation :exec
+DELETE FROM food_translations
+WHERE food_id = $1 AND locale = $2;
+
+-- name: ListDuplicateCandidates :many
+-- Pairs of foods sharing a barcode or with similar names, each pair once.
+-- Names are compared with the trigram % operator so the name index is used.
+-- The search is scoped to a food type or a food; a scan of the whole catalog
+-- would compare every food with every other.
+SELECT
+    a.id::text AS food_id,
+    b.id::text AS other_id,
+    (COALESCE(a.ean_13, '') <> '' AND a.ean_13 = b.ean_13)::boolean AS same_barcode,
+    similarity(a.name, b.name)::float8 AS name_similarity
+FROM foods a
+JOIN foods b ON a.id < b.id AND b.retired_at IS NULL AND (
+    (COALESCE(a.ean_13, '') <> '' AND a.ean_13 = b.ean_13)
+    OR (a.name % b.name AND similarity(a.name, b.name) >= sqlc.arg(min_name_similarity)::float8)
+)
+WHERE a.retired_at IS NULL
+  AND (sqlc.narg(food_type)::text IS NULL OR (a.food_type = sqlc.narg(food_type)::text AND b.food_type = sqlc.narg(food_type)::text))
+  AND (sqlc.narg(food_id)::text IS NULL OR a.id = sqlc.narg(food_id)::text OR b.id = sqlc.narg(food_id)::text)
+  AND (sqlc.narg(food_type)::text IS NOT NULL OR sqlc.narg(food_id)::text IS NOT NULL)
+ORDER BY same_barcode DESC, name_similarity DESC, a.id, b.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: MoveFoodRatings :execrows
+-- Moves ratings to the surviving food of a merge, except from users who
+-- already rated it; their rating of the merged food is dropped with it
+UPDATE food_ratings SET food_id = sqlc.arg(target_id)::text, updated_at = NOW()
+WHERE food_id = sqlc.arg(source_id)::text
+  AND NOT EXISTS (
+      SELECT 1 FROM food_ratings existing
+      WHERE existing.user_id = food_ratings.user_id AND existing.food_id = sqlc.arg(target_id)::text
+  );
+
+-- name: MoveSavedFoods :execrows
+UPDATE user_saved_foods SET food_id = sqlc.arg(target_id)::text
+WHERE food_id = sqlc.arg(source_id)::text
+  AND NOT EXISTS (
+      SELECT 1 FROM user_saved_foods existing
+      WHERE existing.user_id = user_saved_foods.user_id
+        AND existing.list_type = user_saved_foods.list_type
+        AND existing.food_id = sqlc.arg(target_id)::text
+  );
+
+-- name: MoveFoodIntakeLogs :execrows
+UPDATE food_intake_logs SET food_id = sqlc.arg(target_id)::text
+WHERE food_id = sqlc.arg(source_id)::text;
+
+-- name: MoveFoodTranslations :execrows
+-- Keeps the merged food's translations into locales the survivor lacks
+UPDATE food_translations SET food_id = sqlc.arg(target_id)::text, updated_at = CURRENT_TIMESTAMP
+WHERE food_id = sqlc.arg(source_id)::text
+  AND NOT EXISTS (
+      SELECT 1 FROM food_translations existing
+      WHERE existing.locale = food_translations.locale AND existing.food_id = sqlc.arg(target_id)::text
+  );
+
+-- name: MoveFoodSubmissions :exec
+UPDATE food_submissions SET food_id = sqlc.arg(target_id)::text
+WHERE food_id = sqlc.arg(source_id)::text;
+
+-- name: MoveFoodRedirects :exec
+-- Repoints earlier redirects to the merged food so redirects never chain
+UPDATE food_redirects SET food_id = sqlc.arg(target_id)::text
+WHERE food_id = sqlc.arg(source_id)::text;
+
+-- name: CreateFoodRedirect :exec
+-- Replaces any redirect left from an earlier food with the same ID
+INSERT INTO food_redirects (old_id, food_id, merged_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (old_id) DO UPDATE SET
+    food_id = EXCLUDED.food_id,
+    merged_by = EXCLUDED.merged_by,
+    created_at = NOW();
+
+-- name: GetFoodRedirect :one
+SELECT food_id FROM food_redirects
+WHERE old_id = $1 LIMIT 1;
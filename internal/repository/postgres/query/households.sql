@@ -0,0 +1,75 @@
+-- name: CreateHousehold :one
+INSERT INTO households (
+    name,
+    owner_user_id
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetHouseholdByID :one
+SELECT * FROM households
+WHERE id = $1 LIMIT 1;
+
+-- name: ListHouseholdsByUser :many
+SELECT DISTINCT h.* FROM households h
+LEFT JOIN household_members m ON m.household_id = h.id
+LEFT JOIN user_profiles p ON p.id = m.profile_id
+WHERE h.owner_user_id = sqlc.arg(user_id) OR p.user_id = sqlc.arg(user_id)
+ORDER BY h.created_at;
+
+-- name: DeleteHousehold :exec
+DELETE FROM households
+WHERE id = $1 AND owner_user_id = $2;
+
+-- name: AddHouseholdMember :exec
+INSERT INTO household_members (
+    household_id,
+    profile_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (household_id, profile_id) DO NOTHING;
+
+-- name: RemoveHouseholdMember :exec
+DELETE FROM household_members
+WHERE household_id = $1 AND profile_id = $2;
+
+-- name: ListHouseholdMembers :many
+SELECT
+    m.household_id,
+    m.profile_id,
+    m.joined_at,
+    p.user_id,
+    p.profile_name,
+    p.calorie_target
+FROM household_members m
+JOIN user_profiles p ON p.id = m.profile_id
+WHERE m.household_id = $1
+ORDER BY m.joined_at;
+
+-- name: CreateHouseholdInvitation :one
+INSERT INTO household_invitations (
+    household_id,
+    invited_by,
+    invitee_email
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetHouseholdInvitation :one
+SELECT * FROM household_invitations
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPendingHouseholdInvitationsByEmail :many
+SELECT * FROM household_invitations
+WHERE invitee_email = $1 AND status = 'pending'
+ORDER BY created_at DESC;
+
+-- name: UpdateHouseholdInvitationStatus :exec
+UPDATE household_invitations
+SET
+    status = $2,
+    responded_at = NOW()
+WHERE id = $1;
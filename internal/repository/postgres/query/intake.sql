@@ -0,0 +1,22 @@
+-- name: CreateIntakeLog :one
+INSERT INTO food_intake_logs (
+    profile_id,
+    food_id,
+    amount_g,
+    meal_type,
+    consumed_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListIntakeLogsByProfile :many
+SELECT * FROM food_intake_logs
+WHERE profile_id = sqlc.arg(profile_id)
+  AND consumed_at >= sqlc.arg(from_time)
+  AND consumed_at < sqlc.arg(to_time)
+ORDER BY consumed_at;
+
+-- name: DeleteIntakeLog :execrows
+DELETE FROM food_intake_logs
+WHERE id = $1 AND profile_id = $2;
@@ -0,0 +1,53 @@
+-- name: CreateBodyMeasurement :one
+INSERT INTO body_measurements (
+    user_id,
+    measurement_type,
+    value,
+    unit,
+    measured_at,
+    notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListBodyMeasurements :many
+SELECT * FROM body_measurements
+WHERE user_id = sqlc.arg(user_id)
+  AND measurement_type = sqlc.arg(measurement_type)
+  AND measured_at >= sqlc.arg(from_time)
+  AND measured_at < sqlc.arg(to_time)
+ORDER BY measured_at;
+
+-- name: DeleteBodyMeasurement :execrows
+DELETE FROM body_measurements
+WHERE id = $1 AND user_id = $2;
+
+-- name: SetProfileCalorieTarget :execrows
+-- Only changes the target if it is still the one the adjustment started from
+UPDATE user_profiles
+SET calorie_target = sqlc.arg(new_target)::int, updated_at = NOW()
+WHERE id = sqlc.arg(id) AND COALESCE(calorie_target, 0) = sqlc.arg(previous_target)::int;
+
+-- name: CreateCalorieTargetAdjustment :one
+INSERT INTO calorie_target_adjustments (
+    profile_id,
+    previous_target,
+    new_target,
+    observed_rate_kg_week,
+    goal_rate_kg_week
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetLatestCalorieTargetAdjustment :one
+SELECT * FROM calorie_target_adjustments
+WHERE profile_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListCalorieTargetAdjustments :many
+SELECT * FROM calorie_target_adjustments
+WHERE profile_id = $1
+ORDER BY created_at DESC;
@@ -6,4 +6,8 @@ ORDER BY name;
 -- name: ListHealthConditions :many
 SELECT id, name, description, nutrient_restrictions, nutrient_recommendations, created_at
 FROM health_conditions
-ORDER BY name;
\ No newline at end of file
+ORDER BY name;
+
+-- name: ListFoodCategories :many
+SELECT * FROM food_categories
+ORDER BY name;
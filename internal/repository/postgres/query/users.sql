@@ -32,6 +32,7 @@ SET
     height_cm = COALESCE($6, height_cm),
     weight_kg = COALESCE($7, weight_kg),
     activity_level = COALESCE($8, activity_level),
+    locale = COALESCE($9, locale),
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -129,3 +129,31 @@ func (r *referenceRepository) GetDietaryPatterns(ctx context.Context) ([]referen
 		},
 	}, nil
 }
+
+func (r *referenceRepository) GetFoodCategories(ctx context.Context) ([]reference.FoodCategory, error) {
+	categories, err := r.queries.ListFoodCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]reference.FoodCategory, len(categories))
+	for i, c := range categories {
+		foodTypes := []string{}
+		if err := json.Unmarshal(c.FoodTypes, &foodTypes); err != nil {
+			return nil, err
+		}
+
+		result[i] = reference.FoodCategory{
+			ID:        int(c.ID),
+			Slug:      c.Slug,
+			Name:      c.Name,
+			FoodTypes: foodTypes,
+		}
+		if c.ParentID.Valid {
+			parentID := int(c.ParentID.Int32)
+			result[i].ParentID = &parentID
+		}
+	}
+
+	return result, nil
+}
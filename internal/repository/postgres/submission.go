@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/submission"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+type submissionRepository struct {
+	tm      *TransactionManager
+	queries *db.Queries
+}
+
+// NewSubmissionRepository creates a submission repository. It needs the
+// database itself, since approving a submission and adding its food to the
+// catalog happen in one transaction.
+func NewSubmissionRepository(sqlDB *sql.DB, queries *db.Queries) submission.Repository {
+	return &submissionRepository{
+		tm:      NewTransactionManager(sqlDB),
+		queries: queries,
+	}
+}
+
+func (r *submissionRepository) Create(ctx context.Context, s *submission.Submission) error {
+	data, err := json.Marshal(s.Food)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.queries.CreateFoodSubmission(ctx, db.CreateFoodSubmissionParams{
+		SubmitterID: s.SubmitterID,
+		Name:        s.Food.Name,
+		Ean13:       sql.NullString{String: s.Food.EAN13, Valid: s.Food.EAN13 != ""},
+		Food:        data,
+	})
+	if err != nil {
+		return err
+	}
+
+	*s = *mapDbSubmissionToDomain(&result)
+	return nil
+}
+
+func (r *submissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*submission.Submission, error) {
+	result, err := r.queries.GetFoodSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapDbSubmissionToDomain(&result), nil
+}
+
+func (r *submissionRepository) ListBySubmitter(ctx context.Context, submitterID uuid.UUID, status string, limit, offset int) ([]submission.Submission, error) {
+	results, err := r.queries.ListFoodSubmissionsBySubmitter(ctx, db.ListFoodSubmissionsBySubmitterParams{
+		SubmitterID: submitterID,
+		Status:      sql.NullString{String: status, Valid: status != ""},
+		RowLimit:    int32(limit),
+		RowOffset:   int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapDbSubmissionsToDomain(results), nil
+}
+
+func (r *submissionRepository) CountBySubmitter(ctx context.Context, submitterID uuid.UUID, status string) (int64, error) {
+	return r.queries.CountFoodSubmissionsBySubmitter(ctx, db.CountFoodSubmissionsBySubmitterParams{
+		SubmitterID: submitterID,
+		Status:      sql.NullString{String: status, Valid: status != ""},
+	})
+}
+
+func (r *submissionRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]submission.Submission, error) {
+	results, err := r.queries.ListFoodSubmissionsByStatus(ctx, db.ListFoodSubmissionsByStatusParams{
+		Status:    status,
+		RowLimit:  int32(limit),
+		RowOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapDbSubmissionsToDomain(results), nil
+}
+
+func (r *submissionRepository) CountByStatus(ctx context.Context, status string) (int64, error) {
+	return r.queries.CountFoodSubmissionsByStatus(ctx, status)
+}
+
+// UpdateFood replaces the submitted food data of a pending submission. It
+// returns sql.ErrNoRows when the submission has already been reviewed.
+func (r *submissionRepository) UpdateFood(ctx context.Context, s *submission.Submission) error {
+	data, err := json.Marshal(s.Food)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.queries.UpdateFoodSubmissionFood(ctx, db.UpdateFoodSubmissionFoodParams{
+		ID:    s.ID,
+		Name:  s.Food.Name,
+		Ean13: sql.NullString{String: s.Food.EAN13, Valid: s.Food.EAN13 != ""},
+		Food:  data,
+	})
+	if err != nil {
+		return err
+	}
+
+	*s = *mapDbSubmissionToDomain(&result)
+	return nil
+}
+
+// Review records the outcome of a moderator's review of a pending submission.
+// It returns sql.ErrNoRows when the submission has already been reviewed.
+func (r *submissionRepository) Review(ctx context.Context, s *submission.Submission) error {
+	var reviewerID uuid.NullUUID
+	if s.ReviewerID != nil {
+		reviewerID = uuid.NullUUID{UUID: *s.ReviewerID, Valid: true}
+	}
+
+	result, err := r.queries.ReviewFoodSubmission(ctx, db.ReviewFoodSubmissionParams{
+		ID:              s.ID,
+		Status:          s.Status,
+		RejectionReason: sql.NullString{String: s.RejectionReason, Valid: s.RejectionReason != ""},
+		ReviewerID:      reviewerID,
+		FoodID:          sql.NullString{String: s.FoodID, Valid: s.FoodID != ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	*s = *mapDbSubmissionToDomain(&result)
+	return nil
+}
+
+// Approve claims the submission before creating the food, so that of two
+// moderators approving at once only one adds it to the catalog
+func (r *submissionRepository) Approve(ctx context.Context, s *submission.Submission, f *food.Food, rev *food.Revision) error {
+	var reviewerID uuid.NullUUID
+	if s.ReviewerID != nil {
+		reviewerID = uuid.NullUUID{UUID: *s.ReviewerID, Valid: true}
+	}
+
+	return r.tm.WithinTransaction(ctx, func(tx *sql.Tx) error {
+		q := r.queries.WithTx(tx)
+		result, err := q.ReviewFoodSubmission(ctx, db.ReviewFoodSubmissionParams{
+			ID:         s.ID,
+			Status:     submission.StatusApproved,
+			ReviewerID: reviewerID,
+			FoodID:     sql.NullString{String: f.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		created, err := q.CreateFood(ctx, createFoodParams(f))
+		if err != nil {
+			return err
+		}
+		f.CreatedAt = created.CreatedAt.Time
+		f.UpdatedAt = created.UpdatedAt.Time
+		if err := createRevision(ctx, q, rev); err != nil {
+			return err
+		}
+
+		*s = *mapDbSubmissionToDomain(&result)
+		return nil
+	})
+}
+
+func mapDbSubmissionsToDomain(results []db.FoodSubmission) []submission.Submission {
+	submissions := make([]submission.Submission, len(results))
+	for i, s := range results {
+		submissions[i] = *mapDbSubmissionToDomain(&s)
+	}
+	return submissions
+}
+
+func mapDbSubmissionToDomain(s *db.FoodSubmission) *submission.Submission {
+	result := &submission.Submission{
+		ID:              s.ID,
+		SubmitterID:     s.SubmitterID,
+		Status:          s.Status,
+		RejectionReason: s.RejectionReason.String,
+		FoodID:          s.FoodID.String,
+		CreatedAt:       s.CreatedAt.Time,
+		UpdatedAt:       s.UpdatedAt.Time,
+	}
+	json.Unmarshal(s.Food, &result.Food)
+	if s.ReviewerID.Valid {
+		reviewerID := s.ReviewerID.UUID
+		result.ReviewerID = &reviewerID
+	}
+	if s.ReviewedAt.Valid {
+		reviewedAt := s.ReviewedAt.Time
+		result.ReviewedAt = &reviewedAt
+	}
+	return result
+}
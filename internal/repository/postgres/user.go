@@ -73,6 +73,7 @@ func (r *userRepository) Update(user *user.User) error {
 		HeightCm:      sql.NullString{String: fmt.Sprintf("%.2f", user.HeightCm), Valid: user.HeightCm != 0},
 		WeightKg:      sql.NullString{String: fmt.Sprintf("%.2f", user.WeightKg), Valid: user.WeightKg != 0},
 		ActivityLevel: sql.NullString{String: user.ActivityLevel, Valid: user.ActivityLevel != ""},
+		Locale:        sql.NullString{String: user.Locale, Valid: user.Locale != ""},
 	})
 	return err
 }
@@ -131,5 +132,7 @@ func mapDbUserToDomain(u *db.User) *user.User {
 		EmailVerified: u.EmailVerified.Bool,
 		MFAEnabled:    u.MfaEnabled.Bool,
 		MFASecret:     u.MfaSecret.String,
+		Role:          u.Role,
+		Locale:        u.Locale.String,
 	}
 }
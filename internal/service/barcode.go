@@ -0,0 +1,28 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// findByBarcode normalizes an EAN/UPC barcode to GTIN-13 and returns the
+// first food stored under one of its candidates
+func findByBarcode(repo food.Repository, code string) (*food.Food, error) {
+	candidates, err := food.NormalizeBarcode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, gtin := range candidates {
+		f, err := repo.GetByEAN13(gtin)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	return nil, sql.ErrNoRows
+}
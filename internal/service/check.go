@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
+)
+
+// CheckFood evaluates a single food, looked up by ID or barcode, against every
+// rule of the user's default or requested profile
+func (s *recommendationService) CheckFood(ctx context.Context, userID uuid.UUID, req recommendation.CheckRequest) (*recommendation.CheckResult, error) {
+	var userProfile *profile.UserProfile
+	var err error
+	if req.ProfileID != nil {
+		userProfile, err = s.profileRepo.GetByID(*req.ProfileID)
+		if err != nil {
+			return nil, err
+		}
+		if userProfile.UserID != userID {
+			return nil, profile.ErrUnauthorized
+		}
+	} else {
+		userProfile, err = s.profileRepo.GetDefaultByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var f *food.Food
+	if req.FoodID != "" {
+		f, err = s.foodRepo.GetByID(req.FoodID)
+	} else {
+		f, err = findByBarcode(s.foodRepo, req.EAN13)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.GenerateRulesFromProfile(userProfile)
+	if err != nil {
+		return nil, err
+	}
+	sortRulesByPriority(rules)
+
+	result := &recommendation.CheckResult{
+		Food:       *f,
+		ProfileID:  userProfile.ID,
+		Verdict:    recommendation.VerdictSafe,
+		Violations: []recommendation.Violation{},
+	}
+	for _, rule := range rules {
+		v, violated := ruleViolation(*f, rule)
+		if !violated {
+			continue
+		}
+		result.Violations = append(result.Violations, v)
+		if v.Severity == recommendation.SeverityHigh {
+			result.Verdict = recommendation.VerdictAvoid
+		} else if result.Verdict == recommendation.VerdictSafe {
+			result.Verdict = recommendation.VerdictCaution
+		}
+	}
+
+	return result, nil
+}
+
+// ruleViolation reports whether a food breaks a rule, mirroring applyRules,
+// and records what triggered it
+func ruleViolation(f food.Food, rule recommendation.Rule) (recommendation.Violation, bool) {
+	v := recommendation.Violation{Rule: rule, Severity: ruleSeverity(rule)}
+
+	switch rule.Operation {
+	case "exclude":
+		switch rule.Type {
+		case "allergen":
+			matched, ok := allergenMatch(f, rule.Target)
+			if !ok {
+				return v, false
+			}
+			v.Matched = matched
+			v.Reason = fmt.Sprintf("contains allergen %q", rule.Target)
+		case "dietary":
+			if !violatesDietaryRestriction(f, rule.Target) {
+				return v, false
+			}
+			v.Matched = rule.Target
+			v.Reason = fmt.Sprintf("labelled %q, which the dietary restriction excludes", rule.Target)
+		case "preference":
+			if !matchesPreference(f, rule.Target) {
+				return v, false
+			}
+			v.Matched = rule.Target
+			v.Reason = fmt.Sprintf("matches disliked food %q", rule.Target)
+		case processingRuleType:
+			if !excludedByProcessing(f, rule) {
+				return v, false
+			}
+			v.Matched = strings.Join(ultraProcessedMarkers(f), ", ")
+			v.Reason = "ultra-processed (NOVA group 4)"
+		default:
+			return v, false
+		}
+		return v, true
+	case "max", "min":
+		if rule.Type == nutriScoreRuleType && rule.Operation == "max" {
+			if withinNutriScore(f, rule) {
+				return v, false
+			}
+			v.Matched = f.NutriScoreGrade
+			v.Reason = fmt.Sprintf("Nutri-Score %s is worse than the maximum of %v", f.NutriScoreGrade, rule.Value)
+			return v, true
+		}
+		if rule.Type != "nutrient" {
+			return v, false
+		}
+		value, ok := f.NutrientValue(rule.Target)
+		if !ok {
+			return v, false
+		}
+		limit, ok := ruleThreshold(rule.Value)
+		if !ok {
+			return v, false
+		}
+		if rule.Operation == "max" && value <= limit || rule.Operation == "min" && value >= limit {
+			return v, false
+		}
+		v.Matched = rule.Target
+		v.Value = &value
+		if rule.Operation == "max" {
+			v.Reason = fmt.Sprintf("%s %.2f per 100g exceeds the maximum of %.2f", rule.Target, value, limit)
+		} else {
+			v.Reason = fmt.Sprintf("%s %.2f per 100g is below the minimum of %.2f", rule.Target, value, limit)
+		}
+		return v, true
+	}
+
+	return v, false
+}
+
+// allergenMatch returns the label, alternate name, ingredient or allergen
+// declaration that matched an allergen
+func allergenMatch(f food.Food, allergen string) (string, bool) {
+	if containsString(f.Labels, allergen) || containsString(f.AlternateNames, allergen) {
+		return allergen, true
+	}
+	return f.MentionsIngredient(allergen, true)
+}
+
+// ruleSeverity grades a violated rule: allergens, dietary restrictions and
+// processing exclusions make a food unsuitable, anything else only warrants caution
+func ruleSeverity(rule recommendation.Rule) string {
+	switch {
+	case rule.Type == "allergen" || rule.Type == "dietary" || rule.Type == processingRuleType:
+		return recommendation.SeverityHigh
+	case rule.Type == "nutrient":
+		return recommendation.SeverityMedium
+	default:
+		return recommendation.SeverityLow
+	}
+}
+
+// ruleThreshold reads a numeric rule value, which is an int for rules built
+// from a profile and a float64 for rules decoded from JSON
+func ruleThreshold(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
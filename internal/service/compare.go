@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/reference"
+)
+
+// CompareFoods builds an aligned nutrient table for the foods, in the order
+// given, along with their label and allergen differences
+func (s *foodService) CompareFoods(ctx context.Context, foodIDs []string) (*food.Comparison, error) {
+	foods := make([]food.Food, len(foodIDs))
+	for i, id := range foodIDs {
+		f, err := s.repo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("food %s: %w", id, err)
+		}
+		foods[i] = *f
+	}
+	if err := s.localizeFoods(ctx, foods); err != nil {
+		return nil, err
+	}
+
+	allergens, err := s.referenceRepo.GetAllergens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &food.Comparison{
+		Foods:     make([]food.ComparedFood, len(foods)),
+		Nutrients: []food.NutrientComparison{},
+	}
+	labelSets := make([][]string, len(foods))
+	allergenSets := make([][]string, len(foods))
+	for i, f := range foods {
+		labelSets[i] = uniqueSorted(f.Labels)
+		allergenSets[i] = foodAllergens(f, allergens)
+		comparison.Foods[i] = food.ComparedFood{
+			ID:        f.ID,
+			Name:      f.Name,
+			FoodType:  f.FoodType,
+			Labels:    labelSets[i],
+			Allergens: allergenSets[i],
+		}
+	}
+	comparison.Labels = setDifference(foodIDs, labelSets)
+	comparison.Allergens = setDifference(foodIDs, allergenSets)
+
+	for _, n := range food.CanonicalNutrients {
+		row := food.NutrientComparison{
+			Nutrient:    n.Key,
+			Name:        n.Name,
+			Unit:        n.Unit,
+			Values:      make([]*float64, len(foods)),
+			PercentDiff: make([]*float64, len(foods)),
+			Better:      food.NutrientPreference[n.Key],
+		}
+
+		reported := false
+		for i, f := range foods {
+			if v, ok := f.NutrientValue(n.Key); ok {
+				v = round2(v)
+				row.Values[i] = &v
+				reported = true
+			}
+		}
+		if !reported {
+			continue
+		}
+
+		if base := row.Values[0]; base != nil && *base != 0 {
+			for i, v := range row.Values {
+				if v != nil {
+					diff := percentOf(*v-*base, *base)
+					row.PercentDiff[i] = &diff
+				}
+			}
+		}
+		row.BestFoodIDs = bestFoods(foodIDs, row.Values, row.Better)
+
+		comparison.Nutrients = append(comparison.Nutrients, row)
+	}
+
+	return comparison, nil
+}
+
+// bestFoods returns the foods with the best reported value for a nutrient,
+// or nil when the nutrient has no preferred direction or only one food reports it
+func bestFoods(foodIDs []string, values []*float64, better string) []string {
+	if better == "" {
+		return nil
+	}
+
+	var best *float64
+	count := 0
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		count++
+		if best == nil || (better == food.BetterHigher && *v > *best) || (better == food.BetterLower && *v < *best) {
+			best = v
+		}
+	}
+	if count < 2 {
+		return nil
+	}
+
+	var ids []string
+	for i, v := range values {
+		if v != nil && *v == *best {
+			ids = append(ids, foodIDs[i])
+		}
+	}
+	return ids
+}
+
+// foodAllergens returns the reference allergens a food's labels, alternate
+// names or ingredients mention, by name or by one of their common names
+func foodAllergens(f food.Food, allergens []reference.Allergen) []string {
+	found := []string{}
+	for _, a := range allergens {
+		for _, term := range allergenTerms(a) {
+			_, inIngredients := f.MentionsIngredient(term, false)
+			if containsString(f.Labels, term) || containsString(f.AlternateNames, term) || inIngredients {
+				found = append(found, a.Name)
+				break
+			}
+		}
+	}
+	return found
+}
+
+func allergenTerms(a reference.Allergen) []string {
+	terms := []string{a.Name, strings.ReplaceAll(a.Name, "_", " ")}
+	for _, key := range []string{"alternatives", "types"} {
+		values, _ := a.CommonNames[key].([]interface{})
+		for _, v := range values {
+			if term, ok := v.(string); ok && term != "" {
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
+// setDifference splits per-food sets into the values every food has and the
+// values only some foods have
+func setDifference(foodIDs []string, sets [][]string) food.SetDifference {
+	counts := map[string]int{}
+	for _, set := range sets {
+		for _, v := range set {
+			counts[v]++
+		}
+	}
+
+	diff := food.SetDifference{Common: []string{}, Only: map[string][]string{}}
+	for v, n := range counts {
+		if n == len(sets) {
+			diff.Common = append(diff.Common, v)
+		}
+	}
+	sort.Strings(diff.Common)
+
+	for i, set := range sets {
+		for _, v := range set {
+			if counts[v] < len(sets) {
+				diff.Only[foodIDs[i]] = append(diff.Only[foodIDs[i]], v)
+			}
+		}
+	}
+	return diff
+}
+
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+type exportService struct {
+	exportRepo food.ExportRepository
+	logger     zerolog.Logger
+}
+
+func NewExportService(exportRepo food.ExportRepository, logger zerolog.Logger) ExportService {
+	return &exportService{
+		exportRepo: exportRepo,
+		logger:     logger,
+	}
+}
+
+// ExportFoods writes the foods matching filter to w in an export format as
+// they are read, returning the number of foods written
+func (s *exportService) ExportFoods(ctx context.Context, filter food.ExportFilter, format string, w io.Writer) (int, error) {
+	out, err := food.NewExportWriter(w, format)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	count := 0
+	err = s.exportRepo.Export(ctx, filter, func(f *food.Food) error {
+		count++
+		return out.Write(f)
+	})
+	if err != nil {
+		return count, fmt.Errorf("failed to export foods: %w", err)
+	}
+	if err := out.Flush(); err != nil {
+		return count, fmt.Errorf("failed to export foods: %w", err)
+	}
+
+	s.logger.Info().
+		Str("format", format).
+		Str("food_type", filter.FoodType).
+		Int("count", count).
+		Dur("duration", time.Since(start)).
+		Msg("Food catalog exported")
+	return count, nil
+}
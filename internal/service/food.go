@@ -2,31 +2,46 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/reference"
 	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
 )
 
 type foodService struct {
-	repo   food.Repository
-	logger zerolog.Logger
+	repo          food.Repository
+	edits         food.EditRepository
+	mergeRepo     food.MergeRepository
+	referenceRepo reference.Repository
+	suggestions   *suggestIndex
+	logger        zerolog.Logger
 }
 
 func NewFoodService(
 	repo food.Repository,
+	edits food.EditRepository,
+	mergeRepo food.MergeRepository,
+	referenceRepo reference.Repository,
 	logger zerolog.Logger,
 ) FoodService {
 	return &foodService{
-		repo:   repo,
-		logger: logger,
+		repo:          repo,
+		edits:         edits,
+		mergeRepo:     mergeRepo,
+		referenceRepo: referenceRepo,
+		suggestions:   newSuggestIndex(repo, logger),
+		logger:        logger,
 	}
 }
 
 func (s *foodService) Create(food *food.Food) error {
+	food.Classify()
 	return s.repo.Create(food)
 }
 
@@ -39,7 +54,7 @@ func (s *foodService) GetByEAN13(ean13 string) (*food.Food, error) {
 }
 
 func (s *foodService) List(limit, offset int) ([]food.Food, error) {
-	return s.repo.List(limit, offset)
+	return s.repo.List(nil, limit, offset)
 }
 
 func (s *foodService) ListByType(foodType string, limit, offset int) ([]food.Food, error) {
@@ -47,7 +62,7 @@ func (s *foodService) ListByType(foodType string, limit, offset int) ([]food.Foo
 }
 
 func (s *foodService) Search(query string, limit, offset int) ([]food.Food, error) {
-	return s.repo.Search(query, limit, offset)
+	return s.repo.Search(query, food.DefaultLocale, nil, limit, offset)
 }
 
 func (s *foodService) Count() (int64, error) {
@@ -60,53 +75,335 @@ func (s *foodService) Delete(id string) error {
 
 func (s *foodService) Import(filePath string) (int, error) {
 	// Access underlying queries using type assertion
-	if repo, ok := s.repo.(interface{ GetQueries() *db.Queries }); ok {
-		importer := NewFoodImporter(repo.GetQueries(), s.logger)
-		return importer.ImportFromTSV(filePath, 100) // Use batch size of 100
+	if repo, ok := s.repo.(interface {
+		GetDB() *sql.DB
+		GetQueries() *db.Queries
+	}); ok {
+		importer := NewFoodImporter(repo.GetDB(), repo.GetQueries(), s.logger)
+		count, err := importer.ImportFromTSV(filePath, 100) // Use batch size of 100
+		if err != nil {
+			return count, err
+		}
+		if _, err := importer.BackfillIngredientTrees(500); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to backfill ingredient trees after import")
+		}
+		if _, err := importer.BackfillNutriScores(500); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to backfill Nutri-Scores after import")
+		}
+		if _, err := importer.BackfillNovaGroups(500); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to backfill NOVA groups after import")
+		}
+		if err := s.suggestions.Refresh(); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to refresh food suggestion index after import")
+		}
+		return count, nil
 	}
-	return 0, fmt.Errorf("repository does not support GetQueries")
+	return 0, fmt.Errorf("repository does not support GetDB and GetQueries")
 }
 
 // Adapter methods to implement the service.FoodService interface
+// GetFood returns a food, or a *food.MovedError naming the food it was
+// merged into
 func (s *foodService) GetFood(ctx context.Context, id string) (*food.Food, error) {
-	return s.GetByID(id)
+	f, err := s.GetByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		if target, redirectErr := s.repo.GetRedirect(id); redirectErr == nil {
+			return nil, &food.MovedError{FoodID: target}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.localize(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// localize translates a food into the request's locale, when it has a translation
+func (s *foodService) localize(ctx context.Context, f *food.Food) error {
+	foods := []food.Food{*f}
+	if err := s.localizeFoods(ctx, foods); err != nil {
+		return err
+	}
+	*f = foods[0]
+	return nil
 }
 
-func (s *foodService) SearchFoods(ctx context.Context, query string, page, limit int) ([]food.Food, int, error) {
+// localizeFoods translates foods into the request's locale, leaving those
+// without a translation in English. Cursors must be taken beforehand, since
+// they continue from the English name.
+func (s *foodService) localizeFoods(ctx context.Context, foods []food.Food) error {
+	locale := food.LocaleFromContext(ctx)
+	if locale == food.DefaultLocale || len(foods) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(foods))
+	for i := range foods {
+		ids[i] = foods[i].ID
+	}
+	translations, err := s.repo.ListTranslations(ids, locale)
+	if err != nil {
+		return err
+	}
+	for i := range foods {
+		foods[i].Localize(translations[foods[i].ID])
+	}
+	return nil
+}
+
+// SearchFoods returns a page of foods by page number, or after cursor when
+// one from a previous page's NextCursor is given
+func (s *foodService) SearchFoods(ctx context.Context, filter food.SearchFilter, page, limit int, cursor string) (*food.SearchResult, error) {
 	offset := (page - 1) * limit
-	foods, err := s.Search(query, limit, offset)
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.Locale = food.LocaleFromContext(ctx)
+
+	result := &food.SearchResult{}
+	var err error
+	switch {
+	case filter.HasStructuredFilters():
+		err = s.filteredFoods(result, filter, cursor, limit, offset)
+	case filter.Query == "":
+		// Without search terms, page through the whole catalog
+		err = s.allFoods(result, cursor, limit, offset)
+	default:
+		err = s.searchFoods(result, filter.Query, filter.Locale, cursor, limit, offset)
+	}
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	count, err := s.Count()
+	// Facets aggregate over every matching food, so they are only counted
+	// when asked for. They describe the full-text matches, which a fuzzy
+	// fallback has none of.
+	if filter.Facets && !result.Fuzzy {
+		result.Facets, err = s.repo.Facets(filter, facetLabelLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.localizeFoods(ctx, result.Foods); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// facetLabelLimit caps the number of label facets returned with a search
+const facetLabelLimit = 50
+
+func (s *foodService) filteredFoods(result *food.SearchResult, filter food.SearchFilter, cursor string, limit, offset int) error {
+	after, offset, err := decodePageCursor(cursor, food.OrderFiltered, offset)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountFiltered(filter)
+	if err != nil {
+		return err
+	}
+	result.Total = int(count)
+	if count == 0 {
+		result.Foods = []food.Food{}
+		return nil
+	}
+	result.Foods, err = s.repo.Filter(filter, after, limit, offset)
 	if err != nil {
-		return foods, 0, err
+		return err
 	}
 
-	return foods, int(count), nil
+	result.NextCursor, err = nextCursor(result.Foods, limit, func(last *food.Food) (*food.Cursor, error) {
+		points := 0.0
+		if filter.Sort == food.SortNutriScore {
+			points = food.UngradedPoints
+			if last.NutriScore != nil {
+				points = float64(last.NutriScore.Score)
+			}
+		}
+		rank := 0.0
+		if filter.Query != "" {
+			r, err := s.repo.SearchRank(filter.Query, filter.Locale, last.ID)
+			if err != nil {
+				return nil, err
+			}
+			rank = -r
+		}
+		return &food.Cursor{Order: food.OrderFiltered, Keys: []float64{points, rank}, Name: last.Name, ID: last.ID}, nil
+	})
+	return err
 }
 
-func (s *foodService) GetFoodsByCategory(ctx context.Context, category string, page, limit int) ([]food.Food, int, error) {
-	offset := (page - 1) * limit
-	foods, err := s.ListByType(category, limit, offset)
+func (s *foodService) allFoods(result *food.SearchResult, cursor string, limit, offset int) error {
+	after, offset, err := decodePageCursor(cursor, food.OrderName, offset)
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
 
-	// This is a simplification - in a real implementation we would count only foods of this category
+	result.Foods, err = s.repo.List(after, limit, offset)
+	if err != nil {
+		return err
+	}
 	count, err := s.Count()
 	if err != nil {
-		return foods, 0, err
+		return err
+	}
+	result.Total = int(count)
+	result.NextCursor, err = nextCursor(result.Foods, limit, func(last *food.Food) (*food.Cursor, error) {
+		return food.NameCursor(last), nil
+	})
+	return err
+}
+
+// searchFoods runs a ranked full-text search, falling back to trigram
+// similarity so misspellings still find something. Queries match English
+// names and descriptions and their translations into locale.
+func (s *foodService) searchFoods(result *food.SearchResult, query, locale, cursor string, limit, offset int) error {
+	count, err := s.repo.CountSearch(query, locale)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		after, offset, err := decodePageCursor(cursor, food.OrderRelevance, offset)
+		if err != nil {
+			return err
+		}
+		result.Total = int(count)
+		result.Foods, err = s.repo.Search(query, locale, after, limit, offset)
+		if err != nil {
+			return err
+		}
+		result.NextCursor, err = nextCursor(result.Foods, limit, func(last *food.Food) (*food.Cursor, error) {
+			rank, err := s.repo.SearchRank(query, locale, last.ID)
+			if err != nil {
+				return nil, err
+			}
+			return &food.Cursor{Order: food.OrderRelevance, Keys: []float64{-rank}, Name: last.Name, ID: last.ID}, nil
+		})
+		return err
+	}
+
+	count, err = s.repo.CountFuzzySearch(query, locale)
+	if err != nil {
+		return err
 	}
+	result.Total = int(count)
+	if count == 0 {
+		result.Foods = []food.Food{}
+		return nil
+	}
+
+	after, offset, err := decodePageCursor(cursor, food.OrderSimilarity, offset)
+	if err != nil {
+		return err
+	}
+	result.Fuzzy = true
+	result.Foods, err = s.repo.FuzzySearch(query, locale, after, limit, offset)
+	if err != nil {
+		return err
+	}
+	result.NextCursor, err = nextCursor(result.Foods, limit, func(last *food.Food) (*food.Cursor, error) {
+		similarity, err := s.repo.NameSimilarity(query, locale, last.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &food.Cursor{Order: food.OrderSimilarity, Keys: []float64{-similarity}, Name: last.Name, ID: last.ID}, nil
+	})
+	return err
+}
 
-	return foods, int(count), nil
+func (s *foodService) GetFoodByBarcode(ctx context.Context, code string) (*food.Food, error) {
+	f, err := findByBarcode(s.repo, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.localize(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *foodService) SuggestFoods(ctx context.Context, prefix string, limit int) ([]food.Suggestion, error) {
+	return s.suggestions.Suggest(prefix, limit)
+}
+
+// GetFoodsByCategory pages through a taxonomy category, including its
+// subcategories, or through a single food_type when category is not a
+// category slug. Pages are selected by number, or after cursor when set.
+func (s *foodService) GetFoodsByCategory(ctx context.Context, category string, page, limit int, cursor string) (*food.SearchResult, error) {
+	after, offset, err := decodePageCursor(cursor, food.OrderName, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.referenceRepo.GetFoodCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	foodTypes := []string{category}
+	if node, ok := reference.FindCategory(reference.BuildCategoryTree(categories), category); ok {
+		foodTypes = node.SubtreeFoodTypes()
+	}
+
+	count, err := s.repo.CountByTypes(foodTypes)
+	if err != nil {
+		return nil, err
+	}
+	result := &food.SearchResult{Foods: []food.Food{}, Total: int(count)}
+	if count == 0 {
+		return result, nil
+	}
+
+	result.Foods, err = s.repo.ListByTypes(foodTypes, after, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	result.NextCursor, err = nextCursor(result.Foods, limit, func(last *food.Food) (*food.Cursor, error) {
+		return food.NameCursor(last), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.localizeFoods(ctx, result.Foods); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListCategories returns the distinct food types with counts and the category
+// taxonomy with the number of foods under each category
+func (s *foodService) ListCategories(ctx context.Context) (*reference.CategoryListing, error) {
+	typeCounts, err := s.repo.CountByType()
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &reference.CategoryListing{FoodTypes: make([]reference.FoodTypeCount, len(typeCounts))}
+	counts := make(map[string]int64, len(typeCounts))
+	for i, c := range typeCounts {
+		listing.FoodTypes[i] = reference.FoodTypeCount{FoodType: c.Value, Count: c.Count}
+		counts[c.Value] = c.Count
+	}
+
+	categories, err := s.referenceRepo.GetFoodCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	listing.Taxonomy = reference.BuildCategoryTree(categories)
+	reference.CountCategoryFoods(listing.Taxonomy, counts)
+	if listing.Taxonomy == nil {
+		listing.Taxonomy = []reference.FoodCategory{}
+	}
+
+	return listing, nil
 }
 
 // Rating methods
 func (s *foodService) RateFood(ctx context.Context, userID uuid.UUID, foodID string, rating int, comments string) (*food.FoodRating, error) {
-	// Validate food exists
-	if _, err := s.GetFood(ctx, foodID); err != nil {
+	// Validate food exists, rating the surviving food of a merge
+	foodID, err := s.resolveFoodID(foodID)
+	if err != nil {
 		return nil, fmt.Errorf("food not found: %w", err)
 	}
 
@@ -197,8 +494,9 @@ func (s *foodService) DeleteRating(ctx context.Context, userID uuid.UUID, foodID
 
 // Saved food methods
 func (s *foodService) SaveFood(ctx context.Context, userID uuid.UUID, foodID string, listType string) (*food.SavedFood, error) {
-	// Validate food exists
-	if _, err := s.GetFood(ctx, foodID); err != nil {
+	// Validate food exists, saving the surviving food of a merge
+	foodID, err := s.resolveFoodID(foodID)
+	if err != nil {
 		return nil, fmt.Errorf("food not found: %w", err)
 	}
 
@@ -253,3 +551,29 @@ func (s *foodService) RemoveSavedFood(ctx context.Context, userID uuid.UUID, foo
 
 	return nil
 }
+
+// decodePageCursor decodes an optional page cursor for an ordering. A cursor
+// replaces the page offset, so offset is reset to 0 when one is given.
+func decodePageCursor(cursor, order string, offset int) (*food.Cursor, int, error) {
+	if cursor == "" {
+		return nil, offset, nil
+	}
+	after, err := food.DecodeCursor(cursor, order)
+	if err != nil {
+		return nil, 0, err
+	}
+	return after, 0, nil
+}
+
+// nextCursor encodes the cursor after the last food of a full page, built by
+// position. A short page is the last one and gets no cursor.
+func nextCursor(foods []food.Food, limit int, position func(last *food.Food) (*food.Cursor, error)) (string, error) {
+	if limit < 1 || len(foods) < limit {
+		return "", nil
+	}
+	after, err := position(&foods[len(foods)-1])
+	if err != nil {
+		return "", err
+	}
+	return after.Encode(), nil
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// CreateFood adds a food to the catalog and records a create revision. A
+// missing ID is generated.
+func (s *foodService) CreateFood(ctx context.Context, authorID uuid.UUID, f *food.Food) (*food.Food, error) {
+	if f.ID == "" {
+		f.ID = uuid.NewString()
+	}
+	if _, err := s.repo.GetByID(f.ID); err == nil {
+		return nil, food.ErrFoodExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if err := prepareEditedFood(f); err != nil {
+		return nil, err
+	}
+	rev := newRevision(authorID, food.RevisionCreate, f.ID, nil, f, nil)
+	if err := s.edits.Create(ctx, f, rev); err != nil {
+		return nil, err
+	}
+	s.logRevision(rev)
+	return s.repo.GetByID(f.ID)
+}
+
+// PatchFood applies a JSON merge patch to a food and records an update
+// revision. A patch that changes nothing records no revision.
+func (s *foodService) PatchFood(ctx context.Context, authorID uuid.UUID, id string, patch json.RawMessage) (*food.Food, error) {
+	current, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := food.ApplyPatch(current, patch)
+	if err != nil {
+		return nil, err
+	}
+	if len(food.DiffFoods(current, updated)) == 0 {
+		return current, nil
+	}
+
+	if err := prepareEditedFood(updated); err != nil {
+		return nil, err
+	}
+	rev := newRevision(authorID, food.RevisionUpdate, id, current, updated, nil)
+	if err := s.edits.Update(ctx, updated, rev); err != nil {
+		return nil, err
+	}
+	s.logRevision(rev)
+	return updated, nil
+}
+
+// DeleteFood retires a food and records a delete revision holding its last
+// state. The food is kept, with the ratings, saved foods and intake logs
+// that refer to it, so the deletion can be reverted.
+func (s *foodService) DeleteFood(ctx context.Context, authorID uuid.UUID, id string) error {
+	current, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if current.RetiredAt != nil {
+		return sql.ErrNoRows
+	}
+
+	rev := newRevision(authorID, food.RevisionDelete, id, current, nil, nil)
+	if err := s.edits.Retire(ctx, id, rev); err != nil {
+		return err
+	}
+	s.logRevision(rev)
+	return nil
+}
+
+// ListFoodRevisions returns a page of a food's revisions, newest first. The
+// history outlives the food, so deleted foods still have one.
+func (s *foodService) ListFoodRevisions(ctx context.Context, id string, page, limit int) ([]food.Revision, int, error) {
+	offset := (page - 1) * limit
+
+	revisions, err := s.repo.ListRevisions(id, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.CountRevisions(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return revisions, int(total), nil
+}
+
+// GetFoodRevision returns one revision of a food
+func (s *foodService) GetFoodRevision(ctx context.Context, foodID string, revisionID uuid.UUID) (*food.Revision, error) {
+	rev, err := s.repo.GetRevision(revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if rev.FoodID != foodID {
+		return nil, food.ErrRevisionOtherFood
+	}
+	return rev, nil
+}
+
+// RevertFoodRevision restores a food to its state before a revision:
+// reverting a create retires the food, reverting a delete restores it and
+// reverting an update rolls back only the fields it changed, keeping later
+// edits to other fields. The revert is itself recorded as a revision.
+func (s *foodService) RevertFoodRevision(ctx context.Context, authorID uuid.UUID, foodID string, revisionID uuid.UUID) (*food.Revision, error) {
+	rev, err := s.GetFoodRevision(ctx, foodID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if rev.Action == food.RevisionMerge {
+		// The merged food's ratings and history now belong to another food
+		return nil, food.ErrRevertMerge
+	}
+
+	// A retired food counts as deleted
+	current, err := s.repo.GetByID(foodID)
+	if errors.Is(err, sql.ErrNoRows) {
+		current = nil
+	} else if err != nil {
+		return nil, err
+	}
+	live := current
+	if current != nil && current.RetiredAt != nil {
+		live = nil
+	}
+
+	target := rev.Before
+	if target != nil && rev.After != nil && live != nil {
+		if target, err = food.RevertFields(live, rev.Diff); err != nil {
+			return nil, err
+		}
+	}
+	if target != nil {
+		target.ID = foodID
+		target.RetiredAt = nil
+		target.Classify()
+	}
+	if len(food.DiffFoods(live, target)) == 0 {
+		return nil, food.ErrNothingToRevert
+	}
+
+	revert := newRevision(authorID, food.RevisionRevert, foodID, live, target, &rev.ID)
+	switch {
+	case target == nil:
+		err = s.edits.Retire(ctx, foodID, revert)
+	case current == nil:
+		err = s.edits.Create(ctx, target, revert)
+	case live == nil:
+		err = s.edits.Restore(ctx, target, revert)
+	default:
+		err = s.edits.Update(ctx, target, revert)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.logRevision(revert)
+	return revert, nil
+}
+
+// ListFoodTranslations returns a food's translations by locale
+func (s *foodService) ListFoodTranslations(ctx context.Context, id string) ([]food.Translation, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListTranslationsForFood(id)
+}
+
+// PutFoodTranslation creates or replaces a food's translation into a locale
+func (s *foodService) PutFoodTranslation(ctx context.Context, t *food.Translation) (*food.Translation, error) {
+	locale, ok := food.NormalizeLocale(t.Locale)
+	if !ok {
+		return nil, food.ErrUnsupportedLocale
+	}
+	if locale == food.DefaultLocale {
+		return nil, food.ErrDefaultLocale
+	}
+	t.Locale = locale
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return nil, food.ErrTranslationRequired
+	}
+
+	if _, err := s.repo.GetByID(t.FoodID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpsertTranslation(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteFoodTranslation removes a food's translation into a locale
+func (s *foodService) DeleteFoodTranslation(ctx context.Context, id, locale string) error {
+	normalized, ok := food.NormalizeLocale(locale)
+	if !ok {
+		return food.ErrUnsupportedLocale
+	}
+	return s.repo.DeleteTranslation(id, normalized)
+}
+
+// newRevision returns a revision with the diff between two states of a food
+func newRevision(authorID uuid.UUID, action, foodID string, before, after *food.Food, reverted *uuid.UUID) *food.Revision {
+	return &food.Revision{
+		FoodID:             foodID,
+		Action:             action,
+		AuthorID:           &authorID,
+		Diff:               food.DiffFoods(before, after),
+		Before:             before,
+		After:              after,
+		RevertedRevisionID: reverted,
+	}
+}
+
+// logRevision logs a revision once it has been stored
+func (s *foodService) logRevision(rev *food.Revision) {
+	s.logger.Info().
+		Str("food_id", rev.FoodID).
+		Str("action", rev.Action).
+		Str("author_id", rev.AuthorID.String()).
+		Int("changed_fields", len(rev.Diff)).
+		Msg("Recorded food revision")
+}
+
+// prepareEditedFood validates an admin-edited food, normalizes its barcode to
+// GTIN-13 and recomputes its derived classifications
+func prepareEditedFood(f *food.Food) error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return food.ErrNameRequired
+	}
+
+	f.InvalidBarcode = ""
+	if f.EAN13 != "" {
+		gtin, err := food.NormalizeGTIN(f.EAN13)
+		if err != nil {
+			return err
+		}
+		f.EAN13 = gtin
+	}
+
+	f.Classify()
+	return nil
+}
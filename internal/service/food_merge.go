@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// duplicateCandidateFactor is how many candidate pairs are scored for each
+// duplicate returned, since many candidates score below the threshold
+const duplicateCandidateFactor = 5
+
+// maxDuplicates bounds the pairs FindDuplicates returns, and so the
+// candidates it loads and scores
+const maxDuplicates = 100
+
+// FindDuplicates returns likely duplicate pairs scoring at least minScore,
+// most likely first. Candidates share a barcode or have names at least
+// filter.MinNameSimilarity alike, and are scored by barcode, name similarity
+// and the distance between their nutrients. The filter must name a food type
+// or a food, and at most maxDuplicates pairs are returned.
+func (s *foodService) FindDuplicates(ctx context.Context, filter food.DuplicateFilter, minScore float64, limit int) ([]food.DuplicatePair, error) {
+	if minScore < 0 || minScore > 1 {
+		return nil, food.ErrInvalidMinScore
+	}
+	if filter.FoodType == "" && filter.FoodID == "" {
+		return nil, food.ErrDuplicateScopeNeeded
+	}
+	if limit < 1 || limit > maxDuplicates {
+		limit = maxDuplicates
+	}
+
+	candidates, err := s.repo.ListDuplicateCandidates(filter, limit*duplicateCandidateFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := map[string]*food.Food{}
+	load := func(id string) (*food.Food, error) {
+		if f, ok := loaded[id]; ok {
+			return f, nil
+		}
+		f, err := s.repo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("food %s: %w", id, err)
+		}
+		loaded[id] = f
+		return f, nil
+	}
+
+	pairs := []food.DuplicatePair{}
+	for _, c := range candidates {
+		a, err := load(c.FoodID)
+		if err != nil {
+			return nil, err
+		}
+		b, err := load(c.OtherID)
+		if err != nil {
+			return nil, err
+		}
+		if pair := food.ScoreDuplicate(a, b, c); pair.Score >= minScore {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs, nil
+}
+
+// MergeFoods merges a duplicate food into the food that survives: its
+// ratings, saved foods, intake logs and missing translations move to the
+// target, it is deleted and its ID redirects to the target from then on.
+// The merge is recorded as a revision of the source.
+func (s *foodService) MergeFoods(ctx context.Context, authorID uuid.UUID, sourceID, targetID string) (*food.MergeResult, error) {
+	if sourceID == targetID {
+		return nil, food.ErrMergeIntoSelf
+	}
+	source, err := s.repo.GetByID(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(targetID); err != nil {
+		return nil, err
+	}
+
+	rev := newRevision(authorID, food.RevisionMerge, sourceID, source, nil, nil)
+	result, err := s.mergeRepo.Merge(ctx, sourceID, targetID, authorID, rev)
+	if err != nil {
+		return nil, err
+	}
+	s.logRevision(rev)
+
+	s.logger.Info().
+		Str("source_id", sourceID).
+		Str("target_id", targetID).
+		Int64("ratings", result.Ratings).
+		Int64("saved_foods", result.SavedFoods).
+		Int64("intake_logs", result.IntakeLogs).
+		Msg("Merged duplicate food")
+	return result, nil
+}
+
+// resolveFoodID returns the ID of a food, following the redirect left when
+// it was merged into another food
+func (s *foodService) resolveFoodID(id string) (string, error) {
+	_, err := s.repo.GetByID(id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	target, redirectErr := s.repo.GetRedirect(id)
+	if redirectErr != nil {
+		if errors.Is(redirectErr, sql.ErrNoRows) {
+			return "", err
+		}
+		return "", redirectErr
+	}
+	return target, nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/nutrition"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
+)
+
+// portion is an amount of a single food eaten or planned
+type portion struct {
+	food  food.Food
+	grams float64
+}
+
+// GetNutrientGaps compares a profile's average daily intake, taken either from
+// logged intake or from a generated meal plan, with its nutrient targets
+func (s *recommendationService) GetNutrientGaps(ctx context.Context, userID uuid.UUID, req recommendation.GapRequest) (*recommendation.GapReport, error) {
+	userProfile, err := s.profileRepo.GetByID(req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	if userProfile.UserID != userID {
+		return nil, profile.ErrUnauthorized
+	}
+
+	if req.SuggestionLimit < 1 {
+		req.SuggestionLimit = 5
+	}
+
+	report := &recommendation.GapReport{
+		ProfileID:  userProfile.ID,
+		Source:     req.Source,
+		Gaps:       []recommendation.NutrientGap{},
+		MetTargets: []string{},
+		Unassessed: []string{},
+	}
+
+	var portions []portion
+	switch req.Source {
+	case recommendation.GapSourceMealPlan:
+		if req.Days < 1 {
+			req.Days = 7
+		}
+		report.Days = req.Days
+		portions, err = s.mealPlanPortions(ctx, userProfile, req.Days)
+	default:
+		report.Source = recommendation.GapSourceIntake
+		report.From = &req.From
+		report.To = &req.To
+		report.Days = int(math.Ceil(req.To.Sub(req.From).Hours() / 24))
+		if report.Days < 1 {
+			report.Days = 1
+		}
+		portions, err = s.intakePortions(ctx, userProfile.ID, req.From, req.To)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := s.buildTargets(ctx, userProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.GenerateRulesFromProfile(userProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range targets.Keys() {
+		target := targets[key]
+
+		total, reported := 0.0, false
+		contributions := map[string]*recommendation.FoodContribution{}
+		for _, p := range portions {
+			value, ok := p.food.NutrientValue(key)
+			if !ok {
+				continue
+			}
+			reported = true
+			amount := value * p.grams / 100
+			total += amount
+
+			c, ok := contributions[p.food.ID]
+			if !ok {
+				c = &recommendation.FoodContribution{FoodID: p.food.ID, Name: p.food.Name}
+				contributions[p.food.ID] = c
+			}
+			c.Amount += amount
+		}
+
+		if !reported {
+			report.Unassessed = append(report.Unassessed, key)
+			continue
+		}
+
+		average := total / float64(report.Days)
+		gap := recommendation.NutrientGap{
+			Nutrient:     key,
+			Unit:         target.Unit,
+			DailyAverage: round2(average),
+			Target:       target,
+		}
+
+		switch {
+		case target.Min != nil && average < *target.Min:
+			gap.Status = recommendation.GapStatusDeficit
+			gap.Gap = round2(*target.Min - average)
+			gap.PercentOfTarget = percentOf(average, *target.Min)
+			gap.SuggestedFoods, err = s.foodsRichIn(key, rules, req.SuggestionLimit)
+			if err != nil {
+				return nil, err
+			}
+		case target.Max != nil && average > *target.Max:
+			gap.Status = recommendation.GapStatusExcess
+			gap.Gap = round2(average - *target.Max)
+			gap.PercentOfTarget = percentOf(average, *target.Max)
+			gap.TopContributors = topContributors(contributions, req.SuggestionLimit)
+		default:
+			report.MetTargets = append(report.MetTargets, key)
+			continue
+		}
+
+		report.Gaps = append(report.Gaps, gap)
+	}
+
+	return report, nil
+}
+
+// buildTargets combines age/sex reference intakes, the profile's calorie target
+// and the nutrient rules of the profile's health conditions
+func (s *recommendationService) buildTargets(ctx context.Context, userProfile *profile.UserProfile) (nutrition.Targets, error) {
+	age, sex := 0, ""
+	if u, err := s.userRepo.GetByID(userProfile.UserID); err == nil {
+		if u.DateOfBirth != nil {
+			age = nutrition.AgeFromDateOfBirth(*u.DateOfBirth, time.Now())
+		}
+		sex = u.Gender
+	} else {
+		s.logger.Warn().Err(err).Str("user_id", userProfile.UserID.String()).Msg("Failed to load user for reference intakes, using defaults")
+	}
+
+	targets := nutrition.ReferenceIntakes(age, sex, userProfile.CalorieTarget)
+	if userProfile.CalorieTarget > 0 {
+		targets.Add(nutrition.CalorieTarget(userProfile.CalorieTarget))
+	}
+
+	if len(userProfile.HealthConditions) == 0 {
+		return targets, nil
+	}
+
+	conditions, err := s.referenceRepo.GetHealthConditions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conditions {
+		if !containsString(userProfile.HealthConditions, c.Name) {
+			continue
+		}
+		for _, t := range nutrition.TargetsFromCondition(c.Name, c.NutrientRestrictions, c.NutrientRecommendations) {
+			targets.Add(t)
+		}
+	}
+
+	return targets, nil
+}
+
+func (s *recommendationService) intakePortions(ctx context.Context, profileID uuid.UUID, from, to time.Time) ([]portion, error) {
+	entries, err := s.intakeRepo.ListByProfile(ctx, profileID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	foods := map[string]*food.Food{}
+	portions := make([]portion, 0, len(entries))
+	for _, e := range entries {
+		f, ok := foods[e.FoodID]
+		if !ok {
+			f, err = s.foodRepo.GetByID(e.FoodID)
+			if err != nil {
+				return nil, err
+			}
+			foods[e.FoodID] = f
+		}
+		portions = append(portions, portion{food: *f, grams: e.AmountGrams})
+	}
+	return portions, nil
+}
+
+func (s *recommendationService) mealPlanPortions(ctx context.Context, userProfile *profile.UserProfile, days int) ([]portion, error) {
+	plan, err := s.GetMealPlanRecommendations(ctx, userProfile.ID.String(), days)
+	if err != nil {
+		return nil, err
+	}
+
+	var portions []portion
+	for _, day := range plan.Days {
+		for _, meal := range day.Meals {
+			for _, f := range meal.Foods {
+				portions = append(portions, portion{food: f, grams: f.ServingGrams()})
+			}
+		}
+	}
+	return portions, nil
+}
+
+// foodsRichIn returns the catalog foods with the most of a nutrient per 100g
+// that still satisfy the profile's rules
+func (s *recommendationService) foodsRichIn(nutrient string, rules []recommendation.Rule, limit int) ([]food.Food, error) {
+	candidates, err := s.foodRepo.ListTopByNutrient(nutrient, limit*5)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []food.Food{}
+	for _, f := range candidates {
+		if !s.applyRules(f, rules) {
+			continue
+		}
+		suggestions = append(suggestions, f)
+		if len(suggestions) >= limit {
+			break
+		}
+	}
+	return suggestions, nil
+}
+
+func topContributors(contributions map[string]*recommendation.FoodContribution, limit int) []recommendation.FoodContribution {
+	result := make([]recommendation.FoodContribution, 0, len(contributions))
+	for _, c := range contributions {
+		c.Amount = round2(c.Amount)
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Amount > result[j].Amount })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func percentOf(value, target float64) float64 {
+	if target == 0 {
+		return 0
+	}
+	return round2(value / target * 100)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
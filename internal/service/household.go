@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/domain/household"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+	"github.com/yeboahd24/nutrimatch/internal/domain/user"
+)
+
+type householdService struct {
+	householdRepo household.Repository
+	profileRepo   profile.Repository
+	userRepo      user.Repository
+	logger        zerolog.Logger
+}
+
+func NewHouseholdService(
+	householdRepo household.Repository,
+	profileRepo profile.Repository,
+	userRepo user.Repository,
+	logger zerolog.Logger,
+) HouseholdService {
+	return &householdService{
+		householdRepo: householdRepo,
+		profileRepo:   profileRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+	}
+}
+
+func (s *householdService) CreateHousehold(ctx context.Context, userID uuid.UUID, name string, profileIDs []uuid.UUID) (*household.Household, error) {
+	for _, profileID := range profileIDs {
+		if err := s.verifyProfileOwner(profileID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	h := &household.Household{Name: name, OwnerID: userID}
+	if err := s.householdRepo.Create(ctx, h); err != nil {
+		return nil, fmt.Errorf("failed to create household: %w", err)
+	}
+
+	for _, profileID := range profileIDs {
+		if err := s.householdRepo.AddMember(ctx, h.ID, profileID); err != nil {
+			return nil, fmt.Errorf("failed to add household member: %w", err)
+		}
+	}
+
+	s.logger.Info().Str("household_id", h.ID.String()).Str("owner_id", userID.String()).Msg("Household created successfully")
+
+	return s.householdRepo.GetByID(ctx, h.ID)
+}
+
+func (s *householdService) GetHousehold(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*household.Household, error) {
+	h, err := s.householdRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !h.IsMember(userID) {
+		return nil, household.ErrNotMember
+	}
+	return h, nil
+}
+
+func (s *householdService) ListHouseholds(ctx context.Context, userID uuid.UUID) ([]household.Household, error) {
+	return s.householdRepo.ListByUser(ctx, userID)
+}
+
+func (s *householdService) DeleteHousehold(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	h, err := s.householdRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if h.OwnerID != userID {
+		return household.ErrNotOwner
+	}
+	return s.householdRepo.Delete(ctx, id, userID)
+}
+
+func (s *householdService) AddMember(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, profileID uuid.UUID) (*household.Household, error) {
+	h, err := s.GetHousehold(ctx, userID, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users already in the household may add their own profiles; other users need an invitation
+	if err := s.verifyProfileOwner(profileID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.householdRepo.AddMember(ctx, h.ID, profileID); err != nil {
+		return nil, fmt.Errorf("failed to add household member: %w", err)
+	}
+	return s.householdRepo.GetByID(ctx, h.ID)
+}
+
+func (s *householdService) RemoveMember(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, profileID uuid.UUID) error {
+	h, err := s.GetHousehold(ctx, userID, householdID)
+	if err != nil {
+		return err
+	}
+
+	// The owner can remove anyone; members can only remove their own profiles
+	if h.OwnerID != userID {
+		if err := s.verifyProfileOwner(profileID, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.householdRepo.RemoveMember(ctx, householdID, profileID)
+}
+
+func (s *householdService) InviteMember(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, email string) (*household.Invitation, error) {
+	h, err := s.householdRepo.GetByID(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	if h.OwnerID != userID {
+		return nil, household.ErrNotOwner
+	}
+
+	inv := &household.Invitation{
+		HouseholdID:  householdID,
+		InvitedBy:    userID,
+		InviteeEmail: strings.TrimSpace(email),
+	}
+	if err := s.householdRepo.CreateInvitation(ctx, inv); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	s.logger.Info().Str("household_id", householdID.String()).Str("invitation_id", inv.ID.String()).Msg("Household invitation created")
+
+	return inv, nil
+}
+
+func (s *householdService) ListInvitations(ctx context.Context, userID uuid.UUID) ([]household.Invitation, error) {
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.householdRepo.ListPendingInvitations(ctx, u.Email)
+}
+
+func (s *householdService) AcceptInvitation(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID, profileID uuid.UUID) (*household.Household, error) {
+	inv, err := s.pendingInvitationFor(ctx, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyProfileOwner(profileID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.householdRepo.AddMember(ctx, inv.HouseholdID, profileID); err != nil {
+		return nil, fmt.Errorf("failed to add household member: %w", err)
+	}
+	if err := s.householdRepo.UpdateInvitationStatus(ctx, inv.ID, household.InvitationAccepted); err != nil {
+		return nil, fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	return s.householdRepo.GetByID(ctx, inv.HouseholdID)
+}
+
+func (s *householdService) DeclineInvitation(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error {
+	inv, err := s.pendingInvitationFor(ctx, userID, invitationID)
+	if err != nil {
+		return err
+	}
+	return s.householdRepo.UpdateInvitationStatus(ctx, inv.ID, household.InvitationDeclined)
+}
+
+// pendingInvitationFor loads an invitation and checks it is pending and addressed to the user
+func (s *householdService) pendingInvitationFor(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) (*household.Invitation, error) {
+	inv, err := s.householdRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(u.Email, inv.InviteeEmail) {
+		return nil, household.ErrInvitationNotForUser
+	}
+	if inv.Status != household.InvitationPending {
+		return nil, household.ErrInvitationNotPending
+	}
+	return inv, nil
+}
+
+func (s *householdService) verifyProfileOwner(profileID uuid.UUID, userID uuid.UUID) error {
+	p, err := s.profileRepo.GetByID(profileID)
+	if err != nil {
+		return err
+	}
+	if p.UserID != userID {
+		return profile.ErrUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/household"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
+)
+
+// defaultCalorieTarget is used to size portions for members without a calorie target
+const defaultCalorieTarget = 2000
+
+// mealShares is the fraction of a day's calories served at each meal
+var mealShares = map[string]float64{
+	"breakfast": 0.25,
+	"lunch":     0.35,
+	"dinner":    0.40,
+}
+
+// GetHouseholdMealPlan builds one meal plan that is safe for every member of a
+// household and splits each food into per-member portions sized by calorie target
+func (s *recommendationService) GetHouseholdMealPlan(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, days int) (*recommendation.MealPlan, error) {
+	h, err := s.householdRepo.GetByID(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	if !h.IsMember(userID) {
+		return nil, household.ErrNotMember
+	}
+	if len(h.Members) == 0 {
+		return nil, household.ErrNoMembers
+	}
+
+	profiles := make([]*profile.UserProfile, 0, len(h.Members))
+	for _, m := range h.Members {
+		p, err := s.profileRepo.GetByID(m.ProfileID)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+
+	rules, err := s.householdRules(profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	mealPlan := &recommendation.MealPlan{
+		HouseholdID:  householdID.String(),
+		Days:         make([]recommendation.DailyPlan, days),
+		TotalDays:    days,
+		AppliedRules: rules,
+	}
+
+	mealTypes := []string{"breakfast", "lunch", "dinner"}
+	for i := 0; i < days; i++ {
+		dailyPlan := recommendation.DailyPlan{
+			Date:  "Day " + strconv.Itoa(i+1),
+			Meals: make([]recommendation.Meal, len(mealTypes)),
+		}
+
+		for j, mealType := range mealTypes {
+			mealRules := append([]recommendation.Rule{{
+				Type:      "meal_type",
+				Operation: "include",
+				Target:    mealType,
+				Priority:  100,
+			}}, rules...)
+
+			foods, _, err := s.filterFoods(mealRules, nil, 3, 0) // 3 foods per meal
+			if err != nil {
+				return nil, err
+			}
+
+			dailyPlan.Meals[j] = recommendation.Meal{
+				Type:     mealType,
+				Foods:    foods,
+				Portions: memberPortions(profiles, foods, mealShares[mealType]),
+			}
+		}
+
+		mealPlan.Days[i] = dailyPlan
+	}
+
+	return mealPlan, nil
+}
+
+// householdRules returns the union of every member's allergen, dietary and
+// processing exclusions
+func (s *recommendationService) householdRules(profiles []*profile.UserProfile) ([]recommendation.Rule, error) {
+	var rules []recommendation.Rule
+	seen := map[string]bool{}
+	for _, p := range profiles {
+		memberRules, err := s.GenerateRulesFromProfile(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range memberRules {
+			if rule.Operation != "exclude" || (rule.Type != "allergen" && rule.Type != "dietary" && rule.Type != processingRuleType) {
+				continue
+			}
+			key := rule.Type + ":" + rule.Target
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// memberPortions splits a meal's share of each member's calorie target evenly
+// across the meal's foods and converts it to grams of each food
+func memberPortions(profiles []*profile.UserProfile, foods []food.Food, share float64) []recommendation.MemberPortion {
+	if len(foods) == 0 {
+		return nil
+	}
+
+	portions := make([]recommendation.MemberPortion, 0, len(profiles)*len(foods))
+	for _, p := range profiles {
+		target := p.CalorieTarget
+		if target <= 0 {
+			target = defaultCalorieTarget
+		}
+		perFood := float64(target) * share / float64(len(foods))
+
+		for _, f := range foods {
+			portion := recommendation.MemberPortion{
+				ProfileID:   p.ID,
+				ProfileName: p.ProfileName,
+				FoodID:      f.ID,
+			}
+			if kcal, ok := f.NutrientValue("calories"); ok && kcal > 0 {
+				portion.Grams = round2(perFood / kcal * 100)
+				portion.Calories = round2(perFood)
+			} else {
+				// Without energy data, scale a standard serving by the member's target
+				portion.Grams = round2(f.ServingGrams() * float64(target) / defaultCalorieTarget)
+			}
+			portions = append(portions, portion)
+		}
+	}
+	return portions
+}
@@ -1,13 +1,18 @@
 package service
 
 import (
-	"bufio"
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
 
 	"github.com/rs/zerolog"
 	"github.com/sqlc-dev/pqtype"
@@ -17,33 +22,167 @@ import (
 
 // FoodImporter handles importing food data from the OpenNutrition dataset
 type FoodImporter struct {
+	db      *sql.DB
 	queries *db.Queries
 	logger  zerolog.Logger
 }
 
 // NewFoodImporter creates a new food importer
-func NewFoodImporter(queries *db.Queries, logger zerolog.Logger) *FoodImporter {
+func NewFoodImporter(sqlDB *sql.DB, queries *db.Queries, logger zerolog.Logger) *FoodImporter {
 	return &FoodImporter{
+		db:      sqlDB,
 		queries: queries,
 		logger:  logger,
 	}
 }
 
-// ImportFromTSV imports food data from a TSV file
+// Import modes
+const (
+	ImportInsert = "insert" // Add foods, rejecting rows whose ID is already in the catalog
+	ImportUpsert = "upsert" // Add new foods and update the ones that changed
+)
+
+// Import formats
+const (
+	ImportFormatTSV = "tsv" // OpenNutrition TSV
+	ImportFormatFDC = "fdc" // USDA FoodData Central JSON download
+	ImportFormatOFF = "off" // Open Food Facts JSONL dump
+)
+
+// Import errors
+var (
+	ErrInvalidImportFormat  = errors.New("import format must be tsv, fdc or off")
+	ErrInvalidImportMode    = errors.New("import mode must be insert or upsert")
+	ErrRetireRequiresUpsert = errors.New("retiring missing foods requires an upsert import")
+	ErrImportRunning        = errors.New("another food import is running")
+)
+
+// ImportOptions controls an import
+type ImportOptions struct {
+	Format         string               // ImportFormatTSV (the default), ImportFormatFDC or ImportFormatOFF, for Import
+	BatchSize      int                  // Rows copied into the staging table at a time
+	Workers        int                  // Goroutines parsing rows; one per CPU by default
+	Mode           string               // ImportInsert (the default) or ImportUpsert
+	Retire         bool                 // Retire previously imported foods missing from the file; upsert only
+	CheckpointPath string               // Progress is recorded here after each batch; empty for none
+	RejectPath     string               // Rejected rows are written here; empty to only log them
+	Resume         bool                 // Continue from the checkpoint rather than the start of the file
+	Progress       func(ImportProgress) // Called every few seconds while rows are read; logged when nil
+}
+
+// ImportProgress reports how far an import has read
+type ImportProgress struct {
+	Line          int     // Last line staged
+	Rows          int     // Lines read by this run
+	RowsPerSecond float64 // Lines read per second by this run
+}
+
+// ImportResult counts what an import did, across all runs when resumed
+type ImportResult struct {
+	Imported        int            `json:"imported"` // Added, changed and unchanged foods
+	Added           int            `json:"added"`
+	Changed         int            `json:"changed"`
+	Unchanged       int            `json:"unchanged"`
+	Retired         int            `json:"retired"`
+	Kept            int            `json:"kept"` // Foods left as an admin edited them since their last import
+	Rejected        int            `json:"rejected"`
+	InvalidBarcodes int            `json:"invalid_barcodes"`
+	Ungraded        int            `json:"ungraded"`
+	Unclassified    int            `json:"unclassified"`
+	Translations    int            `json:"translations"`
+	FieldChanges    map[string]int `json:"field_changes,omitempty"` // Changed foods per field
+	ResumedAtLine   int            `json:"-"`                       // First line read by this run when resumed, else 0
+	RowsPerSecond   float64        `json:"-"`                       // Lines read per second by this run, merge included
+}
+
+// WriteSummary writes the counts of an import as an aligned table, followed
+// by the number of changed foods per field, most changed first
+func (r *ImportResult) WriteSummary(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Added\t%d\n", r.Added)
+	fmt.Fprintf(tw, "Changed\t%d\n", r.Changed)
+	fmt.Fprintf(tw, "Unchanged\t%d\n", r.Unchanged)
+	fmt.Fprintf(tw, "Retired\t%d\n", r.Retired)
+	fmt.Fprintf(tw, "Kept\t%d\n", r.Kept)
+	fmt.Fprintf(tw, "Rejected\t%d\n", r.Rejected)
+
+	if len(r.FieldChanges) > 0 {
+		fields := make([]string, 0, len(r.FieldChanges))
+		for field := range r.FieldChanges {
+			fields = append(fields, field)
+		}
+		sort.Slice(fields, func(a, b int) bool {
+			if r.FieldChanges[fields[a]] != r.FieldChanges[fields[b]] {
+				return r.FieldChanges[fields[a]] > r.FieldChanges[fields[b]]
+			}
+			return fields[a] < fields[b]
+		})
+		fmt.Fprintln(tw, "\nChanged fields")
+		for _, field := range fields {
+			fmt.Fprintf(tw, "  %s\t%d\n", field, r.FieldChanges[field])
+		}
+	}
+	return tw.Flush()
+}
+
+// importRow is a parsed row waiting to be staged
+type importRow struct {
+	line         int
+	raw          string // Original record, unless the parser kept a smaller one
+	record       db.Food
+	translations json.RawMessage // Array of food.Translation, nil for none
+}
+
+// requiredImportColumns are the columns every OpenNutrition TSV file has
+var requiredImportColumns = []string{
+	"id", "name", "alternate_names", "description", "type",
+	"source", "serving", "nutrition_100g", "ean_13",
+	"labels", "package_size", "ingredients", "ingredient_analysis",
+}
+
+// ImportFromTSV imports food data from a TSV file, without a checkpoint or
+// reject file
 func (i *FoodImporter) ImportFromTSV(filePath string, batchSize int) (int, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return 0, fmt.Errorf("failed to open file: %w", err)
+	result, err := i.ImportTSV(context.Background(), filePath, ImportOptions{BatchSize: batchSize})
+	return result.Imported, err
+}
+
+// Import imports foods from a file in the format opts.Format names
+func (i *FoodImporter) Import(ctx context.Context, filePath string, opts ImportOptions) (*ImportResult, error) {
+	switch opts.Format {
+	case "", ImportFormatTSV:
+		return i.ImportTSV(ctx, filePath, opts)
+	case ImportFormatFDC:
+		return i.ImportFDC(ctx, filePath, opts)
+	case ImportFormatOFF:
+		return i.ImportOFF(ctx, filePath, opts)
 	}
-	defer file.Close()
+	return &ImportResult{}, ErrInvalidImportFormat
+}
 
-	scanner := bufio.NewScanner(file)
+// ImportTSV imports food data from a TSV file. Lines may be any length.
+// Rows are parsed in parallel and copied in batches into a staging table,
+// then merged into the catalog in one transaction once the whole file is
+// staged, so a failed import leaves the catalog as it was. Rows that fail to
+// parse or merge are rejected with their line number and reason and the
+// import carries on. With a checkpoint path, the file's hash and the offset
+// reached are recorded after each batch, and an interrupted import can be
+// resumed from there with Resume.
+func (i *FoodImporter) ImportTSV(ctx context.Context, filePath string, opts ImportOptions) (*ImportResult, error) {
+	return i.importFile(ctx, filePath, opts, i.openTSV)
+}
 
-	// Read header line
-	if !scanner.Scan() {
-		return 0, fmt.Errorf("failed to read header line")
+// openTSV reads the header of an OpenNutrition TSV file and returns a parser
+// for its rows
+func (i *FoodImporter) openTSV(file *os.File) (*importReader, error) {
+	lines := newLineReader(file)
+	header, ok, err := lines.next()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("failed to read header line")
 	}
-	header := scanner.Text()
 	columns := strings.Split(header, "\t")
 
 	// Create column index map
@@ -53,120 +192,117 @@ func (i *FoodImporter) ImportFromTSV(filePath string, batchSize int) (int, error
 	}
 
 	// Validate required columns
-	requiredColumns := []string{
-		"id", "name", "alternate_names", "description", "type",
-		"source", "serving", "nutrition_100g", "ean_13",
-		"labels", "package_size", "ingredients", "ingredient_analysis",
-	}
-
-	for _, col := range requiredColumns {
+	for _, col := range requiredImportColumns {
 		if _, ok := columnMap[col]; !ok {
-			return 0, fmt.Errorf("required column '%s' not found in TSV file", col)
+			return nil, fmt.Errorf("required column '%s' not found in TSV file", col)
 		}
 	}
 
-	// Import data
-	count := 0
-	batch := make([]db.Food, 0, batchSize)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Split(line, "\t")
-
-		// Skip if we don't have enough fields
+	parse := func(raw string) (importRow, error) {
+		fields := strings.Split(raw, "\t")
 		if len(fields) < len(columns) {
-			i.logger.Warn().Str("line", line).Msg("Skipping line with insufficient fields")
-			continue
-		}
-
-		// Parse food item
-		food, err := i.parseFood(fields, columnMap)
-		if err != nil {
-			i.logger.Warn().Err(err).Str("line", line).Msg("Failed to parse food item")
-			continue
+			return importRow{}, fmt.Errorf("expected %d fields, found %d", len(columns), len(fields))
 		}
-
-		// Marshal complex types to JSON
-		alternateNamesJSON, err := json.Marshal(food.AlternateNames)
-		if err != nil {
-			i.logger.Warn().Err(err).Msg("Failed to marshal alternate names")
-			continue
-		}
-
-		sourceJSON, err := json.Marshal(food.Source)
+		f, err := i.parseFood(fields, columnMap)
 		if err != nil {
-			i.logger.Warn().Err(err).Msg("Failed to marshal source")
-			continue
+			return importRow{}, err
 		}
-
-		servingJSON, err := json.Marshal(food.Serving)
+		translations, err := parseTranslations(f.ID, fields, columnMap)
 		if err != nil {
-			i.logger.Warn().Err(err).Msg("Failed to marshal serving")
-			continue
-		}
-
-		nutritionJSON, err := json.Marshal(food.Nutrition100g)
-		if err != nil {
-			i.logger.Warn().Err(err).Msg("Failed to marshal nutrition")
-			continue
+			return importRow{}, err
 		}
+		return newImportRow(f, translations)
+	}
+	return &importReader{source: lines, header: header, parse: parse, dataset: "opennutrition"}, nil
+}
 
-		labelsJSON, err := json.Marshal(food.Labels)
-		if err != nil {
-			i.logger.Warn().Err(err).Msg("Failed to marshal labels")
-			continue
+// newImportRow checks a parsed food against the limits of the foods table,
+// which the staging table does not enforce, and converts it for staging
+func newImportRow(f food.Food, translations []food.Translation) (importRow, error) {
+	switch {
+	case f.ID == "":
+		return importRow{}, errors.New("id is required")
+	case utf8.RuneCountInString(f.ID) > 50:
+		return importRow{}, errors.New("id is longer than 50 characters")
+	case f.Name == "":
+		return importRow{}, errors.New("name is required")
+	case utf8.RuneCountInString(f.Name) > 255:
+		return importRow{}, errors.New("name is longer than 255 characters")
+	case utf8.RuneCountInString(f.FoodType) > 50:
+		return importRow{}, errors.New("type is longer than 50 characters")
+	case utf8.RuneCountInString(f.InvalidBarcode) > 32:
+		return importRow{}, errors.New("ean_13 is longer than 32 characters")
+	}
+	for _, t := range translations {
+		if utf8.RuneCountInString(t.Name) > 255 {
+			return importRow{}, fmt.Errorf("name_%s is longer than 255 characters", t.Locale)
 		}
+	}
 
-		packageSizeJSON, err := json.Marshal(food.PackageSize)
-		if err != nil {
-			i.logger.Warn().Err(err).Msg("Failed to marshal package size")
-			continue
+	record, err := importedFood(f)
+	if err != nil {
+		return importRow{}, err
+	}
+	row := importRow{record: record}
+	if len(translations) > 0 {
+		if row.translations, err = json.Marshal(translations); err != nil {
+			return importRow{}, fmt.Errorf("failed to marshal translations: %w", err)
 		}
+	}
+	return row, nil
+}
 
-		ingredientAnalysisJSON, err := json.Marshal(food.IngredientAnalysis)
+// importedFood converts a parsed food to its database record
+func importedFood(f food.Food) (db.Food, error) {
+	var err error
+	marshal := func(field string, v interface{}) json.RawMessage {
 		if err != nil {
-			i.logger.Warn().Err(err).Msg("Failed to marshal ingredient analysis")
-			continue
-		}
-
-		batch = append(batch, db.Food{
-			ID:                 food.ID,
-			Name:               food.Name,
-			AlternateNames:     pqtype.NullRawMessage{Valid: true, RawMessage: json.RawMessage(alternateNamesJSON)},
-			Description:        sql.NullString{String: food.Description, Valid: food.Description != ""},
-			FoodType:           sql.NullString{String: food.FoodType, Valid: food.FoodType != ""},
-			Source:             pqtype.NullRawMessage{Valid: true, RawMessage: json.RawMessage(sourceJSON)},
-			Serving:            pqtype.NullRawMessage{Valid: true, RawMessage: json.RawMessage(servingJSON)},
-			Nutrition100g:      pqtype.NullRawMessage{Valid: true, RawMessage: json.RawMessage(nutritionJSON)},
-			Ean13:              sql.NullString{String: food.EAN13, Valid: food.EAN13 != ""},
-			Labels:             pqtype.NullRawMessage{Valid: true, RawMessage: json.RawMessage(labelsJSON)},
-			PackageSize:        pqtype.NullRawMessage{Valid: true, RawMessage: json.RawMessage(packageSizeJSON)},
-			Ingredients:        sql.NullString{String: food.Ingredients, Valid: food.Ingredients != ""},
-			IngredientAnalysis: pqtype.NullRawMessage{Valid: true, RawMessage: json.RawMessage(ingredientAnalysisJSON)},
-		})
-		count++
-
-		// Process batch
-		if len(batch) >= batchSize {
-			if err := i.processBatch(batch); err != nil {
-				return count, fmt.Errorf("failed to process batch: %w", err)
-			}
-			batch = make([]db.Food, 0, batchSize)
+			return nil
 		}
-	}
-
-	// Process remaining items
-	if len(batch) > 0 {
-		if err := i.processBatch(batch); err != nil {
-			return count, fmt.Errorf("failed to process final batch: %w", err)
+		raw, marshalErr := json.Marshal(v)
+		if marshalErr != nil {
+			err = fmt.Errorf("failed to marshal %s: %w", field, marshalErr)
 		}
+		return raw
 	}
 
-	if err := scanner.Err(); err != nil {
-		return count, fmt.Errorf("scanner error: %w", err)
+	// Marshal complex types to JSON
+	alternateNamesJSON := marshal("alternate names", f.AlternateNames)
+	sourceJSON := marshal("source", f.Source)
+	servingJSON := marshal("serving", f.Serving)
+	nutritionJSON := marshal("nutrition", f.Nutrition100g)
+	labelsJSON := marshal("labels", f.Labels)
+	packageSizeJSON := marshal("package size", f.PackageSize)
+	ingredientAnalysisJSON := marshal("ingredient analysis", f.IngredientAnalysis)
+	nutriScoreJSON := marshal("nutri-score", f.NutriScore)
+	novaMarkersJSON := marshal("NOVA markers", f.NovaMarkers)
+	ingredientTreeJSON := marshal("ingredient tree", f.IngredientTree)
+	if err != nil {
+		return db.Food{}, err
 	}
 
-	return count, nil
+	return db.Food{
+		ID:                 f.ID,
+		Name:               f.Name,
+		AlternateNames:     pqtype.NullRawMessage{Valid: true, RawMessage: alternateNamesJSON},
+		Description:        sql.NullString{String: f.Description, Valid: f.Description != ""},
+		FoodType:           sql.NullString{String: f.FoodType, Valid: f.FoodType != ""},
+		Source:             pqtype.NullRawMessage{Valid: true, RawMessage: sourceJSON},
+		Serving:            pqtype.NullRawMessage{Valid: true, RawMessage: servingJSON},
+		Nutrition100g:      pqtype.NullRawMessage{Valid: true, RawMessage: nutritionJSON},
+		Ean13:              sql.NullString{String: f.EAN13, Valid: f.EAN13 != ""},
+		Labels:             pqtype.NullRawMessage{Valid: true, RawMessage: labelsJSON},
+		PackageSize:        pqtype.NullRawMessage{Valid: true, RawMessage: packageSizeJSON},
+		Ingredients:        sql.NullString{String: f.Ingredients, Valid: f.Ingredients != ""},
+		IngredientAnalysis: pqtype.NullRawMessage{Valid: true, RawMessage: ingredientAnalysisJSON},
+		InvalidBarcode:     sql.NullString{String: f.InvalidBarcode, Valid: f.InvalidBarcode != ""},
+		NutriScoreGrade:    sql.NullString{String: f.NutriScoreGrade, Valid: f.NutriScore != nil},
+		NutriScorePoints:   sql.NullInt16{Int16: int16(nutriScorePoints(f.NutriScore)), Valid: f.NutriScore != nil},
+		NutriScore:         pqtype.NullRawMessage{Valid: f.NutriScore != nil, RawMessage: nutriScoreJSON},
+		NovaGroup:          sql.NullInt16{Int16: int16(f.NovaGroup), Valid: f.NovaGroup != 0},
+		NovaMarkers:        pqtype.NullRawMessage{Valid: f.NovaGroup != 0, RawMessage: novaMarkersJSON},
+		IngredientTree:     pqtype.NullRawMessage{Valid: f.IngredientTree != nil, RawMessage: ingredientTreeJSON},
+	}, nil
 }
 
 // parseFood parses a food item from TSV fields
@@ -179,7 +315,7 @@ func (i *FoodImporter) parseFood(fields []string, columnMap map[string]int) (foo
 	f.Name = fields[columnMap["name"]]
 	f.Description = fields[columnMap["description"]]
 	f.FoodType = fields[columnMap["type"]]
-	f.EAN13 = fields[columnMap["ean_13"]]
+	f.EAN13, f.InvalidBarcode = normalizeImportedBarcode(fields[columnMap["ean_13"]])
 	f.Ingredients = fields[columnMap["ingredients"]]
 
 	// Parse JSON fields
@@ -211,35 +347,200 @@ func (i *FoodImporter) parseFood(fields []string, columnMap map[string]int) (foo
 		return f, fmt.Errorf("failed to parse ingredient_analysis: %w", err)
 	}
 
+	f.Classify()
+
 	return f, nil
 }
 
-// processBatch processes a batch of food items
-func (i *FoodImporter) processBatch(foods []db.Food) error {
+// parseTranslations reads a food's optional translated columns, named after
+// the English column and the locale such as name_fr, alternate_names_fr and
+// description_fr. A locale is imported when its name is set.
+func parseTranslations(foodID string, fields []string, columnMap map[string]int) ([]food.Translation, error) {
+	var translations []food.Translation
+	for _, locale := range food.SupportedLocales {
+		if locale == food.DefaultLocale {
+			continue
+		}
+		name := strings.TrimSpace(optionalField(fields, columnMap, "name_"+locale))
+		if name == "" {
+			continue
+		}
+
+		t := food.Translation{
+			FoodID:      foodID,
+			Locale:      locale,
+			Name:        name,
+			Description: optionalField(fields, columnMap, "description_"+locale),
+		}
+		if raw := optionalField(fields, columnMap, "alternate_names_"+locale); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &t.AlternateNames); err != nil {
+				return nil, fmt.Errorf("failed to parse alternate_names_%s: %w", locale, err)
+			}
+		}
+		translations = append(translations, t)
+	}
+	return translations, nil
+}
+
+// optionalField returns the value of a column that may be missing from the file
+func optionalField(fields []string, columnMap map[string]int, column string) string {
+	idx, ok := columnMap[column]
+	if !ok || idx >= len(fields) {
+		return ""
+	}
+	return fields[idx]
+}
+
+// importRunTime returns the time an import run is stamped with, truncated to
+// the microseconds Postgres stores so that it compares equal once stored
+func importRunTime() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// BackfillIngredientTrees parses the ingredient statements of foods stored
+// without an ingredient tree and returns how many were parsed
+func (i *FoodImporter) BackfillIngredientTrees(batchSize int) (int, error) {
 	ctx := context.Background()
+	parsed, err := i.backfill(batchSize,
+		func(afterID string) ([]db.Food, error) {
+			return i.queries.ListUnparsedFoods(ctx, db.ListUnparsedFoodsParams{AfterID: afterID, RowLimit: int32(batchSize)})
+		},
+		func(f *food.Food) (bool, error) {
+			if f.IngredientTree == nil {
+				return false, nil
+			}
+			tree, err := json.Marshal(f.IngredientTree)
+			if err != nil {
+				return false, err
+			}
+			return true, i.queries.UpdateFoodIngredientTree(ctx, db.UpdateFoodIngredientTreeParams{
+				ID:             f.ID,
+				IngredientTree: pqtype.NullRawMessage{RawMessage: tree, Valid: true},
+			})
+		})
+	if parsed > 0 {
+		i.logger.Info().Int("parsed", parsed).Msg("Backfilled ingredient trees")
+	}
+	return parsed, err
+}
 
-	// Process all items in the transaction using the same queries instance
-	for _, f := range foods {
-		_, err := i.queries.CreateFood(ctx, db.CreateFoodParams{
-			ID:                 f.ID,
-			Name:               f.Name,
-			AlternateNames:     f.AlternateNames,
-			Description:        f.Description,
-			FoodType:           f.FoodType,
-			Source:             f.Source,
-			Serving:            f.Serving,
-			Nutrition100g:      f.Nutrition100g,
-			Ean13:              f.Ean13,
-			Labels:             f.Labels,
-			PackageSize:        f.PackageSize,
-			Ingredients:        f.Ingredients,
-			IngredientAnalysis: f.IngredientAnalysis,
+// BackfillNutriScores grades foods stored without a Nutri-Score, such as
+// those imported before grading existed, and returns how many were graded
+func (i *FoodImporter) BackfillNutriScores(batchSize int) (int, error) {
+	ctx := context.Background()
+	graded, err := i.backfill(batchSize,
+		func(afterID string) ([]db.Food, error) {
+			return i.queries.ListUngradedFoods(ctx, db.ListUngradedFoodsParams{AfterID: afterID, RowLimit: int32(batchSize)})
+		},
+		func(f *food.Food) (bool, error) {
+			score := food.ComputeNutriScore(f)
+			if score == nil {
+				return false, nil
+			}
+			breakdown, err := json.Marshal(score)
+			if err != nil {
+				return false, err
+			}
+			return true, i.queries.UpdateFoodNutriScore(ctx, db.UpdateFoodNutriScoreParams{
+				ID:               f.ID,
+				NutriScoreGrade:  sql.NullString{String: score.Grade, Valid: true},
+				NutriScorePoints: sql.NullInt16{Int16: int16(score.Score), Valid: true},
+				NutriScore:       pqtype.NullRawMessage{RawMessage: breakdown, Valid: true},
+			})
 		})
+	if graded > 0 {
+		i.logger.Info().Int("graded", graded).Msg("Backfilled Nutri-Scores")
+	}
+	return graded, err
+}
+
+// BackfillNovaGroups classifies foods stored without a NOVA group and
+// returns how many were classified
+func (i *FoodImporter) BackfillNovaGroups(batchSize int) (int, error) {
+	ctx := context.Background()
+	classified, err := i.backfill(batchSize,
+		func(afterID string) ([]db.Food, error) {
+			return i.queries.ListUnclassifiedFoods(ctx, db.ListUnclassifiedFoodsParams{AfterID: afterID, RowLimit: int32(batchSize)})
+		},
+		func(f *food.Food) (bool, error) {
+			nova := food.ClassifyNOVA(f)
+			if nova == nil {
+				return false, nil
+			}
+			markers, err := json.Marshal(nova.Markers)
+			if err != nil {
+				return false, err
+			}
+			return true, i.queries.UpdateFoodNova(ctx, db.UpdateFoodNovaParams{
+				ID:          f.ID,
+				NovaGroup:   sql.NullInt16{Int16: int16(nova.Group), Valid: true},
+				NovaMarkers: pqtype.NullRawMessage{RawMessage: markers, Valid: true},
+			})
+		})
+	if classified > 0 {
+		i.logger.Info().Int("classified", classified).Msg("Backfilled NOVA groups")
+	}
+	return classified, err
+}
+
+// backfill pages through the foods list returns, by ID, calling update on
+// each and counting those it changed
+func (i *FoodImporter) backfill(batchSize int, list func(afterID string) ([]db.Food, error), update func(f *food.Food) (bool, error)) (int, error) {
+	updated := 0
+	afterID := ""
+	for {
+		rows, err := list(afterID)
 		if err != nil {
-			i.logger.Error().Err(err).Str("food_id", f.ID).Msg("Failed to insert food")
-			return fmt.Errorf("failed to insert food %s: %w", f.ID, err)
+			return updated, fmt.Errorf("failed to list foods to backfill: %w", err)
+		}
+		if len(rows) == 0 {
+			return updated, nil
+		}
+
+		for _, row := range rows {
+			afterID = row.ID
+			f := backfillFood(row)
+			changed, err := update(&f)
+			if err != nil {
+				return updated, fmt.Errorf("failed to backfill food %s: %w", row.ID, err)
+			}
+			if changed {
+				updated++
+			}
 		}
 	}
+}
 
-	return nil
+// backfillFood decodes the columns the derived classifications are computed from
+func backfillFood(row db.Food) food.Food {
+	f := food.Food{
+		ID:          row.ID,
+		FoodType:    row.FoodType.String,
+		Ingredients: row.Ingredients.String,
+	}
+	json.Unmarshal(row.Nutrition100g.RawMessage, &f.Nutrition100g)
+	json.Unmarshal(row.IngredientAnalysis.RawMessage, &f.IngredientAnalysis)
+	f.IngredientTree = food.ParseIngredients(f.Ingredients)
+	return f
+}
+
+func nutriScorePoints(score *food.NutriScore) int {
+	if score == nil {
+		return 0
+	}
+	return score.Score
+}
+
+// normalizeImportedBarcode returns the GTIN-13 for a source barcode, or the
+// raw value as invalid when it is not a valid EAN/UPC code
+func normalizeImportedBarcode(raw string) (gtin string, invalid string) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", ""
+	}
+	gtin, err := food.NormalizeGTIN(raw)
+	if err != nil {
+		return "", raw
+	}
+	return gtin, ""
 }
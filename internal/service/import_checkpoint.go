@@ -0,0 +1,162 @@
+package service
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCheckpointMismatch is returned when resuming an import of a file that
+// changed since its checkpoint was recorded
+var ErrCheckpointMismatch = errors.New("import file changed since the checkpoint was recorded")
+
+// importCheckpoint records how far an import got. Everything before Offset
+// has been staged or rejected, so an interrupted import resumes there.
+type importCheckpoint struct {
+	File         string       `json:"file"`
+	SHA256       string       `json:"sha256"`
+	Mode         string       `json:"mode"`
+	RunStartedAt time.Time    `json:"run_started_at"` // Stamped on every food the import sees
+	Offset       int64        `json:"offset"`         // Byte offset of the next unread line
+	Line         int          `json:"line"`           // Number of the last line read, the header being line 1
+	Staged       int          `json:"staged"`         // Rows in the staging table at this point
+	RejectOffset int64        `json:"reject_offset"`  // Size of the reject file at this point
+	Result       ImportResult `json:"result"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// loadCheckpoint reads a checkpoint, returning nil when there is none
+func loadCheckpoint(path string) (*importCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	var c importCheckpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// save writes the checkpoint to a temporary file and renames it into place,
+// so that a crash never leaves a partly written checkpoint
+func (c *importCheckpoint) save(path string) error {
+	c.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// hashFile returns the hex SHA-256 of a file's contents
+func hashFile(file *os.File) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to hash import file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// rejectWriter writes rejected rows to a TSV file: the line number, the
+// reason and then the original row, so that fixed rows can be re-imported
+// by dropping the first two columns
+type rejectWriter struct {
+	file *os.File
+	w    *bufio.Writer
+}
+
+// openRejectWriter creates the reject file, or when resuming truncates it to
+// the size recorded in the checkpoint so rows rejected after the checkpoint
+// are not written twice
+func openRejectWriter(path, header string, resumeAt int64) (*rejectWriter, error) {
+	flags := os.O_RDWR | os.O_CREATE
+	if resumeAt == 0 {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open reject file: %w", err)
+	}
+	if err := file.Truncate(resumeAt); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to truncate reject file: %w", err)
+	}
+	if _, err := file.Seek(resumeAt, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	r := &rejectWriter{file: file, w: bufio.NewWriter(file)}
+	if resumeAt == 0 {
+		if _, err := r.w.WriteString("line\treason\t" + header + "\n"); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// reject writes one rejected row
+func (r *rejectWriter) reject(line int, reason, raw string) error {
+	reason = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(reason)
+	_, err := r.w.WriteString(strconv.Itoa(line) + "\t" + reason + "\t" + raw + "\n")
+	return err
+}
+
+// sync flushes the rejected rows to disk and returns the file's size
+func (r *rejectWriter) sync() (int64, error) {
+	if err := r.w.Flush(); err != nil {
+		return 0, fmt.Errorf("failed to write reject file: %w", err)
+	}
+	if err := r.file.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to write reject file: %w", err)
+	}
+	return r.file.Seek(0, io.SeekCurrent)
+}
+
+func (r *rejectWriter) Close() error {
+	if err := r.w.Flush(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
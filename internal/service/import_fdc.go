@@ -0,0 +1,386 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// fdcNutrients maps USDA FoodData Central nutrient IDs onto the canonical
+// nutrient keys. Where FDC measures a nutrient several ways, the IDs are
+// listed in order of preference and the first one a food has is used.
+var fdcNutrients = []struct {
+	key string
+	ids []int
+}{
+	{"calories", []int{1008, 2048, 2047, 1062}}, // Energy; Atwater specific and general factors; kJ
+	{"protein", []int{1003}},
+	{"carbohydrates", []int{1005, 1050}}, // By difference, by summation
+	{"total_fat", []int{1004, 1085}},     // Total lipid, NLEA
+	{"saturated_fats", []int{1258}},
+	{"trans_fats", []int{1257}},
+	{"cholesterol", []int{1253}},
+	{"dietary_fiber", []int{1079}},
+	{"total_sugars", []int{2000, 1063}},
+	{"added_sugars", []int{1235}},
+	{"sodium", []int{1093}},
+	{"potassium", []int{1092}},
+	{"calcium", []int{1087}},
+	{"iron", []int{1089}},
+	{"magnesium", []int{1090}},
+	{"zinc", []int{1095}},
+	{"vitamin_a", []int{1106}}, // RAE; IU cannot be converted without knowing the source
+	{"vitamin_c", []int{1162}},
+	{"vitamin_d", []int{1114, 1110}}, // mcg, IU
+	{"vitamin_b12", []int{1178}},
+	{"folate", []int{1177, 1190}}, // Total, DFE
+}
+
+// fdcOmega3 are the omega-3 fatty acids FDC reports separately, which are
+// summed into omega_3: ALA, EPA, DPA and DHA
+var fdcOmega3 = []int{1404, 1278, 1280, 1272}
+
+// fdcFood is a food from a FoodData Central JSON download. Foundation, SR
+// Legacy and Branded foods share this shape, each setting some fields.
+type fdcFood struct {
+	FDCID                    int               `json:"fdcId"`
+	Description              string            `json:"description"`
+	DataType                 string            `json:"dataType"`
+	FoodCategory             json.RawMessage   `json:"foodCategory"` // An object, or a string in some releases
+	BrandedFoodCategory      string            `json:"brandedFoodCategory"`
+	BrandOwner               string            `json:"brandOwner"`
+	BrandName                string            `json:"brandName"`
+	GTINUPC                  string            `json:"gtinUpc"`
+	Ingredients              string            `json:"ingredients"`
+	ServingSize              float64           `json:"servingSize"`
+	ServingSizeUnit          string            `json:"servingSizeUnit"`
+	HouseholdServingFullText string            `json:"householdServingFullText"`
+	FoodNutrients            []fdcFoodNutrient `json:"foodNutrients"`
+	FoodPortions             []fdcFoodPortion  `json:"foodPortions"`
+}
+
+// fdcFoodNutrient is the amount of a nutrient in 100g of a food
+type fdcFoodNutrient struct {
+	Nutrient struct {
+		ID       int    `json:"id"`
+		UnitName string `json:"unitName"`
+	} `json:"nutrient"`
+	Amount *float64 `json:"amount"`
+}
+
+// fdcFoodPortion is a household measure of a Foundation or SR Legacy food
+type fdcFoodPortion struct {
+	Amount             float64 `json:"amount"`
+	GramWeight         float64 `json:"gramWeight"`
+	Modifier           string  `json:"modifier"`
+	PortionDescription string  `json:"portionDescription"`
+	MeasureUnit        struct {
+		Name string `json:"name"`
+	} `json:"measureUnit"`
+}
+
+// ImportFDC imports foods from a USDA FoodData Central JSON download, such
+// as the Foundation, SR Legacy or Branded foods file. Foods are given the ID
+// "fdc_" and their FDC ID, so they sit alongside the OpenNutrition foods, and
+// record the FDC ID in their source. Branded foods whose barcode another food
+// already has are rejected. Imports run as ImportTSV's do, with records
+// counted in place of lines.
+func (i *FoodImporter) ImportFDC(ctx context.Context, filePath string, opts ImportOptions) (*ImportResult, error) {
+	return i.importFile(ctx, filePath, opts, openFDC)
+}
+
+// fdcDatasets names the dataset of each FoodData Central download after the
+// array holding its foods
+var fdcDatasets = map[string]string{
+	"FoundationFoods": "fdc_foundation",
+	"SRLegacyFoods":   "fdc_sr_legacy",
+	"BrandedFoods":    "fdc_branded",
+	"SurveyFoods":     "fdc_survey",
+}
+
+// fdcReader reads the foods of a FoodData Central download: an object
+// holding one array of foods, named after the data type
+type fdcReader struct {
+	dec    *json.Decoder
+	record int
+	done   bool
+}
+
+// openFDC reads up to the first food of a FoodData Central download
+func openFDC(file *os.File) (*importReader, error) {
+	r := &fdcReader{dec: json.NewDecoder(bufio.NewReaderSize(file, 1024*1024))}
+	var key string
+	for _, want := range []string{"{", "", "["} {
+		tok, err := r.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read FoodData Central file: %w", err)
+		}
+		if want == "" {
+			var ok bool
+			if key, ok = tok.(string); !ok {
+				return nil, errors.New("not a FoodData Central JSON download")
+			}
+			continue
+		}
+		if d, ok := tok.(json.Delim); !ok || d.String() != want {
+			return nil, errors.New("not a FoodData Central JSON download")
+		}
+	}
+
+	dataset, ok := fdcDatasets[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown FoodData Central download %q", key)
+	}
+	// Branded foods are packaged products that Open Food Facts may already
+	// have added under the same barcode
+	return &importReader{source: r, header: "food", parse: parseFDCFood, dataset: dataset, dedupeBarcodes: dataset == "fdc_branded"}, nil
+}
+
+// next returns the next food compacted onto one line, so that it can be
+// written to the reject file
+func (r *fdcReader) next() (string, bool, error) {
+	if r.done || !r.dec.More() {
+		r.done = true
+		return "", false, nil
+	}
+	var raw json.RawMessage
+	if err := r.dec.Decode(&raw); err != nil {
+		return "", false, fmt.Errorf("failed to read food %d: %w", r.record+1, err)
+	}
+	r.record++
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return "", false, fmt.Errorf("failed to read food %d: %w", r.record, err)
+	}
+	return buf.String(), true, nil
+}
+
+func (r *fdcReader) position() (int64, int) {
+	return r.dec.InputOffset(), r.record
+}
+
+// seek skips the foods already read, since a decoder cannot start partway
+// through an array
+func (r *fdcReader) seek(offset int64, record int) error {
+	for r.record < record {
+		if _, ok, err := r.next(); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("%w: the file ends before food %d", ErrCheckpointMismatch, record)
+		}
+	}
+	if r.dec.InputOffset() != offset {
+		return fmt.Errorf("%w: food %d ends at byte %d, not %d", ErrCheckpointMismatch, record, r.dec.InputOffset(), offset)
+	}
+	return nil
+}
+
+// parseFDCFood parses a FoodData Central food into an import row
+func parseFDCFood(raw string) (importRow, error) {
+	var item fdcFood
+	if err := json.Unmarshal([]byte(raw), &item); err != nil {
+		return importRow{}, fmt.Errorf("failed to parse food: %w", err)
+	}
+	if item.FDCID <= 0 {
+		return importRow{}, errors.New("fdcId is required")
+	}
+
+	fdcID := strconv.Itoa(item.FDCID)
+	source := map[string]string{
+		"type":      "fdc",
+		"id":        fdcID,
+		"data_type": item.DataType,
+		"url":       "https://fdc.nal.usda.gov/food-details/" + fdcID + "/nutrients",
+	}
+	if item.BrandOwner != "" {
+		source["brand_owner"] = item.BrandOwner
+	}
+	if item.BrandName != "" {
+		source["brand_name"] = item.BrandName
+	}
+
+	f := food.Food{
+		ID:            "fdc_" + fdcID,
+		Name:          strings.TrimSpace(item.Description),
+		FoodType:      fdcFoodType(item),
+		Source:        []map[string]string{source},
+		Serving:       fdcServing(item),
+		Nutrition100g: fdcNutrition(item.FoodNutrients),
+		Ingredients:   strings.TrimSpace(item.Ingredients),
+	}
+	if item.GTINUPC != "" {
+		f.EAN13, f.InvalidBarcode = normalizeImportedBarcode(item.GTINUPC)
+	}
+	f.Classify()
+	return newImportRow(f, nil)
+}
+
+// fdcNutrition converts a food's nutrients to the canonical keys and units
+func fdcNutrition(nutrients []fdcFoodNutrient) food.Nutrients {
+	amounts := make(map[int]fdcFoodNutrient, len(nutrients))
+	for _, n := range nutrients {
+		if n.Amount != nil && *n.Amount >= 0 && !math.IsNaN(*n.Amount) {
+			amounts[n.Nutrient.ID] = n
+		}
+	}
+
+	var values food.Nutrients
+	for _, mapping := range fdcNutrients {
+		info, _ := food.LookupNutrient(mapping.key)
+		for _, id := range mapping.ids {
+			n, ok := amounts[id]
+			if !ok {
+				continue
+			}
+			if v, ok := convertNutrientUnit(mapping.key, *n.Amount, n.Nutrient.UnitName, info.Unit); ok {
+				values.Set(mapping.key, roundNutrient(v))
+				break
+			}
+		}
+	}
+
+	var omega3 float64
+	found := false
+	for _, id := range fdcOmega3 {
+		if n, ok := amounts[id]; ok {
+			if v, ok := convertNutrientUnit("omega_3", *n.Amount, n.Nutrient.UnitName, "g"); ok {
+				omega3 += v
+				found = true
+			}
+		}
+	}
+	if found {
+		values.Set("omega_3", roundNutrient(omega3))
+	}
+	return values
+}
+
+// roundNutrient rounds a converted amount to 4 decimal places, dropping the
+// noise unit conversion and summing leave
+func roundNutrient(v float64) float64 {
+	return math.Round(v*1e4) / 1e4
+}
+
+// nutrientMassUnits are the mass units nutrient amounts come in, in grams
+var nutrientMassUnits = map[string]float64{
+	"g":   1,
+	"mg":  1e-3,
+	"mcg": 1e-6,
+}
+
+// iuPerMicrogram converts the nutrients measured in international units
+// that have a fixed conversion
+var iuPerMicrogram = map[string]float64{
+	"vitamin_d": 40,
+}
+
+// convertNutrientUnit converts an amount of a nutrient between units,
+// returning false when they cannot be converted
+func convertNutrientUnit(key string, v float64, from, to string) (float64, bool) {
+	from, to = normalizeNutrientUnit(from), normalizeNutrientUnit(to)
+	switch {
+	case from == to:
+		return v, true
+	case from == "kj" && to == "kcal":
+		return v / 4.184, true
+	case from == "iu" && iuPerMicrogram[key] > 0:
+		from, v = "mcg", v/iuPerMicrogram[key]
+	}
+	fromGrams, ok := nutrientMassUnits[from]
+	if !ok {
+		return 0, false
+	}
+	toGrams, ok := nutrientMassUnits[to]
+	if !ok {
+		return 0, false
+	}
+	return v * fromGrams / toGrams, true
+}
+
+// normalizeNutrientUnit lowercases a unit and spells micrograms one way
+func normalizeNutrientUnit(unit string) string {
+	switch u := strings.ToLower(strings.TrimSpace(unit)); u {
+	case "µg", "μg", "ug":
+		return "mcg"
+	default:
+		return u
+	}
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fdcFoodType derives a food type from a food's FDC category, such as
+// "dairy_and_egg_products"
+func fdcFoodType(item fdcFood) string {
+	category := item.BrandedFoodCategory
+	if category == "" && len(item.FoodCategory) > 0 {
+		var named struct {
+			Description string `json:"description"`
+		}
+		if err := json.Unmarshal(item.FoodCategory, &named); err == nil {
+			category = named.Description
+		} else {
+			json.Unmarshal(item.FoodCategory, &category)
+		}
+	}
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(category), "_"), "_")
+	if len(slug) > 50 {
+		slug = strings.TrimRight(slug[:50], "_")
+	}
+	return slug
+}
+
+// fdcServing returns a branded food's labelled serving, or the first
+// household measure of other foods
+func fdcServing(item fdcFood) food.Serving {
+	if item.ServingSize > 0 {
+		s := food.Serving{Amount: item.ServingSize, Unit: strings.ToLower(item.ServingSizeUnit)}
+		switch s.Unit {
+		case "grm":
+			s.Unit = "g"
+		case "mlt":
+			s.Unit = "ml"
+		}
+		if s.Unit == "g" {
+			s.Grams = s.Amount
+		}
+		if text := strings.TrimSpace(item.HouseholdServingFullText); text != "" {
+			s.Extra = map[string]json.RawMessage{"description": jsonString(text)}
+		}
+		return s
+	}
+
+	for _, p := range item.FoodPortions {
+		if p.GramWeight <= 0 {
+			continue
+		}
+		s := food.Serving{Amount: p.Amount, Unit: p.MeasureUnit.Name, Grams: p.GramWeight}
+		if s.Amount <= 0 {
+			s.Amount = 1
+		}
+		if s.Unit == "" || s.Unit == "undetermined" {
+			s.Unit = p.Modifier
+		}
+		if text := strings.TrimSpace(p.PortionDescription); text != "" {
+			s.Extra = map[string]json.RawMessage{"description": jsonString(text)}
+		}
+		return s
+	}
+	return food.Serving{}
+}
+
+// jsonString encodes a string as a JSON value
+func jsonString(s string) json.RawMessage {
+	raw, _ := json.Marshal(s)
+	return raw
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// offNutrients maps Open Food Facts nutriments onto the canonical nutrient
+// keys. Per-100g values are stored in grams, except energy in kcal or kJ and
+// the fruit and vegetable estimate in percent. Where several fields measure
+// a nutrient, the first one a product has is used.
+var offNutrients = []struct {
+	key    string
+	fields []string
+	unit   string
+}{
+	{"calories", []string{"energy-kcal_100g"}, "kcal"},
+	{"calories", []string{"energy_100g"}, "kj"},
+	{"protein", []string{"proteins_100g"}, "g"},
+	{"carbohydrates", []string{"carbohydrates_100g"}, "g"},
+	{"total_fat", []string{"fat_100g"}, "g"},
+	{"saturated_fats", []string{"saturated-fat_100g"}, "g"},
+	{"trans_fats", []string{"trans-fat_100g"}, "g"},
+	{"cholesterol", []string{"cholesterol_100g"}, "g"},
+	{"dietary_fiber", []string{"fiber_100g"}, "g"},
+	{"total_sugars", []string{"sugars_100g"}, "g"},
+	{"added_sugars", []string{"added-sugars_100g"}, "g"},
+	{"sodium", []string{"sodium_100g"}, "g"},
+	{"potassium", []string{"potassium_100g"}, "g"},
+	{"calcium", []string{"calcium_100g"}, "g"},
+	{"iron", []string{"iron_100g"}, "g"},
+	{"magnesium", []string{"magnesium_100g"}, "g"},
+	{"zinc", []string{"zinc_100g"}, "g"},
+	{"vitamin_a", []string{"vitamin-a_100g"}, "g"},
+	{"vitamin_c", []string{"vitamin-c_100g"}, "g"},
+	{"vitamin_d", []string{"vitamin-d_100g"}, "g"},
+	{"vitamin_b12", []string{"vitamin-b12_100g"}, "g"},
+	{"folate", []string{"folates_100g", "vitamin-b9_100g"}, "g"},
+	{"omega_3", []string{"omega-3-fat_100g"}, "g"},
+	{food.FruitVegKey, []string{
+		"fruits-vegetables-nuts_100g",
+		"fruits-vegetables-nuts-estimate_100g",
+		"fruits-vegetables-nuts-estimate-from-ingredients_100g",
+	}, "%"},
+}
+
+// offSaltField is used for sodium when a product only lists salt, which is
+// 40% sodium by weight
+const offSaltField = "salt_100g"
+
+// offAllergens maps Open Food Facts allergens onto the reference allergen
+// names where they differ, so that allergen rules and comparisons find them
+var offAllergens = map[string]string{
+	"nuts":         "tree_nuts",
+	"soybeans":     "soy",
+	"crustaceans":  "shellfish",
+	"molluscs":     "shellfish",
+	"sesame-seeds": "sesame",
+}
+
+// offProduct is a product from the Open Food Facts JSONL dump. Only the
+// fields the importer reads are decoded, and only those are kept as the
+// product's record, since dump lines carry much more.
+type offProduct struct {
+	Code                string                     `json:"code"`
+	ProductName         string                     `json:"product_name,omitempty"`
+	ProductNameEN       string                     `json:"product_name_en,omitempty"`
+	GenericName         string                     `json:"generic_name,omitempty"`
+	Brands              string                     `json:"brands,omitempty"`
+	Quantity            string                     `json:"quantity,omitempty"`
+	ProductQuantity     json.RawMessage            `json:"product_quantity,omitempty"` // A number or numeric string
+	ProductQuantityUnit string                     `json:"product_quantity_unit,omitempty"`
+	ServingSize         string                     `json:"serving_size,omitempty"`
+	ServingQuantity     json.RawMessage            `json:"serving_quantity,omitempty"`
+	ServingQuantityUnit string                     `json:"serving_quantity_unit,omitempty"`
+	IngredientsText     string                     `json:"ingredients_text,omitempty"`
+	IngredientsTextEN   string                     `json:"ingredients_text_en,omitempty"`
+	AllergensTags       []string                   `json:"allergens_tags,omitempty"`
+	LabelsTags          []string                   `json:"labels_tags,omitempty"`
+	CategoriesTags      []string                   `json:"categories_tags,omitempty"`
+	CountriesTags       []string                   `json:"countries_tags,omitempty"`
+	NutriscoreGrade     string                     `json:"nutriscore_grade,omitempty"`
+	NovaGroup           json.RawMessage            `json:"nova_group,omitempty"`
+	LastModifiedT       json.RawMessage            `json:"last_modified_t,omitempty"`
+	Nutriments          map[string]json.RawMessage `json:"nutriments,omitempty"`
+}
+
+// ImportOFF imports products from the Open Food Facts JSONL dump, plain or
+// gzip-compressed, one product per line. Products are given the ID "off_"
+// and their barcode and keep OFF's own grades, brands and countries in
+// their source. Products whose barcode another food already has are
+// rejected, so the dump only fills the gaps in the catalog. Imports run as
+// ImportTSV's do; a compressed dump is resumed by reading up to the
+// checkpoint again.
+func (i *FoodImporter) ImportOFF(ctx context.Context, filePath string, opts ImportOptions) (*ImportResult, error) {
+	return i.importFile(ctx, filePath, opts, openOFF)
+}
+
+// openOFF opens an Open Food Facts dump
+func openOFF(file *os.File) (*importReader, error) {
+	lines := newLineReader(file)
+	if strings.HasSuffix(file.Name(), ".gz") {
+		var err error
+		if lines, err = newGzipLineReader(file); err != nil {
+			return nil, err
+		}
+	}
+	return &importReader{source: lines, header: "product", parse: parseOFFProduct, dataset: "off", dedupeBarcodes: true}, nil
+}
+
+// parseOFFProduct parses an Open Food Facts product into an import row
+func parseOFFProduct(raw string) (importRow, error) {
+	var p offProduct
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		return importRow{}, fmt.Errorf("failed to parse product: %w", err)
+	}
+	p.Code = strings.TrimSpace(p.Code)
+	if p.Code == "" {
+		return importRow{}, errors.New("code is required")
+	}
+
+	f := food.Food{
+		ID:            "off_" + p.Code,
+		Name:          firstNonEmpty(p.ProductName, p.ProductNameEN, p.GenericName),
+		FoodType:      offFoodType(p.CategoriesTags),
+		Source:        []map[string]string{offSource(p)},
+		Serving:       offServing(p.ServingQuantity, p.ServingQuantityUnit, p.ServingSize),
+		PackageSize:   offServing(p.ProductQuantity, p.ProductQuantityUnit, p.Quantity),
+		Nutrition100g: offNutrition(p.Nutriments),
+		Labels:        offLabels(p.LabelsTags, p.AllergensTags),
+		Ingredients:   strings.TrimSpace(firstNonEmpty(p.IngredientsText, p.IngredientsTextEN)),
+	}
+	if generic := strings.TrimSpace(p.GenericName); generic != "" && generic != f.Name {
+		f.AlternateNames = []string{generic}
+	}
+	f.EAN13, f.InvalidBarcode = normalizeImportedBarcode(p.Code)
+	f.Classify()
+
+	row, err := newImportRow(f, nil)
+	if err != nil {
+		return importRow{}, err
+	}
+
+	// Keep only what was read, rather than the whole dump line
+	p.Nutriments = offReadNutriments(p.Nutriments)
+	trimmed, err := json.Marshal(p)
+	if err != nil {
+		return importRow{}, fmt.Errorf("failed to marshal product: %w", err)
+	}
+	row.raw = string(trimmed)
+	return row, nil
+}
+
+// offNutrition converts a product's nutriments to the canonical keys and units
+func offNutrition(nutriments map[string]json.RawMessage) food.Nutrients {
+	var values food.Nutrients
+	for _, mapping := range offNutrients {
+		if _, ok := values.Get(mapping.key); ok {
+			continue
+		}
+		for _, field := range mapping.fields {
+			v, ok := offNumber(nutriments[field])
+			if !ok || v < 0 {
+				continue
+			}
+			if mapping.unit != "%" {
+				info, _ := food.LookupNutrient(mapping.key)
+				if v, ok = convertNutrientUnit(mapping.key, v, mapping.unit, info.Unit); !ok {
+					continue
+				}
+			}
+			values.Set(mapping.key, roundNutrient(v))
+			break
+		}
+	}
+
+	if _, ok := values.Get("sodium"); !ok {
+		if salt, ok := offNumber(nutriments[offSaltField]); ok && salt >= 0 {
+			values.Set("sodium", roundNutrient(salt*0.4*1000))
+		}
+	}
+	return values
+}
+
+// offReadNutriments returns the nutriments offNutrition reads
+func offReadNutriments(nutriments map[string]json.RawMessage) map[string]json.RawMessage {
+	read := make(map[string]json.RawMessage)
+	for _, mapping := range offNutrients {
+		for _, field := range mapping.fields {
+			if v, ok := nutriments[field]; ok {
+				read[field] = v
+			}
+		}
+	}
+	if v, ok := nutriments[offSaltField]; ok {
+		read[offSaltField] = v
+	}
+	return read
+}
+
+// offNumber reads a JSON number or numeric string
+func offNumber(raw json.RawMessage) (float64, bool) {
+	if len(raw) == 0 {
+		return 0, false
+	}
+	var v float64
+	if err := json.Unmarshal(raw, &v); err == nil {
+		return v, true
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// offServing returns a serving or package size from its quantity, unit and
+// the text printed on the label. OFF quantities are in grams unless the unit
+// says otherwise.
+func offServing(quantity json.RawMessage, unit, text string) food.Serving {
+	var s food.Serving
+	if amount, ok := offNumber(quantity); ok && amount > 0 {
+		s.Amount = amount
+		s.Unit = strings.ToLower(strings.TrimSpace(unit))
+		if s.Unit == "" {
+			s.Unit = "g"
+		}
+		if s.Unit == "g" {
+			s.Grams = amount
+		}
+	}
+	if text = strings.TrimSpace(text); text != "" {
+		s.Extra = map[string]json.RawMessage{"description": jsonString(text)}
+	}
+	return s
+}
+
+// offLabels returns a product's English labels and its allergens, named as
+// the reference allergens, as snake_case labels
+func offLabels(labelTags, allergenTags []string) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	add := func(label string) {
+		label = strings.ReplaceAll(label, "-", "_")
+		if label != "" && !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	for _, tag := range labelTags {
+		if name, ok := strings.CutPrefix(tag, "en:"); ok {
+			add(name)
+		}
+	}
+	for _, tag := range allergenTags {
+		if name, ok := strings.CutPrefix(tag, "en:"); ok {
+			if mapped, ok := offAllergens[name]; ok {
+				name = mapped
+			}
+			add(name)
+		}
+	}
+	return labels
+}
+
+// offFoodType derives a food type from a product's most specific English
+// category, such as "yogurts"
+func offFoodType(categoryTags []string) string {
+	for i := len(categoryTags) - 1; i >= 0; i-- {
+		if name, ok := strings.CutPrefix(categoryTags[i], "en:"); ok {
+			slug := strings.Trim(nonSlugChars.ReplaceAllString(name, "_"), "_")
+			if len(slug) > 50 {
+				slug = strings.TrimRight(slug[:50], "_")
+			}
+			return slug
+		}
+	}
+	return ""
+}
+
+// offSource records a product's provenance and the OFF fields the catalog
+// has no column for
+func offSource(p offProduct) map[string]string {
+	source := map[string]string{
+		"type": "off",
+		"id":   p.Code,
+		"url":  "https://world.openfoodfacts.org/product/" + p.Code,
+	}
+	set := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			source[key] = value
+		}
+	}
+	set("brands", p.Brands)
+	set("nutriscore_grade", p.NutriscoreGrade)
+	set("nova_group", offText(p.NovaGroup))
+	set("last_modified_t", offText(p.LastModifiedT))
+
+	var countries []string
+	for _, tag := range p.CountriesTags {
+		if name, ok := strings.CutPrefix(tag, "en:"); ok {
+			countries = append(countries, name)
+		}
+	}
+	set("countries", strings.Join(countries, ","))
+	return source
+}
+
+// offText returns a JSON number or string as text
+func offText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if v, ok := offNumber(raw); ok {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// firstNonEmpty returns the first of values that is not blank
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+// importProgressInterval is how often an import reports its progress
+const importProgressInterval = 5 * time.Second
+
+// lineParser parses one record of an import file into a row. Parsers are
+// called from several goroutines at once.
+type lineParser func(raw string) (importRow, error)
+
+// importSource reads the records of an import file, one food each
+type importSource interface {
+	// next returns the next record, or false at the end of the file
+	next() (string, bool, error)
+	// position returns the byte offset after the last record read and the
+	// record's number
+	position() (int64, int)
+	// seek continues reading after the record at offset, as recorded in a
+	// checkpoint
+	seek(offset int64, record int) error
+}
+
+// importReader is an import file opened for reading
+type importReader struct {
+	source  importSource
+	header  string // Header rejected records are written under
+	parse   lineParser
+	dataset string // Dataset the foods are recorded as imported from
+
+	// dedupeBarcodes rejects rows whose barcode another food already has,
+	// for datasets that should only add the products the catalog lacks
+	dedupeBarcodes bool
+}
+
+// openImportFile prepares a file for reading
+type openImportFile func(file *os.File) (*importReader, error)
+
+// importLine is a line of an import file waiting to be parsed
+type importLine struct {
+	line int
+	raw  string
+}
+
+// importReject is a line that failed to parse
+type importReject struct {
+	line int
+	raw  string
+	err  error
+}
+
+// importChunk is a run of consecutive lines, read as one and staged as one
+type importChunk struct {
+	seq     int   // Position of the chunk in the file, from 0
+	offset  int64 // Byte offset after the chunk's last line
+	line    int   // Number of the chunk's last line
+	lines   []importLine
+	rows    []importRow
+	rejects []importReject
+}
+
+// parse parses the chunk's lines into rows and rejects
+func (c *importChunk) parse(parse lineParser) {
+	for _, l := range c.lines {
+		var row importRow
+		var err error
+		switch {
+		case !utf8.ValidString(l.raw):
+			err = errors.New("line is not valid UTF-8")
+		case strings.ContainsRune(l.raw, 0):
+			err = errors.New("line contains a NUL byte")
+		default:
+			row, err = parse(l.raw)
+		}
+		if err != nil {
+			c.rejects = append(c.rejects, importReject{line: l.line, raw: l.raw, err: err})
+			continue
+		}
+		row.line = l.line
+		if row.raw == "" {
+			row.raw = l.raw
+		}
+		c.rows = append(c.rows, row)
+	}
+	c.lines = nil
+}
+
+// lineReader reads lines of any length, tracking the byte offset and line
+// number reached. ReadString rather than a Scanner, which fails on lines
+// over 64 KB.
+type lineReader struct {
+	file   *os.File // Nil when reading a stream that cannot seek
+	r      *bufio.Reader
+	offset int64
+	line   int
+}
+
+func newLineReader(file *os.File) *lineReader {
+	return &lineReader{file: file, r: bufio.NewReaderSize(file, 1024*1024)}
+}
+
+// newGzipLineReader reads the lines of a gzip-compressed file. Offsets
+// count uncompressed bytes.
+func newGzipLineReader(file *os.File) (*lineReader, error) {
+	gz, err := gzip.NewReader(bufio.NewReaderSize(file, 1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gzip file: %w", err)
+	}
+	return &lineReader{r: bufio.NewReaderSize(gz, 1024*1024)}, nil
+}
+
+// next returns the next line without its line ending, or false at the end
+// of the file
+func (l *lineReader) next() (string, bool, error) {
+	raw, err := l.r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, fmt.Errorf("failed to read file: %w", err)
+	}
+	if raw == "" {
+		return "", false, nil
+	}
+	l.offset += int64(len(raw))
+	l.line++
+	return strings.TrimRight(raw, "\r\n"), true, nil
+}
+
+func (l *lineReader) position() (int64, int) {
+	return l.offset, l.line
+}
+
+// seek continues after the given line, skipping the lines before it when
+// the file is compressed
+func (l *lineReader) seek(offset int64, line int) error {
+	if l.file == nil {
+		for l.line < line {
+			if _, ok, err := l.next(); err != nil {
+				return err
+			} else if !ok {
+				return fmt.Errorf("%w: the file ends before line %d", ErrCheckpointMismatch, line)
+			}
+		}
+		if l.offset != offset {
+			return fmt.Errorf("%w: line %d ends at byte %d, not %d", ErrCheckpointMismatch, line, l.offset, offset)
+		}
+		return nil
+	}
+	if _, err := l.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek to checkpoint: %w", err)
+	}
+	l.r.Reset(l.file)
+	l.offset, l.line = offset, line
+	return nil
+}
+
+// importFile imports a file of foods read by the reader open returns.
+//
+// The import runs as a pipeline: a reader goroutine splits the file into
+// chunks of BatchSize records, a pool of workers parses them, and the writer
+// copies the parsed chunks in file order into the staging table, recording
+// a checkpoint after each. Once the whole file is staged, it is merged into
+// the catalog in a single transaction. The staging table is shared, so an
+// advisory lock keeps a second import from starting alongside.
+func (i *FoodImporter) importFile(ctx context.Context, filePath string, opts ImportOptions, open openImportFile) (*ImportResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1000
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Mode == "" {
+		opts.Mode = ImportInsert
+	}
+	result := &ImportResult{}
+	if opts.Mode != ImportInsert && opts.Mode != ImportUpsert {
+		return result, ErrInvalidImportMode
+	}
+	if opts.Retire && opts.Mode != ImportUpsert {
+		return result, ErrRetireRequiresUpsert
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return result, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	// Without a checkpoint path the checkpoint is only kept in memory
+	var checkpoint *importCheckpoint
+	resuming := false
+	if opts.CheckpointPath != "" {
+		hash, err := hashFile(file)
+		if err != nil {
+			return result, err
+		}
+		if opts.Resume {
+			if checkpoint, err = loadCheckpoint(opts.CheckpointPath); err != nil {
+				return result, err
+			}
+			if checkpoint == nil {
+				i.logger.Info().Str("checkpoint", opts.CheckpointPath).Msg("No checkpoint found, importing from the start")
+			} else if checkpoint.SHA256 != hash {
+				return result, ErrCheckpointMismatch
+			} else if checkpoint.Mode != opts.Mode {
+				return result, fmt.Errorf("checkpoint was recorded by an %s import, not %s", checkpoint.Mode, opts.Mode)
+			} else {
+				resuming = true
+			}
+		}
+		if checkpoint == nil {
+			checkpoint = &importCheckpoint{File: filePath, SHA256: hash}
+		}
+	} else {
+		checkpoint = &importCheckpoint{File: filePath}
+	}
+	if !resuming {
+		// Every food the run sees is stamped with its start time, which is
+		// kept in the checkpoint so that a resumed run stamps the same time
+		checkpoint.Mode = opts.Mode
+		checkpoint.RunStartedAt = importRunTime()
+	}
+
+	reader, err := open(file)
+	if err != nil {
+		return result, err
+	}
+	src := reader.source
+	if resuming {
+		if err := src.seek(checkpoint.Offset, checkpoint.Line); err != nil {
+			return result, err
+		}
+		*result = checkpoint.Result
+		result.ResumedAtLine = checkpoint.Line + 1
+		i.logger.Info().Int("line", result.ResumedAtLine).Int("staged", checkpoint.Staged).Msg("Resuming food import from checkpoint")
+	} else {
+		checkpoint.Offset, checkpoint.Line = src.position()
+	}
+
+	// The advisory lock, COPY and merge all need the same session
+	conn, err := i.db.Conn(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	locked, err := queries.TryLockFoodImport(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to lock food import: %w", err)
+	}
+	if !locked {
+		return result, ErrImportRunning
+	}
+	defer func() {
+		if err := queries.UnlockFoodImport(context.Background()); err != nil {
+			i.logger.Error().Err(err).Msg("Failed to unlock food import")
+		}
+	}()
+
+	// Rows staged after the checkpoint was recorded are staged again
+	if resuming {
+		if err := queries.DeleteStagedFoodsAfterLine(ctx, int32(checkpoint.Line)); err != nil {
+			return result, fmt.Errorf("failed to reset staging table: %w", err)
+		}
+		staged, err := queries.CountStagedFoods(ctx)
+		if err != nil {
+			return result, fmt.Errorf("failed to count staged foods: %w", err)
+		}
+		if int(staged) != checkpoint.Staged {
+			return result, fmt.Errorf("%w: the staging table holds %d rows, not the %d checkpointed", ErrCheckpointMismatch, staged, checkpoint.Staged)
+		}
+	} else if err := queries.ClearFoodImportStaging(ctx); err != nil {
+		return result, fmt.Errorf("failed to clear staging table: %w", err)
+	}
+
+	var rejects *rejectWriter
+	if opts.RejectPath != "" {
+		var resumeAt int64
+		if resuming {
+			resumeAt = checkpoint.RejectOffset
+		}
+		if rejects, err = openRejectWriter(opts.RejectPath, reader.header, resumeAt); err != nil {
+			return result, err
+		}
+		defer rejects.Close()
+	}
+	reject := func(line int, raw string, reason error) error {
+		result.Rejected++
+		i.logger.Warn().Err(reason).Int("line", line).Msg("Rejected food row")
+		if rejects == nil {
+			return nil
+		}
+		return rejects.reject(line, reason.Error(), raw)
+	}
+
+	started := time.Now()
+	firstLine := checkpoint.Line
+	rowsPerSecond := func() float64 {
+		if elapsed := time.Since(started).Seconds(); elapsed > 0 {
+			return float64(checkpoint.Line-firstLine) / elapsed
+		}
+		return 0
+	}
+	lastReport := started
+	stage := func(c *importChunk) error {
+		for _, r := range c.rejects {
+			if err := reject(r.line, r.raw, r.err); err != nil {
+				return err
+			}
+		}
+		if len(c.rows) > 0 {
+			if err := copyStagedRows(ctx, conn, c.rows); err != nil {
+				return fmt.Errorf("failed to stage rows ending at line %d: %w", c.line, err)
+			}
+		}
+		checkpoint.Staged += len(c.rows)
+		checkpoint.Offset = c.offset
+		checkpoint.Line = c.line
+		checkpoint.Result = *result
+
+		if opts.CheckpointPath != "" {
+			if rejects != nil {
+				if checkpoint.RejectOffset, err = rejects.sync(); err != nil {
+					return err
+				}
+			}
+			if err := checkpoint.save(opts.CheckpointPath); err != nil {
+				return err
+			}
+		}
+
+		if time.Since(lastReport) >= importProgressInterval {
+			lastReport = time.Now()
+			progress := ImportProgress{Line: checkpoint.Line, Rows: checkpoint.Line - firstLine, RowsPerSecond: rowsPerSecond()}
+			if opts.Progress != nil {
+				opts.Progress(progress)
+			} else {
+				i.logger.Info().Int("line", progress.Line).Int("rows", progress.Rows).Float64("rows_per_sec", progress.RowsPerSecond).Msg("Importing foods")
+			}
+		}
+		return nil
+	}
+	if err := runImportPipeline(ctx, src, opts, reader.parse, stage); err != nil {
+		return result, err
+	}
+
+	if err := i.mergeStagedFoods(ctx, conn, opts, reader, checkpoint.RunStartedAt, result, reject); err != nil {
+		return result, err
+	}
+	if rejects != nil {
+		if _, err := rejects.sync(); err != nil {
+			return result, err
+		}
+	}
+	result.RowsPerSecond = rowsPerSecond()
+
+	// The import finished, so the next one starts from the beginning
+	if opts.CheckpointPath != "" {
+		if err := os.Remove(opts.CheckpointPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, fmt.Errorf("failed to remove checkpoint: %w", err)
+		}
+	}
+
+	i.logger.Info().
+		Int("added", result.Added).
+		Int("changed", result.Changed).
+		Int("unchanged", result.Unchanged).
+		Int("retired", result.Retired).
+		Int("rejected", result.Rejected).
+		Int("invalid_barcodes", result.InvalidBarcodes).
+		Int("ungraded", result.Ungraded).
+		Int("unclassified", result.Unclassified).
+		Int("translations", result.Translations).
+		Float64("rows_per_sec", result.RowsPerSecond).
+		Msg("Food import finished")
+	return result, nil
+}
+
+// runImportPipeline reads the source's remaining records in chunks, parses them on
+// opts.Workers goroutines and hands the parsed chunks to stage in file order.
+// Stage runs on the calling goroutine; when it fails the pipeline is stopped
+// and its error returned.
+func runImportPipeline(ctx context.Context, src importSource, opts ImportOptions, parse lineParser, stage func(*importChunk) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan *importChunk, opts.Workers)
+	parsed := make(chan *importChunk, opts.Workers)
+	readErrs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		readErrs <- readImportChunks(ctx, src, opts.BatchSize, chunks)
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				c.parse(parse)
+				select {
+				case parsed <- c:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(parsed)
+	}()
+
+	// Chunks finish parsing out of order, so early ones wait here
+	pending := make(map[int]*importChunk)
+	next := 0
+	var stageErr error
+	for c := range parsed {
+		if stageErr != nil {
+			continue
+		}
+		pending[c.seq] = c
+		for c, ok := pending[next]; ok; c, ok = pending[next] {
+			delete(pending, next)
+			next++
+			if stageErr = stage(c); stageErr != nil {
+				cancel()
+				break
+			}
+		}
+	}
+	readErr := <-readErrs
+	if stageErr != nil {
+		return stageErr
+	}
+	if readErr != nil {
+		return readErr
+	}
+	// Workers stop early on cancellation, leaving chunks unstaged
+	return ctx.Err()
+}
+
+// readImportChunks sends the source's remaining records in chunks of
+// batchSize non-blank records. Blank lines are skipped but still counted.
+func readImportChunks(ctx context.Context, src importSource, batchSize int, out chan<- *importChunk) error {
+	c := &importChunk{}
+	send := func() error {
+		select {
+		case out <- c:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		c = &importChunk{seq: c.seq + 1}
+		return nil
+	}
+
+	for {
+		raw, ok, err := src.next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		c.offset, c.line = src.position()
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		c.lines = append(c.lines, importLine{line: c.line, raw: raw})
+		if len(c.lines) >= batchSize {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+	if c.line > 0 {
+		return send()
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres/db"
+)
+
+// stagingColumns are the columns of food_import_staging in the order
+// stagingValues returns them
+var stagingColumns = []string{
+	"line", "raw", "id", "name", "alternate_names", "description", "food_type",
+	"source", "serving", "nutrition_100g", "ean_13", "labels", "package_size",
+	"ingredients", "ingredient_analysis", "invalid_barcode", "nutri_score_grade",
+	"nutri_score_points", "nutri_score", "nova_group", "nova_markers",
+	"ingredient_tree", "translations",
+}
+
+// copyStagedRows copies rows into the staging table with COPY, which
+// database/sql has no call for, so it goes through the pgx connection
+func copyStagedRows(ctx context.Context, conn *sql.Conn, rows []importRow) error {
+	return conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("copying rows requires the pgx driver, not %T", driverConn)
+		}
+		_, err := c.Conn().CopyFrom(ctx, pgx.Identifier{"food_import_staging"}, stagingColumns,
+			pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+				return rows[i].stagingValues(), nil
+			}))
+		return err
+	})
+}
+
+// stagingValues returns the row's values for stagingColumns, with NULL
+// columns as nil and JSON as text
+func (r *importRow) stagingValues() []any {
+	f := &r.record
+	return []any{
+		int32(r.line),
+		r.raw,
+		f.ID,
+		f.Name,
+		stagingJSON(f.AlternateNames),
+		stagingText(f.Description),
+		stagingText(f.FoodType),
+		stagingJSON(f.Source),
+		stagingJSON(f.Serving),
+		stagingJSON(f.Nutrition100g),
+		stagingText(f.Ean13),
+		stagingJSON(f.Labels),
+		stagingJSON(f.PackageSize),
+		stagingText(f.Ingredients),
+		stagingJSON(f.IngredientAnalysis),
+		stagingText(f.InvalidBarcode),
+		stagingText(f.NutriScoreGrade),
+		stagingSmallint(f.NutriScorePoints),
+		stagingJSON(f.NutriScore),
+		stagingSmallint(f.NovaGroup),
+		stagingJSON(f.NovaMarkers),
+		stagingJSON(f.IngredientTree),
+		stagingJSON(pqtype.NullRawMessage{RawMessage: r.translations, Valid: r.translations != nil}),
+	}
+}
+
+func stagingText(s sql.NullString) any {
+	if !s.Valid {
+		return nil
+	}
+	return s.String
+}
+
+func stagingJSON(m pqtype.NullRawMessage) any {
+	if !m.Valid {
+		return nil
+	}
+	return string(m.RawMessage)
+}
+
+func stagingSmallint(n sql.NullInt16) any {
+	if !n.Valid {
+		return nil
+	}
+	return n.Int16
+}
+
+// mergeStagedFoods merges the staging table into the catalog in one
+// transaction, recording the foods as imported from the reader's dataset,
+// and fills in the result's counts. Rows repeating an earlier row's ID, rows
+// for foods since merged into another, for readers that dedupe barcodes rows
+// with a barcode already taken, and in insert mode rows for foods already in
+// the catalog are rejected first. In
+// upsert mode, foods an admin edited since they were last imported keep the
+// admin's version and are counted as kept.
+func (i *FoodImporter) mergeStagedFoods(ctx context.Context, conn *sql.Conn, opts ImportOptions, reader *importReader, importedAt time.Time, result *ImportResult, reject func(line int, raw string, reason error) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin merge: %w", err)
+	}
+	defer tx.Rollback()
+	queries := db.New(tx)
+
+	duplicates, err := queries.RejectDuplicateStagedFoods(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reject duplicate foods: %w", err)
+	}
+	for _, d := range duplicates {
+		if err := reject(int(d.Line), d.Raw, fmt.Errorf("food %s already appears on an earlier line", d.ID)); err != nil {
+			return err
+		}
+	}
+	merged, err := queries.RejectMergedStagedFoods(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reject merged foods: %w", err)
+	}
+	for _, m := range merged {
+		if err := reject(int(m.Line), m.Raw, fmt.Errorf("food %s was merged into food %s", m.ID, m.MergedInto)); err != nil {
+			return err
+		}
+	}
+	if reader.dedupeBarcodes {
+		taken, err := queries.RejectKnownBarcodeStagedFoods(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to reject known barcodes: %w", err)
+		}
+		for _, t := range taken {
+			if err := reject(int(t.Line), t.Raw, fmt.Errorf("barcode %s already belongs to food %s", t.Ean13.String, t.ExistingID)); err != nil {
+				return err
+			}
+		}
+	}
+	if opts.Mode == ImportUpsert {
+		kept, err := queries.KeepAdminEditedStagedFoods(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to keep admin-edited foods: %w", err)
+		}
+		for _, k := range kept {
+			i.logger.Info().Str("food_id", k.ID).Int32("line", k.Line).Msg("Keeping admin edits of food")
+		}
+		result.Kept = len(kept)
+	}
+	if opts.Mode == ImportInsert {
+		existing, err := queries.RejectExistingStagedFoods(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to reject existing foods: %w", err)
+		}
+		for _, e := range existing {
+			if err := reject(int(e.Line), e.Raw, fmt.Errorf("food %s already exists", e.ID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	summary, err := queries.SummarizeStagedFoods(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to summarize staged foods: %w", err)
+	}
+	var fieldChanges map[string]int
+	if err := json.Unmarshal(summary.FieldChanges, &fieldChanges); err != nil {
+		return fmt.Errorf("failed to parse field changes: %w", err)
+	}
+	result.Added = int(summary.Added)
+	result.Changed = int(summary.Changed)
+	result.Unchanged = int(summary.Unchanged)
+	result.Imported = result.Added + result.Changed + result.Unchanged
+	result.InvalidBarcodes = int(summary.InvalidBarcodes)
+	result.Ungraded = int(summary.Ungraded)
+	result.Unclassified = int(summary.Unclassified)
+	result.Translations = int(summary.Translations)
+	result.FieldChanges = nil
+	for field, n := range fieldChanges {
+		if n == 0 {
+			continue
+		}
+		if result.FieldChanges == nil {
+			result.FieldChanges = map[string]int{}
+		}
+		result.FieldChanges[field] = n
+	}
+
+	// Foods left as they were are still stamped, so they are not retired
+	if opts.Mode == ImportUpsert {
+		if _, err := queries.UpdateChangedFoods(ctx, db.UpdateChangedFoodsParams{ImportedAt: importedAt, Dataset: reader.dataset}); err != nil {
+			return fmt.Errorf("failed to update changed foods: %w", err)
+		}
+		if err := queries.MarkStagedFoodsImported(ctx, db.MarkStagedFoodsImportedParams{ImportedAt: importedAt, Dataset: reader.dataset}); err != nil {
+			return fmt.Errorf("failed to mark imported foods: %w", err)
+		}
+	}
+	if _, err := queries.InsertStagedFoods(ctx, db.InsertStagedFoodsParams{ImportedAt: importedAt, Dataset: reader.dataset}); err != nil {
+		return fmt.Errorf("failed to insert foods: %w", err)
+	}
+	if _, err := queries.UpsertStagedTranslations(ctx); err != nil {
+		return fmt.Errorf("failed to write translations: %w", err)
+	}
+
+	// Only a complete file says which foods were dropped, and an empty one
+	// most likely means the wrong file rather than an empty release. Other
+	// datasets' foods are left alone.
+	if opts.Retire {
+		if result.Imported == 0 {
+			i.logger.Warn().Msg("Nothing was imported, not retiring missing foods")
+		} else {
+			retired, err := queries.RetireUnimportedFoods(ctx, db.RetireUnimportedFoodsParams{Dataset: reader.dataset, ImportedAt: importedAt})
+			if err != nil {
+				return fmt.Errorf("failed to retire missing foods: %w", err)
+			}
+			result.Retired = int(retired)
+		}
+	}
+
+	if err := queries.ClearFoodImportStaging(ctx); err != nil {
+		return fmt.Errorf("failed to clear staging table: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return nil
+}
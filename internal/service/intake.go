@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/intake"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+)
+
+type intakeService struct {
+	intakeRepo  intake.Repository
+	profileRepo profile.Repository
+	foodRepo    food.Repository
+	logger      zerolog.Logger
+}
+
+func NewIntakeService(
+	intakeRepo intake.Repository,
+	profileRepo profile.Repository,
+	foodRepo food.Repository,
+	logger zerolog.Logger,
+) IntakeService {
+	return &intakeService{
+		intakeRepo:  intakeRepo,
+		profileRepo: profileRepo,
+		foodRepo:    foodRepo,
+		logger:      logger,
+	}
+}
+
+func (s *intakeService) LogIntake(ctx context.Context, userID uuid.UUID, entry *intake.Entry) (*intake.Entry, error) {
+	if err := s.verifyOwnership(entry.ProfileID, userID); err != nil {
+		return nil, err
+	}
+
+	// Validate food exists
+	if _, err := s.foodRepo.GetByID(entry.FoodID); err != nil {
+		return nil, fmt.Errorf("food not found: %w", err)
+	}
+
+	if entry.ConsumedAt.IsZero() {
+		entry.ConsumedAt = time.Now()
+	}
+
+	if err := s.intakeRepo.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to log intake: %w", err)
+	}
+
+	s.logger.Info().
+		Str("profile_id", entry.ProfileID.String()).
+		Str("food_id", entry.FoodID).
+		Float64("amount_g", entry.AmountGrams).
+		Msg("Intake logged successfully")
+
+	return entry, nil
+}
+
+func (s *intakeService) ListIntake(ctx context.Context, userID uuid.UUID, profileID uuid.UUID, from, to time.Time) ([]intake.Entry, error) {
+	if err := s.verifyOwnership(profileID, userID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.intakeRepo.ListByProfile(ctx, profileID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list intake: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *intakeService) DeleteIntake(ctx context.Context, userID uuid.UUID, profileID uuid.UUID, entryID uuid.UUID) error {
+	if err := s.verifyOwnership(profileID, userID); err != nil {
+		return err
+	}
+
+	if err := s.intakeRepo.Delete(ctx, entryID, profileID); err != nil {
+		return fmt.Errorf("failed to delete intake: %w", err)
+	}
+	return nil
+}
+
+func (s *intakeService) verifyOwnership(profileID uuid.UUID, userID uuid.UUID) error {
+	p, err := s.profileRepo.GetByID(profileID)
+	if err != nil {
+		return err
+	}
+	if p.UserID != userID {
+		return profile.ErrUnauthorized
+	}
+	return nil
+}
@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/household"
+	"github.com/yeboahd24/nutrimatch/internal/domain/intake"
+	"github.com/yeboahd24/nutrimatch/internal/domain/measurement"
 	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
 	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
 	"github.com/yeboahd24/nutrimatch/internal/domain/reference"
+	"github.com/yeboahd24/nutrimatch/internal/domain/submission"
 	"github.com/yeboahd24/nutrimatch/internal/domain/user"
 )
 
@@ -48,11 +55,28 @@ type ProfileService interface {
 
 // FoodService handles food-related operations
 type FoodService interface {
-	SearchFoods(ctx context.Context, query string, page, limit int) ([]food.Food, int, error)
+	SearchFoods(ctx context.Context, filter food.SearchFilter, page, limit int, cursor string) (*food.SearchResult, error)
+	SuggestFoods(ctx context.Context, prefix string, limit int) ([]food.Suggestion, error)
 	GetFood(ctx context.Context, id string) (*food.Food, error)
-	GetFoodsByCategory(ctx context.Context, category string, page, limit int) ([]food.Food, int, error)
+	GetFoodByBarcode(ctx context.Context, code string) (*food.Food, error)
+	CompareFoods(ctx context.Context, foodIDs []string) (*food.Comparison, error)
+	GetFoodsByCategory(ctx context.Context, category string, page, limit int, cursor string) (*food.SearchResult, error)
+	ListCategories(ctx context.Context) (*reference.CategoryListing, error)
 	Import(filePath string) (int, error)
 
+	// Catalog editing methods
+	CreateFood(ctx context.Context, authorID uuid.UUID, f *food.Food) (*food.Food, error)
+	PatchFood(ctx context.Context, authorID uuid.UUID, id string, patch json.RawMessage) (*food.Food, error)
+	DeleteFood(ctx context.Context, authorID uuid.UUID, id string) error
+	ListFoodRevisions(ctx context.Context, id string, page, limit int) ([]food.Revision, int, error)
+	GetFoodRevision(ctx context.Context, foodID string, revisionID uuid.UUID) (*food.Revision, error)
+	RevertFoodRevision(ctx context.Context, authorID uuid.UUID, foodID string, revisionID uuid.UUID) (*food.Revision, error)
+	ListFoodTranslations(ctx context.Context, id string) ([]food.Translation, error)
+	PutFoodTranslation(ctx context.Context, t *food.Translation) (*food.Translation, error)
+	DeleteFoodTranslation(ctx context.Context, id, locale string) error
+	FindDuplicates(ctx context.Context, filter food.DuplicateFilter, minScore float64, limit int) ([]food.DuplicatePair, error)
+	MergeFoods(ctx context.Context, authorID uuid.UUID, sourceID, targetID string) (*food.MergeResult, error)
+
 	// Rating methods
 	RateFood(ctx context.Context, userID uuid.UUID, foodID string, rating int, comments string) (*food.FoodRating, error)
 	ListUserRatings(ctx context.Context, userID uuid.UUID, limit, offset int) ([]food.FoodRating, error)
@@ -70,6 +94,55 @@ type RecommendationService interface {
 	GetDailyRecommendations(ctx context.Context, profileID string, limit int) ([]food.Food, error)
 	GetMealPlanRecommendations(ctx context.Context, profileID string, days int) (*recommendation.MealPlan, error)
 	GetFoodAlternatives(ctx context.Context, foodID string, limit int) ([]food.Food, error)
+	GetNutrientGaps(ctx context.Context, userID uuid.UUID, req recommendation.GapRequest) (*recommendation.GapReport, error)
+	GetHouseholdMealPlan(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, days int) (*recommendation.MealPlan, error)
+	CheckFood(ctx context.Context, userID uuid.UUID, req recommendation.CheckRequest) (*recommendation.CheckResult, error)
+}
+
+// HouseholdService handles households, their member profiles and invitations
+type HouseholdService interface {
+	CreateHousehold(ctx context.Context, userID uuid.UUID, name string, profileIDs []uuid.UUID) (*household.Household, error)
+	GetHousehold(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*household.Household, error)
+	ListHouseholds(ctx context.Context, userID uuid.UUID) ([]household.Household, error)
+	DeleteHousehold(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+	AddMember(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, profileID uuid.UUID) (*household.Household, error)
+	RemoveMember(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, profileID uuid.UUID) error
+	InviteMember(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, email string) (*household.Invitation, error)
+	ListInvitations(ctx context.Context, userID uuid.UUID) ([]household.Invitation, error)
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID, profileID uuid.UUID) (*household.Household, error)
+	DeclineInvitation(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error
+}
+
+// SubmissionService handles user-submitted foods and their moderation
+type SubmissionService interface {
+	Submit(ctx context.Context, userID uuid.UUID, input submission.Input) (*submission.Submission, error)
+	ListMySubmissions(ctx context.Context, userID uuid.UUID, status string, page, limit int) ([]submission.Submission, int, error)
+	GetMySubmission(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*submission.Submission, error)
+	ListSubmissions(ctx context.Context, status string, page, limit int) ([]submission.Submission, int, error)
+	GetSubmission(ctx context.Context, id uuid.UUID) (*submission.Submission, error)
+	EditSubmission(ctx context.Context, id uuid.UUID, patch json.RawMessage) (*submission.Submission, error)
+	ApproveSubmission(ctx context.Context, moderatorID uuid.UUID, id uuid.UUID) (*submission.Submission, error)
+	RejectSubmission(ctx context.Context, moderatorID uuid.UUID, id uuid.UUID, reason string) (*submission.Submission, error)
+}
+
+// MeasurementService handles body measurement history and trend-based calorie target adjustment
+type MeasurementService interface {
+	RecordMeasurement(ctx context.Context, userID uuid.UUID, m *measurement.Measurement) (*measurement.RecordResult, error)
+	ListMeasurements(ctx context.Context, userID uuid.UUID, measurementType string, from, to time.Time) (*measurement.Series, error)
+	DeleteMeasurement(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+	ListCalorieAdjustments(ctx context.Context, userID uuid.UUID, profileID uuid.UUID) ([]measurement.CalorieAdjustment, error)
+}
+
+// IntakeService handles food intake logging operations
+type IntakeService interface {
+	LogIntake(ctx context.Context, userID uuid.UUID, entry *intake.Entry) (*intake.Entry, error)
+	ListIntake(ctx context.Context, userID uuid.UUID, profileID uuid.UUID, from, to time.Time) ([]intake.Entry, error)
+	DeleteIntake(ctx context.Context, userID uuid.UUID, profileID uuid.UUID, entryID uuid.UUID) error
+}
+
+// ExportService streams the food catalog in bulk export formats
+type ExportService interface {
+	ExportFoods(ctx context.Context, filter food.ExportFilter, format string, w io.Writer) (int, error)
 }
 
 // ReferenceService handles reference data operations
@@ -77,4 +150,5 @@ type ReferenceService interface {
 	GetAllergens(ctx context.Context) ([]reference.Allergen, error)
 	GetHealthConditions(ctx context.Context) ([]reference.HealthCondition, error)
 	GetDietaryPatterns(ctx context.Context) ([]reference.DietaryPattern, error)
+	GetFoodCategories(ctx context.Context) ([]reference.FoodCategory, error)
 }
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/domain/measurement"
+	"github.com/yeboahd24/nutrimatch/internal/domain/nutrition"
+	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
+	"github.com/yeboahd24/nutrimatch/internal/domain/user"
+)
+
+const (
+	// trendWarmup is how much history before the requested range is used to seed the moving average
+	trendWarmup = 30 * 24 * time.Hour
+	// rateWindow is the span of trend used to measure the rate of weight change
+	rateWindow = 28 * 24 * time.Hour
+	// minRateSpan and minRatePoints are the least history needed before a rate is reported
+	minRateSpan   = 14 * 24 * time.Hour
+	minRatePoints = 4
+	// rateTolerance is how far (kg/week) the observed rate may drift from the goal before adjusting
+	rateTolerance = 0.2
+	// adjustmentCooldown gives a new calorie target time to show up in the trend
+	adjustmentCooldown = 14 * 24 * time.Hour
+	// maxAdjustmentStep caps a single automatic change to the calorie target
+	maxAdjustmentStep = 250
+)
+
+type measurementService struct {
+	measurementRepo measurement.Repository
+	profileRepo     profile.Repository
+	userRepo        user.Repository
+	logger          zerolog.Logger
+}
+
+func NewMeasurementService(
+	measurementRepo measurement.Repository,
+	profileRepo profile.Repository,
+	userRepo user.Repository,
+	logger zerolog.Logger,
+) MeasurementService {
+	return &measurementService{
+		measurementRepo: measurementRepo,
+		profileRepo:     profileRepo,
+		userRepo:        userRepo,
+		logger:          logger,
+	}
+}
+
+func (s *measurementService) RecordMeasurement(ctx context.Context, userID uuid.UUID, m *measurement.Measurement) (*measurement.RecordResult, error) {
+	unit, ok := measurement.Units[m.Type]
+	if !ok {
+		return nil, measurement.ErrUnknownType
+	}
+
+	m.UserID = userID
+	m.Unit = unit
+	if m.MeasuredAt.IsZero() {
+		m.MeasuredAt = time.Now()
+	}
+	// A minute of leeway allows for clients whose clocks run slightly ahead
+	if m.MeasuredAt.After(time.Now().Add(time.Minute)) {
+		return nil, measurement.ErrFutureTime
+	}
+
+	if err := s.measurementRepo.Create(ctx, m); err != nil {
+		return nil, fmt.Errorf("failed to record measurement: %w", err)
+	}
+
+	result := &measurement.RecordResult{Measurement: m}
+	if m.Type != measurement.TypeWeight {
+		return result, nil
+	}
+
+	now := time.Now()
+	series, err := s.buildSeries(ctx, userID, measurement.TypeWeight, now.Add(-rateWindow), now.Add(time.Minute))
+	if err != nil {
+		return nil, err
+	}
+	result.Trend = series
+
+	// Keep the user's current weight in step with the latest reading
+	if n := len(series.Points); n > 0 && !series.Points[n-1].MeasuredAt.After(m.MeasuredAt) {
+		s.updateUserWeight(userID, m.Value)
+	}
+
+	adjustment, err := s.adjustCalorieTarget(ctx, userID, series)
+	if err != nil {
+		s.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to adjust calorie target")
+	}
+	result.Adjustment = adjustment
+
+	return result, nil
+}
+
+func (s *measurementService) ListMeasurements(ctx context.Context, userID uuid.UUID, measurementType string, from, to time.Time) (*measurement.Series, error) {
+	if _, ok := measurement.Units[measurementType]; !ok {
+		return nil, measurement.ErrUnknownType
+	}
+	return s.buildSeries(ctx, userID, measurementType, from, to)
+}
+
+func (s *measurementService) DeleteMeasurement(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	if err := s.measurementRepo.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to delete measurement: %w", err)
+	}
+	return nil
+}
+
+func (s *measurementService) ListCalorieAdjustments(ctx context.Context, userID uuid.UUID, profileID uuid.UUID) ([]measurement.CalorieAdjustment, error) {
+	p, err := s.profileRepo.GetByID(profileID)
+	if err != nil {
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, profile.ErrUnauthorized
+	}
+	return s.measurementRepo.ListAdjustments(ctx, profileID)
+}
+
+// buildSeries loads measurements in [from, to) and smooths them. Readings from
+// before the range seed the moving average but are not returned.
+func (s *measurementService) buildSeries(ctx context.Context, userID uuid.UUID, measurementType string, from, to time.Time) (*measurement.Series, error) {
+	measurements, err := s.measurementRepo.List(ctx, userID, measurementType, from.Add(-trendWarmup), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list measurements: %w", err)
+	}
+
+	series := &measurement.Series{
+		Type:       measurementType,
+		Unit:       measurement.Units[measurementType],
+		Points:     []measurement.TrendPoint{},
+		RateWindow: int(rateWindow.Hours() / 24),
+	}
+
+	smoothed := measurement.Smooth(measurements, measurement.DefaultSmoothing)
+	if rate, ok := measurement.WeeklyRate(smoothed, rateWindow, minRateSpan, minRatePoints); ok {
+		series.WeeklyRate = &rate
+	}
+	if len(smoothed) > 0 {
+		latest := smoothed[len(smoothed)-1].Trend
+		series.Latest = &latest
+	}
+
+	for _, p := range smoothed {
+		if !p.MeasuredAt.Before(from) {
+			series.Points = append(series.Points, p)
+		}
+	}
+	series.SampleCount = len(series.Points)
+
+	return series, nil
+}
+
+// adjustCalorieTarget recomputes the default profile's calorie target when the
+// weight trend since the last adjustment drifts from the rate implied by its goal
+func (s *measurementService) adjustCalorieTarget(ctx context.Context, userID uuid.UUID, series *measurement.Series) (*measurement.CalorieAdjustment, error) {
+	p, err := s.profileRepo.GetDefaultByUserID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// No active profile, nothing to adjust
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load default profile: %w", err)
+	}
+
+	goalRate, ok := nutrition.GoalWeeklyRate(p.GoalType)
+	if !ok {
+		return nil, nil
+	}
+
+	// Only judge the trend on readings taken since the last adjustment
+	points := series.Points
+	last, err := s.measurementRepo.GetLatestAdjustment(ctx, p.ID)
+	if err == nil {
+		if time.Since(last.CreatedAt) < adjustmentCooldown {
+			return nil, nil
+		}
+		points = pointsSince(points, last.CreatedAt)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load last calorie adjustment: %w", err)
+	}
+
+	observedRate, ok := measurement.WeeklyRate(points, rateWindow, minRateSpan, minRatePoints)
+	if !ok || math.Abs(observedRate-goalRate) <= rateTolerance {
+		return nil, nil
+	}
+
+	current := p.CalorieTarget
+	if current == 0 && series.Latest != nil {
+		if current, err = s.estimateCalorieTarget(userID, *series.Latest, p.GoalType); err != nil {
+			return nil, err
+		}
+	}
+	if current == 0 {
+		return nil, nil
+	}
+
+	newTarget := nutrition.AdjustCalorieTarget(current, observedRate, goalRate, maxAdjustmentStep)
+	if newTarget == p.CalorieTarget {
+		return nil, nil
+	}
+
+	adjustment := &measurement.CalorieAdjustment{
+		ProfileID:          p.ID,
+		PreviousTarget:     p.CalorieTarget,
+		NewTarget:          newTarget,
+		ObservedRateKgWeek: observedRate,
+		GoalRateKgWeek:     goalRate,
+	}
+
+	if err := s.measurementRepo.ApplyAdjustment(ctx, adjustment); errors.Is(err, sql.ErrNoRows) {
+		// The target was changed meanwhile, which takes precedence
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to adjust calorie target: %w", err)
+	}
+
+	s.logger.Info().
+		Str("profile_id", p.ID.String()).
+		Int("previous_target", adjustment.PreviousTarget).
+		Int("new_target", adjustment.NewTarget).
+		Float64("observed_rate_kg_week", observedRate).
+		Float64("goal_rate_kg_week", goalRate).
+		Msg("Calorie target adjusted from weight trend")
+
+	return adjustment, nil
+}
+
+func (s *measurementService) estimateCalorieTarget(userID uuid.UUID, weightKg float64, goalType string) (int, error) {
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	age := 0
+	if u.DateOfBirth != nil {
+		age = nutrition.AgeFromDateOfBirth(*u.DateOfBirth, time.Now())
+	}
+	return nutrition.EstimateCalorieTarget(weightKg, u.HeightCm, age, u.Gender, u.ActivityLevel, goalType), nil
+}
+
+func (s *measurementService) updateUserWeight(userID uuid.UUID, weightKg float64) {
+	u, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		s.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to load user to update weight")
+		return
+	}
+
+	u.WeightKg = weightKg
+	if err := s.userRepo.Update(u); err != nil {
+		s.logger.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to update user weight")
+	}
+}
+
+func pointsSince(points []measurement.TrendPoint, since time.Time) []measurement.TrendPoint {
+	for i, p := range points {
+		if !p.MeasuredAt.Before(since) {
+			return points[i:]
+		}
+	}
+	return nil
+}
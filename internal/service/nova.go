@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
+)
+
+// Processing rules: {"type": "processing", "operation": "exclude", "target":
+// "ultra_processed"} drops NOVA group 4 foods. Profiles get one by listing
+// "no_ultra_processed" among their dietary restrictions. Foods without a NOVA
+// group are never excluded.
+const (
+	processingRuleType          = "processing"
+	ultraProcessedTarget        = "ultra_processed"
+	noUltraProcessedRestriction = "no_ultra_processed"
+)
+
+// excludedByProcessing reports whether a processing exclusion rule matches a food
+func excludedByProcessing(f food.Food, rule recommendation.Rule) bool {
+	return rule.Target == ultraProcessedTarget && f.IsUltraProcessed()
+}
+
+// ultraProcessedMarkers lists the markers that made a food ultra-processed
+func ultraProcessedMarkers(f food.Food) []string {
+	var markers []string
+	for _, m := range f.NovaMarkers {
+		if m.Group == food.NovaUltraProcessed {
+			markers = append(markers, m.Marker)
+		}
+	}
+	return markers
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
+)
+
+// Nutri-Score rules: {"type": "nutri_score", "operation": "max", "value": "C"}
+// excludes foods graded worse than C, and a "prefer" rule orders the
+// recommended foods best grade first. Ungraded foods are never excluded.
+const nutriScoreRuleType = "nutri_score"
+
+// withinNutriScore reports whether a food's grade is no worse than the
+// rule's grade
+func withinNutriScore(f food.Food, rule recommendation.Rule) bool {
+	limit, _ := rule.Value.(string)
+	maxRank := food.NutriScoreRank(strings.ToUpper(limit))
+	rank := food.NutriScoreRank(f.NutriScoreGrade)
+	if maxRank < 0 || rank < 0 {
+		return true // Without a valid grade on both sides, don't exclude
+	}
+	return rank <= maxRank
+}
+
+func prefersNutriScore(rules []recommendation.Rule) bool {
+	for _, rule := range rules {
+		if rule.Type == nutriScoreRuleType && rule.Operation == "prefer" {
+			return true
+		}
+	}
+	return false
+}
+
+// sortByNutriScore orders foods best grade first, keeping ungraded foods last
+// and otherwise preserving the original order
+func sortByNutriScore(foods []food.Food) {
+	rank := func(f food.Food) int {
+		if r := food.NutriScoreRank(f.NutriScoreGrade); r >= 0 {
+			return r
+		}
+		return len(food.NutriScoreGrades)
+	}
+	sort.SliceStable(foods, func(i, j int) bool { return rank(foods[i]) < rank(foods[j]) })
+}
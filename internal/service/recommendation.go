@@ -8,25 +8,41 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/household"
+	"github.com/yeboahd24/nutrimatch/internal/domain/intake"
 	"github.com/yeboahd24/nutrimatch/internal/domain/profile"
 	"github.com/yeboahd24/nutrimatch/internal/domain/recommendation"
+	"github.com/yeboahd24/nutrimatch/internal/domain/reference"
+	"github.com/yeboahd24/nutrimatch/internal/domain/user"
 )
 
 type recommendationService struct {
-	foodRepo    food.Repository
-	profileRepo profile.Repository
-	logger      zerolog.Logger
+	foodRepo      food.Repository
+	profileRepo   profile.Repository
+	userRepo      user.Repository
+	referenceRepo reference.Repository
+	intakeRepo    intake.Repository
+	householdRepo household.Repository
+	logger        zerolog.Logger
 }
 
 func NewRecommendationService(
 	foodRepo food.Repository,
 	profileRepo profile.Repository,
+	userRepo user.Repository,
+	referenceRepo reference.Repository,
+	intakeRepo intake.Repository,
+	householdRepo household.Repository,
 	logger zerolog.Logger,
 ) RecommendationService {
 	return &recommendationService{
-		foodRepo:    foodRepo,
-		profileRepo: profileRepo,
-		logger:      logger,
+		foodRepo:      foodRepo,
+		profileRepo:   profileRepo,
+		userRepo:      userRepo,
+		referenceRepo: referenceRepo,
+		intakeRepo:    intakeRepo,
+		householdRepo: householdRepo,
+		logger:        logger,
 	}
 }
 
@@ -67,17 +83,13 @@ func (s *recommendationService) GetRecommendations(userID uuid.UUID, req recomme
 	if req.Limit > 0 {
 		limit = req.Limit
 	}
-	foods, err := s.foodRepo.List(limit, req.Offset)
+	after, offset, err := decodePageCursor(req.Cursor, food.OrderName, req.Offset)
 	if err != nil {
 		return nil, err
 	}
-
-	// Apply rules to filter foods
-	var filteredFoods []food.Food
-	for _, f := range foods {
-		if s.applyRules(f, rules) {
-			filteredFoods = append(filteredFoods, f)
-		}
+	filteredFoods, next, err := s.filterFoods(rules, after, limit, offset)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get total count for pagination
@@ -87,9 +99,37 @@ func (s *recommendationService) GetRecommendations(userID uuid.UUID, req recomme
 		Foods:        filteredFoods,
 		TotalCount:   totalCount,
 		AppliedRules: rules,
+		NextCursor:   next,
 	}, nil
 }
 
+// filterFoods lists a page of foods and keeps those that pass the rules. The
+// next cursor continues after the last food listed, filtered out or not, so
+// that the next page scans on from there.
+func (s *recommendationService) filterFoods(rules []recommendation.Rule, after *food.Cursor, limit, offset int) ([]food.Food, string, error) {
+	foods, err := s.foodRepo.List(after, limit, offset)
+	if err != nil {
+		return nil, "", err
+	}
+	next, err := nextCursor(foods, limit, func(last *food.Food) (*food.Cursor, error) {
+		return food.NameCursor(last), nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var filteredFoods []food.Food
+	for _, f := range foods {
+		if s.applyRules(f, rules) {
+			filteredFoods = append(filteredFoods, f)
+		}
+	}
+	if prefersNutriScore(rules) {
+		sortByNutriScore(filteredFoods)
+	}
+	return filteredFoods, next, nil
+}
+
 func (s *recommendationService) GetAlternatives(userID uuid.UUID, foodID string, limit int) ([]food.Food, error) {
 	// Get the original food
 	originalFood, err := s.foodRepo.GetByID(foodID)
@@ -132,6 +172,15 @@ func (s *recommendationService) GenerateRulesFromProfile(profile *profile.UserPr
 
 	// Add dietary restriction rules (high priority)
 	for _, restriction := range profile.DietaryRestrictions {
+		if restriction == noUltraProcessedRestriction {
+			rules = append(rules, recommendation.Rule{
+				Type:      processingRuleType,
+				Operation: "exclude",
+				Target:    ultraProcessedTarget,
+				Priority:  90,
+			})
+			continue
+		}
 		rules = append(rules, recommendation.Rule{
 			Type:      "dietary",
 			Operation: "exclude",
@@ -171,6 +220,14 @@ func (s *recommendationService) GenerateRulesFromProfile(profile *profile.UserPr
 		})
 	}
 
+	// Prefer foods with a better Nutri-Score (lowest priority)
+	rules = append(rules, recommendation.Rule{
+		Type:      nutriScoreRuleType,
+		Operation: "prefer",
+		Target:    "grade",
+		Priority:  30,
+	})
+
 	// Add cuisine preference rules (low priority)
 	for _, cuisine := range profile.CuisinePreferences {
 		rules = append(rules, recommendation.Rule{
@@ -218,16 +275,19 @@ func (s *recommendationService) applyRules(food food.Food, rules []recommendatio
 func (s *recommendationService) matchesExclusionRule(food food.Food, rule recommendation.Rule) bool {
 	switch rule.Type {
 	case "allergen":
-		// Check ingredients for allergen
+		// Check ingredients, including "may contain" declarations, for allergen
+		_, inIngredients := food.MentionsIngredient(rule.Target, true)
 		return containsString(food.Labels, rule.Target) ||
 			containsString(food.AlternateNames, rule.Target) ||
-			contains(food.Ingredients, rule.Target)
+			inIngredients
 	case "dietary":
 		// Check if food violates dietary restriction
 		return violatesDietaryRestriction(food, rule.Target)
 	case "preference":
 		// Check if food matches disliked item
 		return matchesPreference(food, rule.Target)
+	case processingRuleType:
+		return excludedByProcessing(food, rule)
 	default:
 		return false
 	}
@@ -245,15 +305,17 @@ func (s *recommendationService) matchesInclusionRule(food food.Food, rule recomm
 func (s *recommendationService) matchesMaxRule(food food.Food, rule recommendation.Rule) bool {
 	switch rule.Type {
 	case "nutrient":
-		value, ok := getNutrientValue(food, rule.Target)
+		value, ok := food.NutrientValue(rule.Target)
 		if !ok {
 			return true // If nutrient info not available, don't exclude
 		}
-		maxValue, ok := rule.Value.(int)
+		maxValue, ok := ruleThreshold(rule.Value)
 		if !ok {
 			return true // If rule value invalid, don't exclude
 		}
-		return value <= float64(maxValue)
+		return value <= maxValue
+	case nutriScoreRuleType:
+		return withinNutriScore(food, rule)
 	default:
 		return true
 	}
@@ -262,15 +324,15 @@ func (s *recommendationService) matchesMaxRule(food food.Food, rule recommendati
 func (s *recommendationService) matchesMinRule(food food.Food, rule recommendation.Rule) bool {
 	switch rule.Type {
 	case "nutrient":
-		value, ok := getNutrientValue(food, rule.Target)
+		value, ok := food.NutrientValue(rule.Target)
 		if !ok {
 			return true // If nutrient info not available, don't exclude
 		}
-		minValue, ok := rule.Value.(int)
+		minValue, ok := ruleThreshold(rule.Value)
 		if !ok {
 			return true // If rule value invalid, don't exclude
 		}
-		return value >= float64(minValue)
+		return value >= minValue
 	default:
 		return true
 	}
@@ -314,24 +376,6 @@ func matchesPreference(food food.Food, preference string) bool {
 		contains(food.Name, preference)
 }
 
-func getNutrientValue(food food.Food, nutrient string) (float64, bool) {
-	// Nutrition100g is already a map[string]interface{}
-	if value, ok := food.Nutrition100g[nutrient]; ok {
-		// Try to convert the value to float64
-		switch v := value.(type) {
-		case float64:
-			return v, true
-		case int:
-			return float64(v), true
-		case string:
-			if f, err := strconv.ParseFloat(v, 64); err == nil {
-				return f, true
-			}
-		}
-	}
-	return 0, false
-}
-
 // Adapter methods to implement the service.RecommendationService interface
 func (s *recommendationService) GetDailyRecommendations(ctx context.Context, profileID string, limit int) ([]food.Food, error) {
 	pid, err := uuid.Parse(profileID)
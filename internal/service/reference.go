@@ -36,3 +36,12 @@ func (s *referenceService) GetDietaryPatterns(ctx context.Context) ([]reference.
 	s.logger.Debug().Msg("Getting dietary patterns from repository")
 	return s.repo.GetDietaryPatterns(ctx)
 }
+
+func (s *referenceService) GetFoodCategories(ctx context.Context) ([]reference.FoodCategory, error) {
+	s.logger.Debug().Msg("Getting food categories from repository")
+	categories, err := s.repo.GetFoodCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return reference.BuildCategoryTree(categories), nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/domain/submission"
+)
+
+type submissionService struct {
+	submissionRepo submission.Repository
+	foodRepo       food.Repository
+	logger         zerolog.Logger
+}
+
+func NewSubmissionService(
+	submissionRepo submission.Repository,
+	foodRepo food.Repository,
+	logger zerolog.Logger,
+) SubmissionService {
+	return &submissionService{
+		submissionRepo: submissionRepo,
+		foodRepo:       foodRepo,
+		logger:         logger,
+	}
+}
+
+// Submit stores a user-submitted food as a pending submission. The barcode is
+// normalized to GTIN-13 and must not already be in the catalog.
+func (s *submissionService) Submit(ctx context.Context, userID uuid.UUID, input submission.Input) (*submission.Submission, error) {
+	f := input.Food()
+	if err := s.prepareSubmittedFood(f); err != nil {
+		return nil, err
+	}
+
+	sub := &submission.Submission{SubmitterID: userID, Food: f}
+	if err := s.submissionRepo.Create(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to create submission: %w", err)
+	}
+
+	s.logger.Info().Str("submission_id", sub.ID.String()).Str("submitter_id", userID.String()).Msg("Food submitted for review")
+	return sub, nil
+}
+
+// ListMySubmissions returns a page of a user's own submissions, newest first,
+// optionally limited to one status
+func (s *submissionService) ListMySubmissions(ctx context.Context, userID uuid.UUID, status string, page, limit int) ([]submission.Submission, int, error) {
+	if status != "" && !submission.ValidStatus(status) {
+		return nil, 0, submission.ErrUnknownStatus
+	}
+	offset := (page - 1) * limit
+
+	subs, err := s.submissionRepo.ListBySubmitter(ctx, userID, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.submissionRepo.CountBySubmitter(ctx, userID, status)
+	if err != nil {
+		return nil, 0, err
+	}
+	return subs, int(total), nil
+}
+
+// GetMySubmission returns one of a user's own submissions
+func (s *submissionService) GetMySubmission(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*submission.Submission, error) {
+	sub, err := s.submissionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.SubmitterID != userID {
+		return nil, submission.ErrNotSubmitter
+	}
+	return sub, nil
+}
+
+// ListSubmissions returns a page of submissions with a status, oldest first so
+// that the moderation queue is worked in order. The status defaults to pending.
+func (s *submissionService) ListSubmissions(ctx context.Context, status string, page, limit int) ([]submission.Submission, int, error) {
+	if status == "" {
+		status = submission.StatusPending
+	}
+	if !submission.ValidStatus(status) {
+		return nil, 0, submission.ErrUnknownStatus
+	}
+	offset := (page - 1) * limit
+
+	subs, err := s.submissionRepo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.submissionRepo.CountByStatus(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+	return subs, int(total), nil
+}
+
+// GetSubmission returns any submission
+func (s *submissionService) GetSubmission(ctx context.Context, id uuid.UUID) (*submission.Submission, error) {
+	return s.submissionRepo.GetByID(ctx, id)
+}
+
+// EditSubmission applies a JSON merge patch to the food of a pending
+// submission, letting moderators fix it up before approval
+func (s *submissionService) EditSubmission(ctx context.Context, id uuid.UUID, patch json.RawMessage) (*submission.Submission, error) {
+	sub, err := s.pendingSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	edited, err := food.ApplyPatch(sub.Food, patch)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.prepareSubmittedFood(edited); err != nil {
+		return nil, err
+	}
+
+	sub.Food = edited
+	if err := s.submissionRepo.UpdateFood(ctx, sub); err != nil {
+		return nil, notPendingOr(err)
+	}
+	return sub, nil
+}
+
+// ApproveSubmission promotes a pending submission into the food catalog. The
+// food is created as the moderator, so it gets a create revision like any
+// other catalog edit. The submission is claimed in the same transaction, so
+// a concurrent approval or a failure leaves no food behind.
+func (s *submissionService) ApproveSubmission(ctx context.Context, moderatorID uuid.UUID, id uuid.UUID) (*submission.Submission, error) {
+	sub, err := s.pendingSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The barcode may have been catalogued since the food was submitted
+	if err := s.prepareSubmittedFood(sub.Food); err != nil {
+		return nil, err
+	}
+	candidate := *sub.Food
+	candidate.ID = uuid.NewString()
+	if err := prepareEditedFood(&candidate); err != nil {
+		return nil, err
+	}
+	rev := newRevision(moderatorID, food.RevisionCreate, candidate.ID, nil, &candidate, nil)
+
+	sub.ReviewerID = &moderatorID
+	if err := s.submissionRepo.Approve(ctx, sub, &candidate, rev); err != nil {
+		return nil, notPendingOr(err)
+	}
+
+	s.logger.Info().Str("submission_id", id.String()).Str("food_id", candidate.ID).Str("reviewer_id", moderatorID.String()).Msg("Food submission approved")
+	return sub, nil
+}
+
+// RejectSubmission rejects a pending submission with a reason shown to the submitter
+func (s *submissionService) RejectSubmission(ctx context.Context, moderatorID uuid.UUID, id uuid.UUID, reason string) (*submission.Submission, error) {
+	sub, err := s.pendingSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.Status = submission.StatusRejected
+	sub.ReviewerID = &moderatorID
+	sub.RejectionReason = strings.TrimSpace(reason)
+	if err := s.submissionRepo.Review(ctx, sub); err != nil {
+		return nil, notPendingOr(err)
+	}
+
+	s.logger.Info().Str("submission_id", id.String()).Str("reviewer_id", moderatorID.String()).Msg("Food submission rejected")
+	return sub, nil
+}
+
+func (s *submissionService) pendingSubmission(ctx context.Context, id uuid.UUID) (*submission.Submission, error) {
+	sub, err := s.submissionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status != submission.StatusPending {
+		return nil, submission.ErrNotPending
+	}
+	return sub, nil
+}
+
+// prepareSubmittedFood validates a submitted food and normalizes its barcode,
+// rejecting barcodes already in the catalog
+func (s *submissionService) prepareSubmittedFood(f *food.Food) error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return food.ErrNameRequired
+	}
+	if f.EAN13 == "" {
+		return nil
+	}
+
+	gtin, err := food.NormalizeGTIN(f.EAN13)
+	if err != nil {
+		return err
+	}
+	f.EAN13 = gtin
+
+	if _, err := findByBarcode(s.foodRepo, gtin); err == nil {
+		return submission.ErrBarcodeInCatalog
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// notPendingOr reports a conditional update that matched no row, because the
+// submission was reviewed concurrently, as ErrNotPending
+func notPendingOr(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return submission.ErrNotPending
+	}
+	return err
+}
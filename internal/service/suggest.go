@@ -0,0 +1,219 @@
+package service
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+)
+
+// suggestIndexTTL bounds how stale the autocomplete index can get when foods
+// are imported by another process
+const suggestIndexTTL = 10 * time.Minute
+
+// maxSuggestKeyRunes truncates index keys to bound memory on long names
+const maxSuggestKeyRunes = 48
+
+// suggestEntry is one word-start position within a food name. key is the
+// lowercased name from that word onwards, so a sorted slice of entries
+// answers "any word starts with the prefix" with a binary search.
+type suggestEntry struct {
+	key       string
+	foodID    string
+	text      string
+	runeStart int
+	alternate bool
+}
+
+// suggestIndex is an in-memory prefix index over food names and alternate names
+type suggestIndex struct {
+	repo   food.Repository
+	logger zerolog.Logger
+
+	mu         sync.RWMutex
+	entries    []suggestEntry
+	builtAt    time.Time
+	refreshing bool
+}
+
+func newSuggestIndex(repo food.Repository, logger zerolog.Logger) *suggestIndex {
+	return &suggestIndex{repo: repo, logger: logger}
+}
+
+// Refresh rebuilds the index from the food catalog
+func (idx *suggestIndex) Refresh() error {
+	names, err := idx.repo.ListNames()
+	if err != nil {
+		return err
+	}
+
+	var entries []suggestEntry
+	for _, n := range names {
+		entries = appendSuggestEntries(entries, n.FoodID, n.Name, false)
+		for _, alt := range n.AlternateNames {
+			entries = appendSuggestEntries(entries, n.FoodID, alt, true)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	idx.mu.Lock()
+	idx.entries = entries
+	idx.builtAt = time.Now()
+	idx.mu.Unlock()
+
+	idx.logger.Info().Int("foods", len(names)).Int("entries", len(entries)).Msg("Rebuilt food suggestion index")
+	return nil
+}
+
+// ensureFresh builds the index on first use and rebuilds it in the
+// background once it is older than suggestIndexTTL
+func (idx *suggestIndex) ensureFresh() error {
+	idx.mu.Lock()
+	built, stale := !idx.builtAt.IsZero(), time.Since(idx.builtAt) > suggestIndexTTL
+	startRefresh := built && stale && !idx.refreshing
+	if startRefresh {
+		idx.refreshing = true
+	}
+	idx.mu.Unlock()
+
+	if !built {
+		return idx.Refresh()
+	}
+	if startRefresh {
+		go func() {
+			if err := idx.Refresh(); err != nil {
+				idx.logger.Error().Err(err).Msg("Failed to refresh food suggestion index")
+			}
+			idx.mu.Lock()
+			idx.refreshing = false
+			idx.mu.Unlock()
+		}()
+	}
+	return nil
+}
+
+// Suggest returns up to limit names with a word starting with prefix. Matches
+// at the start of a name rank first, then primary names, then shorter names.
+func (idx *suggestIndex) Suggest(prefix string, limit int) ([]food.Suggestion, error) {
+	if err := idx.ensureFresh(); err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return []food.Suggestion{}, nil
+	}
+	prefixLen := len([]rune(prefix))
+	prefix = truncateRunes(prefix, maxSuggestKeyRunes)
+
+	idx.mu.RLock()
+	entries := idx.entries
+	idx.mu.RUnlock()
+
+	// Every match is ranked, keeping only the best limit names, so that
+	// short prefixes with many matches still return the best of them
+	start := sort.Search(len(entries), func(i int) bool { return entries[i].key >= prefix })
+	var best []suggestEntry
+	for i := start; i < len(entries) && strings.HasPrefix(entries[i].key, prefix); i++ {
+		best = rankSuggestion(best, entries[i], limit)
+	}
+
+	suggestions := make([]food.Suggestion, len(best))
+	for i, m := range best {
+		suggestions[i] = food.Suggestion{
+			FoodID:      m.foodID,
+			Text:        m.text,
+			Highlighted: highlightRunes(m.text, m.runeStart, prefixLen),
+			Alternate:   m.alternate,
+		}
+	}
+	return suggestions, nil
+}
+
+// rankSuggestion adds a match to best, which holds at most limit matches in
+// rank order and one match per name of a food
+func rankSuggestion(best []suggestEntry, m suggestEntry, limit int) []suggestEntry {
+	for i, b := range best {
+		if b.foodID == m.foodID && b.text == m.text {
+			if !suggestsBefore(m, b) {
+				return best
+			}
+			best = append(best[:i], best[i+1:]...)
+			break
+		}
+	}
+	if limit < 1 || (len(best) >= limit && !suggestsBefore(m, best[len(best)-1])) {
+		return best
+	}
+
+	at := sort.Search(len(best), func(i int) bool { return suggestsBefore(m, best[i]) })
+	best = append(best, suggestEntry{})
+	copy(best[at+1:], best[at:])
+	best[at] = m
+	if len(best) > limit {
+		best = best[:limit]
+	}
+	return best
+}
+
+// suggestsBefore reports whether a ranks above b: matches at the start of a
+// name first, then primary names, then shorter names
+func suggestsBefore(a, b suggestEntry) bool {
+	if (a.runeStart == 0) != (b.runeStart == 0) {
+		return a.runeStart == 0
+	}
+	if a.alternate != b.alternate {
+		return !a.alternate
+	}
+	if len(a.text) != len(b.text) {
+		return len(a.text) < len(b.text)
+	}
+	return a.text < b.text
+}
+
+// appendSuggestEntries adds one entry per word start in text
+func appendSuggestEntries(entries []suggestEntry, foodID, text string, alternate bool) []suggestEntry {
+	runes := []rune(text)
+	for i, r := range runes {
+		if !isWordRune(r) || (i > 0 && isWordRune(runes[i-1])) {
+			continue
+		}
+		entries = append(entries, suggestEntry{
+			key:       truncateRunes(strings.ToLower(string(runes[i:])), maxSuggestKeyRunes),
+			foodID:    foodID,
+			text:      text,
+			runeStart: i,
+			alternate: alternate,
+		})
+	}
+	return entries
+}
+
+func truncateRunes(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// highlightRunes wraps length runes of text starting at start in <em> tags,
+// escaping the rest for safe display
+func highlightRunes(text string, start, length int) string {
+	runes := []rune(text)
+	end := start + length
+	if end > len(runes) {
+		end = len(runes)
+	}
+	return html.EscapeString(string(runes[:start])) +
+		"<em>" + html.EscapeString(string(runes[start:end])) + "</em>" +
+		html.EscapeString(string(runes[end:]))
+}
@@ -229,6 +229,9 @@ func (s *userService) UpdateUser(ctx context.Context, userID string, input *user
 	if input.ActivityLevel != nil {
 		existingUser.ActivityLevel = *input.ActivityLevel
 	}
+	if input.Locale != nil {
+		existingUser.Locale = *input.Locale
+	}
 
 	// Save updates
 	if err := s.Update(existingUser); err != nil {
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS food_intake_logs;
@@ -0,0 +1,13 @@
+-- Create food_intake_logs table for tracking what a profile has eaten
+CREATE TABLE food_intake_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES user_profiles(id) ON DELETE CASCADE,
+    food_id VARCHAR(50) NOT NULL REFERENCES foods(id) ON DELETE CASCADE,
+    amount_g NUMERIC(7,2) NOT NULL CHECK (amount_g > 0),
+    meal_type VARCHAR(20), -- breakfast, lunch, dinner, snack
+    consumed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_food_intake_logs_profile_consumed ON food_intake_logs(profile_id, consumed_at);
+CREATE INDEX idx_food_intake_logs_food_id ON food_intake_logs(food_id);
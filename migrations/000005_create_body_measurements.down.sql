@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS calorie_target_adjustments;
+DROP TABLE IF EXISTS body_measurements;
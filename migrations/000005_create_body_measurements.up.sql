@@ -0,0 +1,26 @@
+-- Create body_measurements table for weight and body measurement history
+CREATE TABLE body_measurements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    measurement_type VARCHAR(20) NOT NULL, -- weight, body_fat, waist, hip, chest, neck
+    value NUMERIC(7,2) NOT NULL CHECK (value > 0),
+    unit VARCHAR(10) NOT NULL, -- kg, %, cm
+    measured_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_body_measurements_user_type_measured ON body_measurements(user_id, measurement_type, measured_at);
+
+-- Create calorie_target_adjustments table recording automatic changes to a profile's calorie target
+CREATE TABLE calorie_target_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES user_profiles(id) ON DELETE CASCADE,
+    previous_target INTEGER,
+    new_target INTEGER NOT NULL,
+    observed_rate_kg_week NUMERIC(5,2) NOT NULL,
+    goal_rate_kg_week NUMERIC(5,2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_calorie_target_adjustments_profile_created ON calorie_target_adjustments(profile_id, created_at);
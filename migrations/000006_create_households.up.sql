@@ -0,0 +1,34 @@
+-- Create households table grouping profiles that share meal plans
+CREATE TABLE households (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    owner_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_households_owner_user_id ON households(owner_user_id);
+
+-- Create household_members table; members are profiles, possibly owned by different users
+CREATE TABLE household_members (
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    profile_id UUID NOT NULL REFERENCES user_profiles(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (household_id, profile_id)
+);
+
+CREATE INDEX idx_household_members_profile_id ON household_members(profile_id);
+
+-- Create household_invitations table for inviting other users to a household
+CREATE TABLE household_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    household_id UUID NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    invitee_email VARCHAR(255) NOT NULL, -- stored lowercased
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, accepted, declined
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    responded_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_household_invitations_household_id ON household_invitations(household_id);
+CREATE INDEX idx_household_invitations_invitee_email ON household_invitations(invitee_email);
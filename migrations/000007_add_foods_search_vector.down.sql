@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_foods_search_vector;
+ALTER TABLE foods DROP COLUMN IF EXISTS search_vector;
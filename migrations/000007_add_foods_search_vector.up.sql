@@ -0,0 +1,10 @@
+-- Full-text search over the food catalog. Weights rank name matches above
+-- alternate names, then description, then ingredients.
+ALTER TABLE foods ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(jsonb_to_tsvector('english', coalesce(alternate_names, '[]'::jsonb), '["string"]'), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(ingredients, '')), 'D')
+) STORED;
+
+CREATE INDEX idx_foods_search_vector ON foods USING GIN (search_vector);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_foods_name_trgm;
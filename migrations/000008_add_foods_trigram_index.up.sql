@@ -0,0 +1,4 @@
+-- Trigram index for typo-tolerant name matching
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_foods_name_trgm ON foods USING GIN (name gin_trgm_ops);
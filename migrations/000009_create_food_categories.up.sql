@@ -0,0 +1,59 @@
+-- Hierarchical food category taxonomy. Each category lists the foods.food_type
+-- values that belong to it; a category's foods include those of its subcategories.
+CREATE TABLE food_categories (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    parent_id INTEGER REFERENCES food_categories(id) ON DELETE CASCADE,
+    food_types JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_food_categories_parent_id ON food_categories(parent_id);
+
+-- Top-level categories
+INSERT INTO food_categories (slug, name, food_types) VALUES
+('dairy', 'Dairy', '["dairy"]'),
+('meat', 'Meat', '["meat"]'),
+('seafood', 'Seafood', '["seafood"]'),
+('produce', 'Fruits and vegetables', '["produce"]'),
+('grains', 'Grains', '["grains", "grain"]'),
+('legumes-nuts-seeds', 'Legumes, nuts and seeds', '["legumes", "nuts", "seeds"]'),
+('beverages', 'Beverages', '["beverages", "beverage", "drinks"]'),
+('snacks-sweets', 'Snacks and sweets', '["snacks", "sweets", "desserts"]'),
+('prepared-foods', 'Prepared foods', '["prepared"]');
+
+-- Second level
+INSERT INTO food_categories (slug, name, parent_id, food_types)
+SELECT v.slug, v.name, p.id, v.food_types::jsonb
+FROM (VALUES
+    ('cheese', 'Cheese', 'dairy', '["cheese"]'),
+    ('milk', 'Milk and cream', 'dairy', '["milk", "cream"]'),
+    ('yogurt', 'Yogurt', 'dairy', '["yogurt"]'),
+    ('poultry', 'Poultry', 'meat', '["poultry"]'),
+    ('beef', 'Beef', 'meat', '["beef"]'),
+    ('pork', 'Pork', 'meat', '["pork"]'),
+    ('fish', 'Fish', 'seafood', '["fish"]'),
+    ('shellfish', 'Shellfish', 'seafood', '["shellfish"]'),
+    ('fruits', 'Fruits', 'produce', '["fruit", "fruits"]'),
+    ('vegetables', 'Vegetables', 'produce', '["vegetable", "vegetables"]'),
+    ('bread', 'Bread and bakery', 'grains', '["bread", "bakery"]'),
+    ('pasta', 'Pasta', 'grains', '["pasta"]'),
+    ('rice', 'Rice', 'grains', '["rice"]'),
+    ('cereals', 'Breakfast cereals', 'grains', '["cereal", "cereals"]'),
+    ('restaurant', 'Restaurant dishes', 'prepared-foods', '["restaurant"]')
+) AS v(slug, name, parent_slug, food_types)
+JOIN food_categories p ON p.slug = v.parent_slug;
+
+-- Third level
+INSERT INTO food_categories (slug, name, parent_id, food_types)
+SELECT v.slug, v.name, p.id, v.food_types::jsonb
+FROM (VALUES
+    ('hard-cheese', 'Hard cheese', 'cheese', '["hard cheese"]'),
+    ('soft-cheese', 'Soft cheese', 'cheese', '["soft cheese"]'),
+    ('leafy-greens', 'Leafy greens', 'vegetables', '["leafy greens"]'),
+    ('root-vegetables', 'Root vegetables', 'vegetables', '["root vegetables"]'),
+    ('berries', 'Berries', 'fruits', '["berries"]'),
+    ('citrus', 'Citrus fruits', 'fruits', '["citrus"]')
+) AS v(slug, name, parent_slug, food_types)
+JOIN food_categories p ON p.slug = v.parent_slug;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_foods_invalid_barcode;
+ALTER TABLE foods DROP COLUMN IF EXISTS invalid_barcode;
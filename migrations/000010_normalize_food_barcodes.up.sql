@@ -0,0 +1,8 @@
+-- Barcodes are stored as GTIN-13. Codes that fail validation at import are
+-- kept in invalid_barcode instead of ean_13 so they can be reviewed.
+ALTER TABLE foods ADD COLUMN invalid_barcode VARCHAR(32);
+
+-- UPC-A codes become GTIN-13 with a leading zero
+UPDATE foods SET ean_13 = '0' || ean_13 WHERE ean_13 ~ '^[0-9]{12}$';
+
+CREATE INDEX idx_foods_invalid_barcode ON foods(invalid_barcode) WHERE invalid_barcode IS NOT NULL;
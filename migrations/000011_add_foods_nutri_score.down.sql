@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_foods_nutri_score;
+ALTER TABLE foods DROP COLUMN IF EXISTS nutri_score;
+ALTER TABLE foods DROP COLUMN IF EXISTS nutri_score_points;
+ALTER TABLE foods DROP COLUMN IF EXISTS nutri_score_grade;
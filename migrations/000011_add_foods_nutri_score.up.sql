@@ -0,0 +1,7 @@
+-- Nutri-Score grade, score and points breakdown, computed when foods are
+-- imported. Foods missing energy, sugars, saturated fat or sodium stay NULL.
+ALTER TABLE foods ADD COLUMN nutri_score_grade CHAR(1) CHECK (nutri_score_grade IN ('A', 'B', 'C', 'D', 'E'));
+ALTER TABLE foods ADD COLUMN nutri_score_points SMALLINT;
+ALTER TABLE foods ADD COLUMN nutri_score JSONB;
+
+CREATE INDEX idx_foods_nutri_score ON foods(nutri_score_points, name) WHERE nutri_score_grade IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_foods_nova_group;
+ALTER TABLE foods DROP COLUMN IF EXISTS nova_markers;
+ALTER TABLE foods DROP COLUMN IF EXISTS nova_group;
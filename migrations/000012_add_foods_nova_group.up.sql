@@ -0,0 +1,6 @@
+-- NOVA processing group and the ingredients or additives that triggered it,
+-- classified when foods are imported. Foods without ingredient data stay NULL.
+ALTER TABLE foods ADD COLUMN nova_group SMALLINT CHECK (nova_group BETWEEN 1 AND 4);
+ALTER TABLE foods ADD COLUMN nova_markers JSONB;
+
+CREATE INDEX idx_foods_nova_group ON foods(nova_group) WHERE nova_group IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Ingredient statements parsed into a tree of ingredients, sub-ingredients,
+-- percentages and E-numbers plus "contains" and "may contain" declarations
+ALTER TABLE foods ADD COLUMN ingredient_tree JSONB;
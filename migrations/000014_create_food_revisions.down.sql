@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS food_revisions;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,20 @@
+-- Users are either regular users or admins, who can edit the food catalog.
+-- Grant admin with: UPDATE users SET role = 'admin' WHERE email = '...';
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
+
+-- Every admin change to a food is kept as a revision holding the food before
+-- and after the change, so it can be audited and reverted
+CREATE TABLE food_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    food_id VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'revert')),
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    diff JSONB NOT NULL DEFAULT '{}',
+    before JSONB,
+    after JSONB,
+    reverted_revision_id UUID REFERENCES food_revisions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- No foreign key on food_id: revisions outlive deleted foods so they can be restored
+CREATE INDEX idx_food_revisions_food_id ON food_revisions(food_id, created_at DESC);
@@ -0,0 +1,23 @@
+-- Moderators review user-submitted foods
+ALTER TABLE users DROP CONSTRAINT users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator', 'admin'));
+
+-- Foods submitted by users wait here until a moderator approves them into
+-- foods, so pending submissions never show up in search
+CREATE TABLE food_submissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    submitter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    name VARCHAR(255) NOT NULL,
+    ean_13 VARCHAR(13),
+    food JSONB NOT NULL,
+    rejection_reason TEXT,
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    food_id VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_food_submissions_status ON food_submissions(status, created_at);
+CREATE INDEX idx_food_submissions_submitter ON food_submissions(submitter_id, created_at DESC);
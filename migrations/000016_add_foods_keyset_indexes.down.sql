@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_foods_food_type_name_id;
+DROP INDEX IF EXISTS idx_foods_name_id;
+CREATE INDEX idx_foods_name ON foods(name);
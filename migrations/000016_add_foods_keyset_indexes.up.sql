@@ -0,0 +1,4 @@
+-- Keyset pagination seeks on (name, id), overall and within food types
+DROP INDEX IF EXISTS idx_foods_name;
+CREATE INDEX idx_foods_name_id ON foods(name, id);
+CREATE INDEX idx_foods_food_type_name_id ON foods(food_type, name, id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_foods_updated_at;
@@ -0,0 +1,2 @@
+-- Incremental catalog exports select foods updated since a time
+CREATE INDEX idx_foods_updated_at ON foods(updated_at);
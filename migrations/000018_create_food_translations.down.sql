@@ -0,0 +1,11 @@
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+
+DROP FUNCTION IF EXISTS food_name_similarity(text, text, text, text);
+DROP FUNCTION IF EXISTS food_name_matches(text, text, text, text);
+DROP FUNCTION IF EXISTS food_search_rank(text, tsvector, tsquery, text);
+DROP FUNCTION IF EXISTS food_search_matches(text, tsvector, tsquery, text);
+DROP FUNCTION IF EXISTS food_search_query(text, text);
+
+DROP TABLE IF EXISTS food_translations;
+
+DROP FUNCTION IF EXISTS locale_text_search_config(text);
@@ -0,0 +1,90 @@
+-- Text search configuration for the language of a locale, such as fr-FR.
+-- Languages without a stemmer, such as Twi or Ewe, are matched word by word.
+CREATE FUNCTION locale_text_search_config(locale text) RETURNS regconfig
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT (CASE split_part(lower(locale), '-', 1)
+        WHEN 'en' THEN 'english'
+        WHEN 'fr' THEN 'french'
+        WHEN 'de' THEN 'german'
+        ELSE 'simple'
+    END)::regconfig
+$$;
+
+-- Food names, alternate names and descriptions translated from English
+CREATE TABLE food_translations (
+    food_id VARCHAR(50) NOT NULL REFERENCES foods(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    alternate_names JSONB,
+    description TEXT,
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector(locale_text_search_config(locale), coalesce(name, '')), 'A') ||
+        setweight(jsonb_to_tsvector(locale_text_search_config(locale), coalesce(alternate_names, '[]'::jsonb), '["string"]'), 'B') ||
+        setweight(to_tsvector(locale_text_search_config(locale), coalesce(description, '')), 'C')
+    ) STORED,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (food_id, locale)
+);
+
+CREATE INDEX idx_food_translations_locale ON food_translations(locale);
+CREATE INDEX idx_food_translations_search_vector ON food_translations USING GIN (search_vector);
+CREATE INDEX idx_food_translations_name_trgm ON food_translations USING GIN (name gin_trgm_ops);
+
+-- Full-text query for a search in a locale. The catalog itself is in
+-- English, so other locales match English terms or terms stemmed for their
+-- own language.
+CREATE FUNCTION food_search_query(query text, locale text) RETURNS tsquery
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE locale_text_search_config(locale)
+        WHEN 'english'::regconfig THEN websearch_to_tsquery('english', query)
+        ELSE websearch_to_tsquery('english', query) || websearch_to_tsquery(locale_text_search_config(locale), query)
+    END
+$$;
+
+-- Whether a food, given its ID and search vector, or its translation into a
+-- locale matches a full-text query
+CREATE FUNCTION food_search_matches(food_id text, search_vector tsvector, query tsquery, locale text) RETURNS boolean
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT $2 @@ $3 OR EXISTS (
+        SELECT 1 FROM food_translations ft
+        WHERE ft.food_id = $1 AND ft.locale = $4 AND ft.search_vector @@ $3
+    )
+$$;
+
+-- Relevance of a food to a full-text query: the better of the rank of the
+-- food and of its translation into the locale. Weights rank name matches
+-- above alternate names, then description, then ingredients.
+CREATE FUNCTION food_search_rank(food_id text, search_vector tsvector, query tsquery, locale text) RETURNS float8
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT GREATEST(
+        ts_rank('{0.1, 0.2, 0.4, 1.0}', $2, $3),
+        (SELECT ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, $3)
+         FROM food_translations ft
+         WHERE ft.food_id = $1 AND ft.locale = $4)
+    )::float8
+$$;
+
+-- Whether a food name, or the name of its translation into a locale, is
+-- similar to a possibly misspelled query
+CREATE FUNCTION food_name_matches(food_id text, name text, query text, locale text) RETURNS boolean
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT $3 <% $2 OR EXISTS (
+        SELECT 1 FROM food_translations ft
+        WHERE ft.food_id = $1 AND ft.locale = $4 AND $3 <% ft.name
+    )
+$$;
+
+-- Trigram similarity of a query to a food name or its translated name
+CREATE FUNCTION food_name_similarity(food_id text, name text, query text, locale text) RETURNS float8
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT GREATEST(
+        word_similarity($3, $2),
+        (SELECT word_similarity($3, ft.name)
+         FROM food_translations ft
+         WHERE ft.food_id = $1 AND ft.locale = $4)
+    )::float8
+$$;
+
+-- Preferred language for food names and descriptions, overriding Accept-Language
+ALTER TABLE users ADD COLUMN locale VARCHAR(10);
@@ -0,0 +1,6 @@
+DELETE FROM food_revisions WHERE action = 'merge';
+ALTER TABLE food_revisions DROP CONSTRAINT food_revisions_action_check;
+ALTER TABLE food_revisions ADD CONSTRAINT food_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'revert'));
+
+DROP TABLE IF EXISTS food_redirects;
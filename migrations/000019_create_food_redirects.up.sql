@@ -0,0 +1,16 @@
+-- Foods merged into another food keep their ID as a redirect, so links,
+-- bookmarks and API clients holding the old ID still find the food
+CREATE TABLE food_redirects (
+    old_id VARCHAR(50) PRIMARY KEY,
+    food_id VARCHAR(50) NOT NULL REFERENCES foods(id) ON DELETE CASCADE,
+    merged_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_food_redirects_food_id ON food_redirects(food_id);
+
+-- Merges are recorded in the food history like other catalog edits
+ALTER TABLE food_revisions DROP CONSTRAINT food_revisions_action_check;
+ALTER TABLE food_revisions ADD CONSTRAINT food_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'revert', 'merge'));
+
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_foods_last_imported_at;
+ALTER TABLE foods DROP COLUMN IF EXISTS last_imported_at;
+ALTER TABLE foods DROP COLUMN IF EXISTS retired_at;
@@ -0,0 +1,15 @@
+-- Foods dropped from a newer dataset release are retired rather than
+-- deleted, so ratings, saved foods and intake logs keep pointing at them.
+-- last_imported_at records the import run that last saw a food; foods
+-- created by admins or from submissions have none and are never retired.
+ALTER TABLE foods ADD COLUMN retired_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE foods ADD COLUMN last_imported_at TIMESTAMP WITH TIME ZONE;
+
+-- Foods without a create revision came from an earlier import
+UPDATE foods SET last_imported_at = created_at
+WHERE NOT EXISTS (
+    SELECT 1 FROM food_revisions r
+    WHERE r.food_id = foods.id AND r.action = 'create'
+);
+
+CREATE INDEX idx_foods_last_imported_at ON foods(last_imported_at) WHERE retired_at IS NULL;
@@ -0,0 +1,31 @@
+-- Food imports copy parsed rows here in batches and merge them into foods in
+-- one transaction once the whole file is loaded. Rows keep their line and
+-- original text so that rows rejected during the merge can be reported.
+-- Text columns are unbounded so that COPY never fails on a long value.
+CREATE TABLE food_import_staging (
+    line INTEGER PRIMARY KEY,
+    raw TEXT NOT NULL,
+    id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    alternate_names JSONB,
+    description TEXT,
+    food_type TEXT,
+    source JSONB,
+    serving JSONB,
+    nutrition_100g JSONB,
+    ean_13 TEXT,
+    labels JSONB,
+    package_size JSONB,
+    ingredients TEXT,
+    ingredient_analysis JSONB,
+    invalid_barcode TEXT,
+    nutri_score_grade TEXT,
+    nutri_score_points SMALLINT,
+    nutri_score JSONB,
+    nova_group SMALLINT,
+    nova_markers JSONB,
+    ingredient_tree JSONB,
+    translations JSONB
+);
+
+CREATE INDEX idx_food_import_staging_id ON food_import_staging(id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_foods_dataset_last_imported_at;
+CREATE INDEX idx_foods_last_imported_at ON foods(last_imported_at) WHERE retired_at IS NULL;
+
+ALTER TABLE foods DROP COLUMN IF EXISTS dataset;
@@ -0,0 +1,11 @@
+-- dataset names where an imported food came from, such as opennutrition or
+-- fdc_branded. Retiring the foods missing from a release only considers
+-- foods from the same dataset, so importing one dataset never retires
+-- another's foods.
+ALTER TABLE foods ADD COLUMN dataset VARCHAR(50);
+
+-- Foods imported so far all came from OpenNutrition
+UPDATE foods SET dataset = 'opennutrition' WHERE last_imported_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_foods_last_imported_at;
+CREATE INDEX idx_foods_dataset_last_imported_at ON foods(dataset, last_imported_at) WHERE retired_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_food_import_staging_ean_13;
@@ -0,0 +1,3 @@
+-- Imports that only add foods the catalog lacks reject staged rows whose
+-- barcode is already taken, by a stored food or an earlier row
+CREATE INDEX idx_food_import_staging_ean_13 ON food_import_staging(ean_13) WHERE ean_13 IS NOT NULL;
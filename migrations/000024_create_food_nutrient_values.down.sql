@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS foods_sync_nutrient_values_update ON foods;
+DROP TRIGGER IF EXISTS foods_sync_nutrient_values_insert ON foods;
+DROP FUNCTION IF EXISTS sync_food_nutrient_values();
+DROP TABLE IF EXISTS food_nutrient_values;
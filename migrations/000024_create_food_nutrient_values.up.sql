@@ -0,0 +1,38 @@
+-- Per-100g nutrient values of each food, one row per nutrient, so that the
+-- foods richest in a nutrient can be read off an index instead of sorting
+-- the catalog on a JSONB field. Kept in step with nutrition_100g by a
+-- trigger, since every write path (admin edits, merges, imports) sets it.
+CREATE TABLE food_nutrient_values (
+    food_id VARCHAR(50) NOT NULL REFERENCES foods(id) ON DELETE CASCADE,
+    nutrient TEXT NOT NULL,
+    amount NUMERIC NOT NULL,
+    PRIMARY KEY (food_id, nutrient)
+);
+
+CREATE INDEX idx_food_nutrient_values_nutrient_amount ON food_nutrient_values(nutrient, amount DESC);
+
+CREATE FUNCTION sync_food_nutrient_values() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    DELETE FROM food_nutrient_values WHERE food_id = NEW.id;
+    INSERT INTO food_nutrient_values (food_id, nutrient, amount)
+    SELECT NEW.id, n.key, (n.value #>> '{}')::numeric
+    FROM jsonb_each(coalesce(NEW.nutrition_100g, '{}'::jsonb)) n
+    WHERE jsonb_typeof(n.value) = 'number';
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER foods_sync_nutrient_values_insert
+AFTER INSERT ON foods
+FOR EACH ROW EXECUTE FUNCTION sync_food_nutrient_values();
+
+CREATE TRIGGER foods_sync_nutrient_values_update
+AFTER UPDATE OF nutrition_100g ON foods
+FOR EACH ROW WHEN (OLD.nutrition_100g IS DISTINCT FROM NEW.nutrition_100g)
+EXECUTE FUNCTION sync_food_nutrient_values();
+
+INSERT INTO food_nutrient_values (food_id, nutrient, amount)
+SELECT f.id, n.key, (n.value #>> '{}')::numeric
+FROM foods f, jsonb_each(coalesce(f.nutrition_100g, '{}'::jsonb)) n
+WHERE jsonb_typeof(n.value) = 'number';
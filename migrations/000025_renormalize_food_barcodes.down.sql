@@ -0,0 +1,3 @@
+-- Normalized barcodes are kept, since the original codes are not recorded
+DROP FUNCTION IF EXISTS normalize_gtin(text);
+DROP FUNCTION IF EXISTS gtin_check_digit_valid(text);
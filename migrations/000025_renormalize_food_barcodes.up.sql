@@ -0,0 +1,87 @@
+-- Whether the trailing GS1 check digit of a barcode is right: digits are
+-- weighted 3 and 1 alternately from the right, excluding the check digit
+CREATE FUNCTION gtin_check_digit_valid(digits text) RETURNS boolean
+LANGUAGE plpgsql IMMUTABLE PARALLEL SAFE AS $$
+DECLARE
+    n int := length(digits);
+    total int := 0;
+    d int;
+BEGIN
+    FOR p IN 1..n - 1 LOOP
+        d := substr(digits, p, 1)::int;
+        IF (n - 1 - p) % 2 = 0 THEN
+            d := d * 3;
+        END IF;
+        total := total + d;
+    END LOOP;
+    RETURN (10 - total % 10) % 10 = substr(digits, n, 1)::int;
+END
+$$;
+
+-- The preferred GTIN-13 for an EAN-13, UPC-A, EAN-8 or UPC-E barcode, or
+-- NULL when it is not a valid one. Mirrors food.NormalizeGTIN.
+CREATE FUNCTION normalize_gtin(code text) RETURNS text
+LANGUAGE plpgsql IMMUTABLE PARALLEL SAFE AS $$
+DECLARE
+    digits text := replace(replace(btrim(code), ' ', ''), '-', '');
+    d text;
+    manufacturer text;
+    product text;
+    upc_a text;
+BEGIN
+    IF digits !~ '^[0-9]+$' THEN
+        RETURN NULL;
+    END IF;
+
+    CASE length(digits)
+    WHEN 13 THEN
+        IF gtin_check_digit_valid(digits) THEN
+            RETURN digits;
+        END IF;
+    WHEN 12 THEN
+        IF gtin_check_digit_valid(digits) THEN
+            RETURN '0' || digits;
+        END IF;
+    WHEN 8 THEN
+        -- An EAN-8, or else a UPC-E expanded to UPC-A
+        IF gtin_check_digit_valid(digits) THEN
+            RETURN '00000' || digits;
+        END IF;
+        IF substr(digits, 1, 1) IN ('0', '1') THEN
+            d := substr(digits, 2, 6);
+            CASE substr(d, 6, 1)
+            WHEN '0', '1', '2' THEN
+                manufacturer := substr(d, 1, 2) || substr(d, 6, 1) || '00';
+                product := '00' || substr(d, 3, 3);
+            WHEN '3' THEN
+                manufacturer := substr(d, 1, 3) || '00';
+                product := '000' || substr(d, 4, 2);
+            WHEN '4' THEN
+                manufacturer := substr(d, 1, 4) || '0';
+                product := '0000' || substr(d, 5, 1);
+            ELSE
+                manufacturer := substr(d, 1, 5);
+                product := '0000' || substr(d, 6, 1);
+            END CASE;
+            upc_a := substr(digits, 1, 1) || manufacturer || product || substr(digits, 8, 1);
+            IF gtin_check_digit_valid(upc_a) THEN
+                RETURN '0' || upc_a;
+            END IF;
+        END IF;
+    ELSE
+        NULL;
+    END CASE;
+    RETURN NULL;
+END
+$$;
+
+-- 000010 only padded 12-digit codes. Normalize the rest the way barcode
+-- lookups and imports do, moving codes that fail validation to
+-- invalid_barcode so they can be reviewed.
+UPDATE foods SET ean_13 = NULL, updated_at = CURRENT_TIMESTAMP WHERE btrim(ean_13) = '';
+
+UPDATE foods SET
+    ean_13 = normalize_gtin(ean_13),
+    invalid_barcode = CASE WHEN normalize_gtin(ean_13) IS NULL THEN btrim(ean_13) ELSE invalid_barcode END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE ean_13 IS NOT NULL AND ean_13 IS DISTINCT FROM normalize_gtin(ean_13);
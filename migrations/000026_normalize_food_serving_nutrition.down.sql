@@ -0,0 +1,3 @@
+-- The original spellings are not recorded, so normalized values are kept.
+-- Nothing else to undo: the helper functions are dropped by the up migration.
+SELECT 1;
@@ -0,0 +1,75 @@
+-- Servings and nutrients are decoded into typed fields and encoded back,
+-- which spells "quantity" as "amount", drops zero amounts and empty units and
+-- writes numeric strings as numbers. Rewrite the stored values the same way
+-- once, so that foods read and written back, and unchanged foods in a newer
+-- import, compare equal to what is stored.
+
+-- A JSON number, or a string holding one, as a number; NULL for anything else
+CREATE FUNCTION normalize_json_number(v jsonb) RETURNS jsonb
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE
+        WHEN jsonb_typeof(v) = 'number' THEN v
+        WHEN jsonb_typeof(v) = 'string' AND btrim(v #>> '{}') ~ '^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$'
+            THEN to_jsonb(btrim(v #>> '{}')::numeric)
+    END
+$$;
+
+-- A serving as food.Serving encodes it
+CREATE FUNCTION normalize_serving_json(s jsonb) RETURNS jsonb
+LANGUAGE plpgsql IMMUTABLE PARALLEL SAFE AS $$
+DECLARE
+    field text;
+    n jsonb;
+BEGIN
+    IF s IS NULL OR jsonb_typeof(s) = 'null' THEN
+        RETURN '{}'::jsonb;
+    END IF;
+    IF jsonb_typeof(s) <> 'object' THEN
+        RETURN s;
+    END IF;
+
+    IF NOT s ? 'amount' AND s ? 'quantity' THEN
+        s := (s - 'quantity') || jsonb_build_object('amount', s -> 'quantity');
+    END IF;
+    FOREACH field IN ARRAY ARRAY['amount', 'grams'] LOOP
+        n := normalize_json_number(s -> field);
+        IF n IS NOT NULL THEN
+            IF (n #>> '{}')::numeric = 0 THEN
+                s := s - field;
+            ELSE
+                s := jsonb_set(s, ARRAY[field], n);
+            END IF;
+        END IF;
+    END LOOP;
+    IF s -> 'unit' = '""'::jsonb THEN
+        s := s - 'unit';
+    END IF;
+    RETURN s;
+END
+$$;
+
+-- Nutrients as food.Nutrients encodes them
+CREATE FUNCTION normalize_nutrients_json(n jsonb) RETURNS jsonb
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE
+        WHEN n IS NULL OR jsonb_typeof(n) = 'null' THEN '{}'::jsonb
+        WHEN jsonb_typeof(n) <> 'object' THEN n
+        ELSE coalesce(
+            (SELECT jsonb_object_agg(e.key, coalesce(normalize_json_number(e.value), e.value))
+             FROM jsonb_each(n) e),
+            '{}'::jsonb
+        )
+    END
+$$;
+
+UPDATE foods SET
+    serving = normalize_serving_json(serving),
+    package_size = normalize_serving_json(package_size),
+    nutrition_100g = normalize_nutrients_json(nutrition_100g)
+WHERE serving IS DISTINCT FROM normalize_serving_json(serving)
+   OR package_size IS DISTINCT FROM normalize_serving_json(package_size)
+   OR nutrition_100g IS DISTINCT FROM normalize_nutrients_json(nutrition_100g);
+
+DROP FUNCTION normalize_nutrients_json(jsonb);
+DROP FUNCTION normalize_serving_json(jsonb);
+DROP FUNCTION normalize_json_number(jsonb);
@@ -0,0 +1,43 @@
+-- Whether a food, given its ID and search vector, or its translation into a
+-- locale matches a full-text query
+CREATE FUNCTION food_search_matches(food_id text, search_vector tsvector, query tsquery, locale text) RETURNS boolean
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT $2 @@ $3 OR EXISTS (
+        SELECT 1 FROM food_translations ft
+        WHERE ft.food_id = $1 AND ft.locale = $4 AND ft.search_vector @@ $3
+    )
+$$;
+
+-- Relevance of a food to a full-text query: the better of the rank of the
+-- food and of its translation into the locale. Weights rank name matches
+-- above alternate names, then description, then ingredients.
+CREATE FUNCTION food_search_rank(food_id text, search_vector tsvector, query tsquery, locale text) RETURNS float8
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT GREATEST(
+        ts_rank('{0.1, 0.2, 0.4, 1.0}', $2, $3),
+        (SELECT ts_rank('{0.1, 0.2, 0.4, 1.0}', ft.search_vector, $3)
+         FROM food_translations ft
+         WHERE ft.food_id = $1 AND ft.locale = $4)
+    )::float8
+$$;
+
+-- Whether a food name, or the name of its translation into a locale, is
+-- similar to a possibly misspelled query
+CREATE FUNCTION food_name_matches(food_id text, name text, query text, locale text) RETURNS boolean
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT $3 <% $2 OR EXISTS (
+        SELECT 1 FROM food_translations ft
+        WHERE ft.food_id = $1 AND ft.locale = $4 AND $3 <% ft.name
+    )
+$$;
+
+-- Trigram similarity of a query to a food name or its translated name
+CREATE FUNCTION food_name_similarity(food_id text, name text, query text, locale text) RETURNS float8
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT GREATEST(
+        word_similarity($3, $2),
+        (SELECT word_similarity($3, ft.name)
+         FROM food_translations ft
+         WHERE ft.food_id = $1 AND ft.locale = $4)
+    )::float8
+$$;
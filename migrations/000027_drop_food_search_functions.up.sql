@@ -0,0 +1,7 @@
+-- The search queries match and rank foods and their translations inline.
+-- Subqueries kept these functions from being inlined, so the planner could
+-- not use the GIN and trigram indexes on foods and food_translations.
+DROP FUNCTION IF EXISTS food_search_matches(text, tsvector, tsquery, text);
+DROP FUNCTION IF EXISTS food_search_rank(text, tsvector, tsquery, text);
+DROP FUNCTION IF EXISTS food_name_matches(text, text, text, text);
+DROP FUNCTION IF EXISTS food_name_similarity(text, text, text, text);
@@ -0,0 +1,2 @@
+-- The groups are classified again at startup; there is nothing to restore.
+SELECT 1;
@@ -0,0 +1,6 @@
+-- NOVA groups were classified from every string in the ingredient analysis
+-- and from culinary ingredients found inside longer names, so that "sugar snap
+-- peas" counted as sugar. Clear them so that the NOVA backfill run at startup
+-- classifies every food again.
+UPDATE foods SET nova_group = NULL, nova_markers = NULL
+WHERE nova_group IS NOT NULL;
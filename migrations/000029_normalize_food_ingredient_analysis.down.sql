@@ -0,0 +1,2 @@
+-- Empty analyses cannot be told apart from missing ones, so they are kept.
+SELECT 1;
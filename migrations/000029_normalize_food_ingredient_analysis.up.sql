@@ -0,0 +1,5 @@
+-- The ingredient analysis is decoded into a typed struct and encoded back,
+-- which writes a missing analysis as an empty object. Store missing analyses
+-- the same way, so that unchanged foods in a newer import compare equal.
+UPDATE foods SET ingredient_analysis = '{}'::jsonb
+WHERE ingredient_analysis IS NULL OR jsonb_typeof(ingredient_analysis) = 'null';
@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS filter_foods(text, text, text, jsonb, jsonb, boolean, jsonb, jsonb);
@@ -0,0 +1,37 @@
+-- Foods still in the catalog that pass the filters of the filtered search: a
+-- full-text query in a locale, food type, all of labels_all, any of
+-- labels_any, barcode presence, Nutri-Score grades and nutrient ranges. NULL
+-- filters match every food. The filtered search, its count and its facets all
+-- select from it; a single-statement SQL function is inlined into the calling
+-- query, so the search indexes are still used.
+CREATE FUNCTION filter_foods(
+    query text, locale text, food_type text, labels_all jsonb, labels_any jsonb,
+    has_barcode boolean, nutri_score_grades jsonb, nutrient_ranges jsonb
+) RETURNS SETOF foods
+LANGUAGE sql STABLE PARALLEL SAFE AS $$
+    SELECT * FROM foods f
+    WHERE f.retired_at IS NULL
+      AND ($1 IS NULL OR f.id IN (
+          SELECT id FROM foods WHERE search_vector @@ food_search_query($1, $2)
+          UNION
+          SELECT ft.food_id FROM food_translations ft
+          WHERE ft.locale = $2 AND ft.search_vector @@ food_search_query($1, $2)
+      ))
+      AND ($3 IS NULL OR f.food_type = $3)
+      AND ($4 IS NULL OR f.labels @> $4)
+      AND ($5 IS NULL OR EXISTS (
+          SELECT 1 FROM jsonb_array_elements_text($5) AS wanted(label)
+          WHERE f.labels ? wanted.label
+      ))
+      AND ($6 IS NULL OR (COALESCE(f.ean_13, '') <> '') = $6)
+      AND ($7 IS NULL OR f.nutri_score_grade IN (SELECT jsonb_array_elements_text($7)))
+      AND NOT EXISTS (
+          SELECT 1 FROM jsonb_to_recordset(COALESCE($8, '[]'::jsonb)) AS r(nutrient text, min numeric, max numeric)
+          WHERE CASE
+              WHEN jsonb_typeof(f.nutrition_100g -> r.nutrient) = 'number' THEN
+                  (r.min IS NOT NULL AND (f.nutrition_100g ->> r.nutrient)::numeric < r.min) OR
+                  (r.max IS NOT NULL AND (f.nutrition_100g ->> r.nutrient)::numeric > r.max)
+              ELSE TRUE
+          END
+      )
+$$;
@@ -79,6 +79,16 @@ func PaginationMeta(page, limit, total int) map[string]interface{} {
 	}
 }
 
+// CursorPaginationMeta creates pagination metadata with the cursor of the next
+// page, omitted on the last page
+func CursorPaginationMeta(page, limit, total int, nextCursor string) map[string]interface{} {
+	meta := PaginationMeta(page, limit, total)
+	if nextCursor != "" {
+		meta["next_cursor"] = nextCursor
+	}
+	return meta
+}
+
 // NoContent sends a 204 No Content response
 func NoContent(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/yeboahd24/nutrimatch/internal/config"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres"
+)
+
+// searchCheck is a search query and the indexes its plan must use
+type searchCheck struct {
+	query   string
+	indexes []string
+}
+
+var searchChecks = []searchCheck{
+	{"SearchFoods", []string{"idx_foods_search_vector", "idx_food_translations_search_vector"}},
+	{"CountSearchFoods", []string{"idx_foods_search_vector", "idx_food_translations_search_vector"}},
+	{"FuzzySearchFoods", []string{"idx_foods_name_trgm", "idx_food_translations_name_trgm"}},
+	{"CountFuzzySearchFoods", []string{"idx_foods_name_trgm", "idx_food_translations_name_trgm"}},
+	{"FilterFoods", []string{"idx_foods_search_vector", "idx_food_translations_search_vector"}},
+	{"CountFilteredFoods", []string{"idx_foods_search_vector", "idx_food_translations_search_vector"}},
+	{"FacetFoodTypes", []string{"idx_foods_search_vector", "idx_food_translations_search_vector"}},
+}
+
+var queryArg = regexp.MustCompile(`sqlc\.n?arg\((\w+)\)`)
+
+func main() {
+	// Parse command line flags
+	queriesPath := flag.String("queries", "internal/repository/postgres/query/foods.sql", "File holding the search queries")
+	search := flag.String("q", "apple", "Search text")
+	locale := flag.String("locale", "fr-FR", "Locale to search in")
+	flag.Parse()
+
+	source, err := os.ReadFile(*queriesPath)
+	if err != nil {
+		log.Fatalf("Failed to read queries: %v", err)
+	}
+	queries := splitQueries(string(source))
+
+	// Values for the named query arguments; nil leaves optional filters unset
+	values := map[string]interface{}{
+		"query":              *search,
+		"locale":             *locale,
+		"sort_by":            "relevance",
+		"nutrient_ranges":    "[]",
+		"histogram_bounds":   "{}",
+		"row_limit":          20,
+		"row_offset":         0,
+		"label_limit":        20,
+		"after_rank":         0,
+		"after_similarity":   0,
+		"after_points":       0,
+		"after_name":         "",
+		"after_id":           nil,
+		"food_type":          nil,
+		"labels_all":         nil,
+		"labels_any":         nil,
+		"has_barcode":        nil,
+		"nutri_score_grades": nil,
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Connect to database
+	db, err := postgres.NewDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	failed := false
+	for _, check := range searchChecks {
+		text, ok := queries[check.query]
+		if !ok {
+			log.Fatalf("Query %s not found in %s", check.query, *queriesPath)
+		}
+		sqlText, args, err := bindArgs(text, values)
+		if err != nil {
+			log.Fatalf("%s: %v", check.query, err)
+		}
+		used, err := explainIndexes(context.Background(), db, sqlText, args)
+		if err != nil {
+			log.Fatalf("Failed to explain %s: %v", check.query, err)
+		}
+
+		var missing []string
+		for _, index := range check.indexes {
+			if !used[index] {
+				missing = append(missing, index)
+			}
+		}
+		if len(missing) > 0 {
+			failed = true
+			fmt.Printf("FAIL %s: does not use %s\n", check.query, strings.Join(missing, ", "))
+			continue
+		}
+		fmt.Printf("ok   %s\n", check.query)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// splitQueries returns the queries in a query file by their "-- name:" header
+func splitQueries(source string) map[string]string {
+	queries := map[string]string{}
+	var name string
+	var body strings.Builder
+	flush := func() {
+		if name != "" {
+			queries[name] = strings.TrimSuffix(strings.TrimSpace(body.String()), ";")
+		}
+		body.Reset()
+	}
+	for _, line := range strings.Split(source, "\n") {
+		if strings.HasPrefix(line, "-- name:") {
+			flush()
+			name = strings.Fields(strings.TrimPrefix(line, "-- name:"))[0]
+			continue
+		}
+		body.WriteString(line)
+		body.WriteString("\n")
+	}
+	flush()
+	return queries
+}
+
+// bindArgs replaces the named arguments in a query with numbered parameters,
+// numbering repeated names once
+func bindArgs(query string, values map[string]interface{}) (string, []interface{}, error) {
+	positions := map[string]int{}
+	var args []interface{}
+	var missing []string
+	bound := queryArg.ReplaceAllStringFunc(query, func(arg string) string {
+		name := queryArg.FindStringSubmatch(arg)[1]
+		if _, ok := positions[name]; !ok {
+			value, ok := values[name]
+			if !ok {
+				missing = append(missing, name)
+			}
+			args = append(args, value)
+			positions[name] = len(args)
+		}
+		return fmt.Sprintf("$%d", positions[name])
+	})
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("no value for %s", strings.Join(missing, ", "))
+	}
+	return bound, args, nil
+}
+
+// planNode is a node of a JSON query plan
+type planNode struct {
+	IndexName string     `json:"Index Name"`
+	Plans     []planNode `json:"Plans"`
+}
+
+// explainIndexes returns the indexes in the plan of a query. Sequential scans
+// are disabled so that a small catalog still shows whether the indexes can be
+// used at all.
+func explainIndexes(ctx context.Context, db *sql.DB, query string, args []interface{}) (map[string]bool, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+		return nil, err
+	}
+	var plan []byte
+	if err := tx.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&plan); err != nil {
+		return nil, err
+	}
+
+	var plans []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &plans); err != nil {
+		return nil, err
+	}
+	used := map[string]bool{}
+	var walk func(planNode)
+	walk = func(n planNode) {
+		if n.IndexName != "" {
+			used[n.IndexName] = true
+		}
+		for _, child := range n.Plans {
+			walk(child)
+		}
+	}
+	for _, p := range plans {
+		walk(p.Plan)
+	}
+	return used, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/config"
+	"github.com/yeboahd24/nutrimatch/internal/domain/food"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	format := flag.String("format", food.ExportNDJSON, "Export format: ndjson or csv")
+	foodType := flag.String("type", "", "Only export foods of this type")
+	updatedSince := flag.String("updated-since", "", "Only export foods updated at or after this time (RFC 3339 or YYYY-MM-DD)")
+	includeRetired := flag.Bool("include-retired", false, "Also export retired foods")
+	output := flag.String("out", "", "Output file (default stdout)")
+	flag.Parse()
+
+	filter := food.ExportFilter{FoodType: *foodType, IncludeRetired: *includeRetired}
+	if *updatedSince != "" {
+		since, err := time.Parse(time.RFC3339, *updatedSince)
+		if err != nil {
+			since, err = time.Parse("2006-01-02", *updatedSince)
+		}
+		if err != nil {
+			log.Fatalf("Invalid -updated-since %q, expected RFC 3339 or YYYY-MM-DD", *updatedSince)
+		}
+		filter.UpdatedSince = &since
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Connect to database
+	db, err := postgres.NewDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Write to stdout unless an output file is given, keeping logs on stderr
+	out := os.Stdout
+	if *output != "" {
+		out, err = os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer out.Close()
+	}
+	w := bufio.NewWriterSize(out, 64*1024)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	exportService := service.NewExportService(postgres.NewFoodExportRepository(db, postgres.NewQueries(db)), logger)
+
+	count, err := exportService.ExportFoods(ctx, filter, *format, w)
+	if err != nil {
+		log.Fatalf("Export failed after %d foods: %v", count, err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("Failed to write export: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/yeboahd24/nutrimatch/internal/config"
+	"github.com/yeboahd24/nutrimatch/internal/repository/postgres"
+	"github.com/yeboahd24/nutrimatch/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	batchSize := flag.Int("batch", 1000, "Rows copied into the staging table at a time")
+	workers := flag.Int("workers", runtime.NumCPU(), "Goroutines parsing rows")
+	format := flag.String("format", service.ImportFormatTSV, "Input format: tsv for OpenNutrition, fdc for a USDA FoodData Central JSON download, or off for the Open Food Facts JSONL dump")
+	mode := flag.String("mode", service.ImportInsert, "Import mode: insert, or upsert to update foods from a newer release")
+	retire := flag.Bool("retire", false, "Retire previously imported foods missing from the file (upsert only)")
+	checkpoint := flag.String("checkpoint", "", "Checkpoint file (default <file>.checkpoint)")
+	rejects := flag.String("rejects", "", "File rejected rows are written to (default <file>.rejects.tsv)")
+	resume := flag.Bool("resume", false, "Resume an interrupted import from its checkpoint")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("Usage: go run scripts/import.go [-format tsv|fdc|off] [-mode insert|upsert] [-retire] [-batch n] [-workers n] [-checkpoint file] [-rejects file] [-resume] <path-to-file>")
+	}
+	filePath := flag.Arg(0)
+	opts := service.ImportOptions{
+		Format:         *format,
+		BatchSize:      *batchSize,
+		Workers:        *workers,
+		Mode:           *mode,
+		Retire:         *retire,
+		CheckpointPath: *checkpoint,
+		RejectPath:     *rejects,
+		Resume:         *resume,
+	}
+	if opts.CheckpointPath == "" {
+		opts.CheckpointPath = filePath + ".checkpoint"
+	}
+	if opts.RejectPath == "" {
+		opts.RejectPath = filePath + ".rejects.tsv"
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Connect to database
+	db, err := postgres.NewDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Stop after the batch being staged on interrupt, leaving the checkpoint to resume from
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	importer := service.NewFoodImporter(db, postgres.NewQueries(db), logger)
+
+	log.Printf("Starting import from %s", filePath)
+	startTime := time.Now()
+
+	result, err := importer.Import(ctx, filePath, opts)
+	if errors.Is(err, service.ErrInvalidImportFormat) || errors.Is(err, service.ErrInvalidImportMode) || errors.Is(err, service.ErrRetireRequiresUpsert) || errors.Is(err, service.ErrImportRunning) {
+		log.Fatalf("Import failed: %v", err)
+	}
+	if errors.Is(err, service.ErrCheckpointMismatch) {
+		log.Fatalf("Import failed: %v; delete %s to import from the start", err, opts.CheckpointPath)
+	}
+	if err != nil {
+		log.Fatalf("Import stopped: %v; rerun with -resume to continue", err)
+	}
+
+	duration := time.Since(startTime)
+	log.Printf("Import completed in %v (%.0f rows/sec)", duration, result.RowsPerSecond)
+	if err := result.WriteSummary(os.Stdout); err != nil {
+		log.Fatalf("Failed to write summary: %v", err)
+	}
+	if result.Rejected > 0 {
+		log.Printf("Rejected rows were written to %s", opts.RejectPath)
+	}
+}